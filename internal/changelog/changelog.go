@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"sync"
 	"text/template"
@@ -24,45 +25,64 @@ const defaultChangelogTemplate = `{{- if .Version}}## {{if .Project}}{{.Project}
 
 {{end}}`
 
+// changelogTemplate is a parsed changelog template and the pattern of the
+// release headings it renders
+type changelogTemplate struct {
+	tmpl   *template.Template
+	header *regexp.Regexp
+}
+
 var (
-	templateCache     = make(map[string]*template.Template)
+	templateCache     = make(map[string]*changelogTemplate)
 	templateCacheLock sync.Mutex
 )
 
-func getChangelogTemplate(fs filesystem.FileSystem, projectRoot string) (*template.Template, error) {
-	path := findCustomTemplate(fs, projectRoot)
+func getChangelogTemplate(fs filesystem.FileSystem, projectRoot, templatePath string) (*changelogTemplate, error) {
+	path := templatePath
+	if path == "" {
+		path = findCustomTemplate(fs, projectRoot)
+	}
 	cacheKey := path
 	if cacheKey == "" {
 		cacheKey = "__default__"
 	}
 
 	templateCacheLock.Lock()
-	tmpl, ok := templateCache[cacheKey]
+	cached, ok := templateCache[cacheKey]
 	templateCacheLock.Unlock()
 	if ok {
-		return tmpl, nil
+		return cached, nil
 	}
 
-	var parsed *template.Template
-	var err error
+	text := defaultChangelogTemplate
+	header := DefaultHeaderPattern
 	if path != "" {
 		data, readErr := fs.ReadFile(path)
 		if readErr != nil {
 			return nil, fmt.Errorf("failed to read changelog template: %w", readErr)
 		}
-		parsed, err = template.New("changelog").Funcs(sprig.TxtFuncMap()).Parse(string(data))
-	} else {
-		parsed, err = template.New("changelog").Funcs(sprig.TxtFuncMap()).Parse(defaultChangelogTemplate)
+		text = string(data)
+
+		declared, err := headerPatternOf(text)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse changelog template %s: %w", path, err)
+		}
+		if declared != nil {
+			header = declared
+		}
 	}
+
+	parsed, err := template.New("changelog").Funcs(sprig.TxtFuncMap()).Parse(text)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse changelog template: %w", err)
 	}
+	cached = &changelogTemplate{tmpl: parsed, header: header}
 
 	templateCacheLock.Lock()
-	templateCache[cacheKey] = parsed
+	templateCache[cacheKey] = cached
 	templateCacheLock.Unlock()
 
-	return parsed, nil
+	return cached, nil
 }
 
 func findCustomTemplate(fs filesystem.FileSystem, start string) string {
@@ -83,7 +103,9 @@ func findCustomTemplate(fs filesystem.FileSystem, start string) string {
 
 // Changelog handles reading and writing CHANGELOG.md files
 type Changelog struct {
-	fs filesystem.FileSystem
+	fs           filesystem.FileSystem
+	templatePath string
+	categories   []models.Category
 }
 
 // NewChangelog creates a new Changelog instance
@@ -91,16 +113,115 @@ func NewChangelog(fs filesystem.FileSystem) *Changelog {
 	return &Changelog{fs: fs}
 }
 
+// WithTemplate uses the template at path instead of searching for
+// .changeset/changelog.tmpl. An empty path keeps the search.
+func (cl *Changelog) WithTemplate(path string) *Changelog {
+	cl.templatePath = path
+	return cl
+}
+
+// WithCategories sets the change categories and their order for the
+// Categories of the template data. Nil keeps models.DefaultCategories.
+func (cl *Changelog) WithCategories(categories []models.Category) *Changelog {
+	cl.categories = categories
+	return cl
+}
+
 // Entry represents an entry to be added to the changelog
 type Entry struct {
 	Version    *models.Version
 	Date       time.Time
 	Changesets []*models.Changeset
+
+	// Dependencies are workspace dependencies released with new versions,
+	// listed in an "Updated Dependencies" section
+	Dependencies []Dependency
+}
+
+// Dependency is a workspace dependency and its new version
+type Dependency struct {
+	Name    string
+	Version *models.Version
 }
 
 // Append adds a new entry to the changelog
 func (cl *Changelog) Append(projectRoot string, projectName string, entry *Entry) error {
-	changelogPath := filepath.Join(projectRoot, changelogFileName)
+	newEntry, err := cl.formatEntry(entry, projectName, projectRoot)
+	if err != nil {
+		return err
+	}
+
+	return cl.prepend(projectRoot, newEntry)
+}
+
+// ProjectEntry is the entry of a single project in a batch
+type ProjectEntry struct {
+	Project string
+	Entry   *Entry
+}
+
+// AppendBatch adds one entry for a batch of project releases, e.g. to the
+// CHANGELOG.md at the workspace root. The entries of the projects are nested
+// below a heading with the date of the batch.
+func (cl *Changelog) AppendBatch(root string, date time.Time, entries []ProjectEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	fmt.Fprintf(&buf, "## %s\n\n", date.Format("2006-01-02"))
+	for _, e := range entries {
+		formatted, err := cl.formatEntry(e.Entry, e.Project, root)
+		if err != nil {
+			return err
+		}
+		buf.WriteString(demoteHeadings(formatted))
+	}
+
+	return cl.prepend(root, buf.String())
+}
+
+// headingLine matches a heading that can be demoted, e.g. "## Title"
+var headingLine = regexp.MustCompile(`^#{1,5}(?: |$)`)
+
+// demoteHeadings moves every markdown heading one level down, as far as level
+// six. Lines in fenced code blocks and text such as #hashtag are kept.
+func demoteHeadings(markdown string) string {
+	lines := strings.Split(markdown, "\n")
+	fence := ""
+	for i, line := range lines {
+		trimmed := strings.TrimLeft(line, " \t")
+		if fence != "" {
+			if strings.HasPrefix(trimmed, fence) && strings.Trim(trimmed, fence[:1]+" \t") == "" {
+				fence = ""
+			}
+			continue
+		}
+		if marker := fenceMarker(trimmed); marker != "" {
+			fence = marker
+			continue
+		}
+		if headingLine.MatchString(line) {
+			lines[i] = "#" + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// fenceMarker returns the ``` or ~~~ run opening a fenced code block, if any
+func fenceMarker(line string) string {
+	for _, c := range []string{"`", "~"} {
+		n := len(line) - len(strings.TrimLeft(line, c))
+		if n >= 3 {
+			return line[:n]
+		}
+	}
+	return ""
+}
+
+// prepend inserts newEntry above the existing entries of the changelog in dir.
+func (cl *Changelog) prepend(dir string, newEntry string) error {
+	changelogPath := filepath.Join(dir, changelogFileName)
 
 	var existingContent string
 	if cl.fs.Exists(changelogPath) {
@@ -111,42 +232,23 @@ func (cl *Changelog) Append(projectRoot string, projectName string, entry *Entry
 		existingContent = string(data)
 	}
 
-	newEntry, err := cl.formatEntry(entry, projectName, projectRoot)
-	if err != nil {
-		return err
-	}
-
 	var buf bytes.Buffer
 
 	if strings.Contains(existingContent, "# Changelog") {
-		lines := strings.Split(existingContent, "\n")
-		headerEndIdx := 0
-		for i, line := range lines {
-			if strings.HasPrefix(line, "## ") {
-				headerEndIdx = i
-				break
-			}
+		header, err := cl.headerPattern(dir)
+		if err != nil {
+			return err
 		}
-		if headerEndIdx > 0 {
-			buf.WriteString(strings.Join(lines[0:headerEndIdx], "\n"))
-			buf.WriteString("\n")
+		doc := Parse(existingContent, header)
+		buf.WriteString(doc.Preamble)
+		buf.WriteString(newEntry)
+		for _, release := range doc.Releases {
+			buf.WriteString(release.String())
 		}
 	} else {
 		buf.WriteString("# Changelog\n\n")
 		buf.WriteString("All notable changes to this project will be documented in this file.\n\n\n")
-	}
-
-	buf.WriteString(newEntry)
-
-	if strings.Contains(existingContent, "# Changelog") {
-		lines := strings.Split(existingContent, "\n")
-		for i, line := range lines {
-			if strings.HasPrefix(line, "## ") {
-				buf.WriteString(strings.Join(lines[i:], "\n"))
-				break
-			}
-		}
-	} else {
+		buf.WriteString(newEntry)
 		buf.WriteString(existingContent)
 	}
 
@@ -159,14 +261,14 @@ func (cl *Changelog) Append(projectRoot string, projectName string, entry *Entry
 
 // FormatEntry generates changelog content without version header.
 func (cl *Changelog) FormatEntry(changesets []*models.Changeset, projectName, projectRoot string) (string, error) {
-	return cl.formatWithTemplate(changesets, projectName, projectRoot, "", time.Time{})
+	return cl.formatWithTemplate(changesets, nil, projectName, projectRoot, "", time.Time{})
 }
 
 func (cl *Changelog) formatEntry(entry *Entry, projectName, projectRoot string) (string, error) {
-	return cl.formatWithTemplate(entry.Changesets, projectName, projectRoot, entry.Version.String(), entry.Date)
+	return cl.formatWithTemplate(entry.Changesets, entry.Dependencies, projectName, projectRoot, entry.Version.String(), entry.Date)
 }
 
-func (cl *Changelog) formatWithTemplate(changesets []*models.Changeset, projectName, projectRoot, version string, date time.Time) (string, error) {
+func (cl *Changelog) formatWithTemplate(changesets []*models.Changeset, dependencies []Dependency, projectName, projectRoot, version string, date time.Time) (string, error) {
 	root := projectRoot
 	if root == "" {
 		cwd, err := cl.fs.Getwd()
@@ -175,15 +277,15 @@ func (cl *Changelog) formatWithTemplate(changesets []*models.Changeset, projectN
 		}
 	}
 
-	tmpl, err := getChangelogTemplate(cl.fs, root)
+	tmpl, err := getChangelogTemplate(cl.fs, root, cl.templatePath)
 	if err != nil {
 		return "", err
 	}
 
-	data := cl.buildTemplateData(changesets, projectName, version, date)
+	data := cl.buildTemplateData(changesets, dependencies, projectName, version, date)
 
 	var buf bytes.Buffer
-	if err := tmpl.Execute(&buf, data); err != nil {
+	if err := tmpl.tmpl.Execute(&buf, data); err != nil {
 		return "", fmt.Errorf("failed to execute changelog template: %w", err)
 	}
 
@@ -191,15 +293,25 @@ func (cl *Changelog) formatWithTemplate(changesets []*models.Changeset, projectN
 }
 
 type changelogTemplateData struct {
-	Project  string
-	Version  string
-	Date     string
+	Project string
+	Version string
+	Date    string
+
+	// Sections group the changes by bump type
 	Sections []changelogTemplateSection
-	Items    []changelogTemplateItem
+
+	// Categories group the changes by category, in the configured order.
+	// Changes without a category are in a last "Other Changes" section.
+	Categories []changelogTemplateSection
+
+	Items []changelogTemplateItem
 }
 
 type changelogTemplateSection struct {
 	Title string
+
+	// Type is the category of a category section
+	Type  string
 	Items []changelogTemplateItem
 }
 
@@ -207,9 +319,11 @@ type changelogTemplateItem struct {
 	FirstLine string
 	RestLines []string
 	PR        *models.PullRequest
+	Bump      models.BumpType
+	Type      string
 }
 
-func (cl *Changelog) buildTemplateData(changesets []*models.Changeset, projectName, version string, date time.Time) changelogTemplateData {
+func (cl *Changelog) buildTemplateData(changesets []*models.Changeset, dependencies []Dependency, projectName, version string, date time.Time) changelogTemplateData {
 	data := changelogTemplateData{
 		Project: projectName,
 		Version: version,
@@ -220,7 +334,7 @@ func (cl *Changelog) buildTemplateData(changesets []*models.Changeset, projectNa
 
 	sections := buildSections(changesets, projectName)
 	for _, section := range sections {
-		items := buildTemplateItems(section.Changesets)
+		items := buildTemplateItems(section.Changesets, projectName)
 		data.Sections = append(data.Sections, changelogTemplateSection{
 			Title: section.Title,
 			Items: items,
@@ -228,10 +342,35 @@ func (cl *Changelog) buildTemplateData(changesets []*models.Changeset, projectNa
 		data.Items = append(data.Items, items...)
 	}
 
+	categories := cl.categories
+	if categories == nil {
+		categories = models.DefaultCategories
+	}
+	for _, section := range buildCategorySections(changesets, projectName, categories) {
+		data.Categories = append(data.Categories, changelogTemplateSection{
+			Title: section.Title,
+			Type:  section.Type,
+			Items: buildTemplateItems(section.Changesets, projectName),
+		})
+	}
+
+	if len(dependencies) > 0 {
+		items := make([]changelogTemplateItem, 0, len(dependencies))
+		for _, dep := range dependencies {
+			items = append(items, changelogTemplateItem{
+				FirstLine: fmt.Sprintf("%s@%s", dep.Name, dep.Version.String()),
+			})
+		}
+		data.Sections = append(data.Sections, changelogTemplateSection{
+			Title: "Updated Dependencies",
+			Items: items,
+		})
+	}
+
 	return data
 }
 
-func buildTemplateItems(changesets []*models.Changeset) []changelogTemplateItem {
+func buildTemplateItems(changesets []*models.Changeset, projectName string) []changelogTemplateItem {
 	items := make([]changelogTemplateItem, 0, len(changesets))
 
 	for _, cs := range changesets {
@@ -240,42 +379,87 @@ func buildTemplateItems(changesets []*models.Changeset) []changelogTemplateItem
 			continue
 		}
 
+		bump, _ := determineBump(cs, projectName)
 		items = append(items, changelogTemplateItem{
 			FirstLine: first,
 			RestLines: rest,
 			PR:        cs.PR,
+			Bump:      bump,
+			Type:      cs.GetTypeForProject(projectName),
 		})
 	}
 
 	return items
 }
 
-// GetEntryForVersion reads the changelog entry for a specific version
-func (cl *Changelog) GetEntryForVersion(projectRoot string, version *models.Version) (string, error) {
+// headerPattern returns the release heading pattern of the template used in dir
+func (cl *Changelog) headerPattern(dir string) (*regexp.Regexp, error) {
+	tmpl, err := getChangelogTemplate(cl.fs, dir, cl.templatePath)
+	if err != nil {
+		return nil, err
+	}
+	return tmpl.header, nil
+}
+
+// Read parses the CHANGELOG.md in projectRoot, using the header pattern of
+// the changelog template
+func (cl *Changelog) Read(projectRoot string) (*Document, error) {
 	changelogPath := filepath.Join(projectRoot, changelogFileName)
 
 	if !cl.fs.Exists(changelogPath) {
-		return "", fmt.Errorf("changelog not found")
+		return nil, fmt.Errorf("changelog not found")
 	}
 
 	data, err := cl.fs.ReadFile(changelogPath)
 	if err != nil {
-		return "", fmt.Errorf("failed to read changelog: %w", err)
+		return nil, fmt.Errorf("failed to read changelog: %w", err)
+	}
+
+	header, err := cl.headerPattern(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(string(data), header), nil
+}
+
+// Versions returns every version of projectName in the changelog, newest first
+func (cl *Changelog) Versions(projectRoot, projectName string) ([]*models.Version, error) {
+	doc, err := cl.Read(projectRoot)
+	if err != nil {
+		return nil, err
 	}
+	return doc.Versions(projectName), nil
+}
 
-	content := string(data)
-	versionHeader := fmt.Sprintf("## %s", version.String())
+// GetEntryForVersion reads the changelog entry of projectName for a specific
+// version, including its heading
+func (cl *Changelog) GetEntryForVersion(projectRoot, projectName string, version *models.Version) (string, error) {
+	release, err := cl.getRelease(projectRoot, projectName, version)
+	if err != nil {
+		return "", err
+	}
+	return release.String(), nil
+}
 
-	startIdx := strings.Index(content, versionHeader)
-	if startIdx == -1 {
-		return "", fmt.Errorf("version %s not found in changelog", version.String())
+// GetReleaseNotes reads the changelog entry of projectName for a specific
+// version without its heading, e.g. for the release notes of a GitHub release
+func (cl *Changelog) GetReleaseNotes(projectRoot, projectName string, version *models.Version) (string, error) {
+	release, err := cl.getRelease(projectRoot, projectName, version)
+	if err != nil {
+		return "", err
 	}
+	return release.Notes(), nil
+}
 
-	endIdx := strings.Index(content[startIdx+len(versionHeader):], "\n## ")
-	if endIdx == -1 {
-		return content[startIdx:], nil
+func (cl *Changelog) getRelease(projectRoot, projectName string, version *models.Version) (*Release, error) {
+	doc, err := cl.Read(projectRoot)
+	if err != nil {
+		return nil, err
 	}
 
-	endIdx += startIdx + len(versionHeader)
-	return content[startIdx:endIdx], nil
+	release := doc.Find(projectName, version)
+	if release == nil {
+		return nil, fmt.Errorf("version %s not found in changelog", version.String())
+	}
+	return release, nil
 }
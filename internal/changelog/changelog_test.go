@@ -5,7 +5,6 @@ import (
 	"path/filepath"
 	"strings"
 	"testing"
-	"text/template"
 	"time"
 
 	"github.com/gkampitakis/go-snaps/snaps"
@@ -297,6 +296,33 @@ func TestChangelog_CustomTemplateOverride(t *testing.T) {
 	snaps.MatchSnapshot(t, output)
 }
 
+func TestChangelog_FormatEntry_Categories(t *testing.T) {
+	t.Cleanup(func() {
+		resetChangelogTemplateCache()
+	})
+
+	fs := filesystem.NewMockFileSystem()
+	templateContent := "{{- range .Categories}}\n### {{.Title}}\n{{range .Items}}- {{.FirstLine}} ({{.Bump}})\n{{end}}{{end}}"
+	fs.AddFile("/workspace/.changeset/changelog.tmpl", []byte(templateContent))
+
+	cl := NewChangelog(fs).WithCategories([]models.Category{
+		{Type: "security", Title: "Security"},
+		{Type: "fix", Title: "Bug Fixes"},
+	})
+	changesets := []*models.Changeset{
+		{ID: "a", Message: "Fix login redirect", Type: "fix", Projects: map[string]models.BumpType{"auth": models.BumpPatch}},
+		{ID: "b", Message: "Escape HTML in errors", Type: "fix", ProjectTypes: map[string]string{"auth": "security"}, Projects: map[string]models.BumpType{"auth": models.BumpPatch}},
+		{ID: "c", Message: "Add OAuth2 support", Type: "feat", Projects: map[string]models.BumpType{"auth": models.BumpMinor}},
+		{ID: "d", Message: "Refactor token store", Projects: map[string]models.BumpType{"auth": models.BumpPatch}},
+	}
+
+	result, err := cl.FormatEntry(changesets, "auth", "/workspace")
+	require.NoError(t, err)
+	require.Equal(t, "\n### Security\n- Escape HTML in errors (patch)\n"+
+		"\n### Bug Fixes\n- Fix login redirect (patch)\n"+
+		"\n### Other Changes\n- Add OAuth2 support (minor)\n- Refactor token store (patch)\n", result)
+}
+
 func TestChangelog_FormatEntry_withPRDetails(t *testing.T) {
 	changesets := []*models.Changeset{
 		{
@@ -453,8 +479,94 @@ func TestChangelog_Append(t *testing.T) {
 	}
 }
 
+func TestChangelog_Append_withDependencies(t *testing.T) {
+	fs := filesystem.NewMockFileSystem()
+	cl := NewChangelog(fs)
+	fs.AddDir("/test/backend")
+
+	entry := &Entry{
+		Version: &models.Version{Major: 1, Minor: 0, Patch: 1},
+		Date:    time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Changesets: []*models.Changeset{
+			{ID: "fix", Message: "Fix retry loop", Projects: map[string]models.BumpType{"backend": models.BumpPatch}},
+		},
+		Dependencies: []Dependency{
+			{Name: "shared", Version: &models.Version{Major: 1, Minor: 3, Patch: 0}},
+			{Name: "proto", Version: &models.Version{Major: 0, Minor: 2, Patch: 1}},
+		},
+	}
+	require.NoError(t, cl.Append("/test/backend", "", entry))
+
+	data, err := fs.ReadFile("/test/backend/CHANGELOG.md")
+	require.NoError(t, err)
+
+	content := string(data)
+	require.Contains(t, content, "### Patch Changes\n- Fix retry loop\n\n### Updated Dependencies\n- shared@1.3.0\n- proto@0.2.1\n")
+}
+
+func TestChangelog_AppendBatch(t *testing.T) {
+	fs := filesystem.NewMockFileSystem()
+	cl := NewChangelog(fs)
+	fs.AddDir("/test")
+	fs.AddFile("/test/CHANGELOG.md", []byte("# Changelog\n\n## backend@1.0.0 (2024-02-01)\n### Patch Changes\n- Initial release\n"))
+
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	entries := []ProjectEntry{
+		{Project: "shared", Entry: &Entry{
+			Version:    &models.Version{Major: 1, Minor: 3, Patch: 0},
+			Date:       date,
+			Changesets: []*models.Changeset{{ID: "retry", Message: "Add retry helpers", Projects: map[string]models.BumpType{"shared": models.BumpMinor}}},
+		}},
+		{Project: "backend", Entry: &Entry{
+			Version:      &models.Version{Major: 1, Minor: 0, Patch: 1},
+			Date:         date,
+			Dependencies: []Dependency{{Name: "shared", Version: &models.Version{Major: 1, Minor: 3, Patch: 0}}},
+		}},
+	}
+	require.NoError(t, cl.AppendBatch("/test", date, entries))
+
+	data, err := fs.ReadFile("/test/CHANGELOG.md")
+	require.NoError(t, err)
+	require.Equal(t, "# Changelog\n\n"+
+		"## 2024-03-01\n\n"+
+		"### shared@1.3.0 (2024-03-01)\n#### Minor Changes\n- Add retry helpers\n\n"+
+		"### backend@1.0.1 (2024-03-01)\n#### Updated Dependencies\n- shared@1.3.0\n\n"+
+		"## backend@1.0.0 (2024-02-01)\n### Patch Changes\n- Initial release\n", string(data))
+}
+
+func TestChangelog_AppendBatch_KeepsCodeBlocks(t *testing.T) {
+	t.Cleanup(func() {
+		resetChangelogTemplateCache()
+	})
+
+	fs := filesystem.NewMockFileSystem()
+	fs.AddFile("/test/.changeset/changelog.tmpl", []byte("## {{.Project}}@{{.Version}}\n{{range .Items}}{{.FirstLine}}\n{{range .RestLines}}{{.}}\n{{end}}{{end}}"))
+	cl := NewChangelog(fs)
+
+	message := "Add a config loader\n\n```sh\n# load the defaults\nloader --defaults\n```\n\n#config is read at startup\n\n### Migration\n\n~~~~\n## not a heading\n~~~\n~~~~"
+	date := time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+	entries := []ProjectEntry{
+		{Project: "shared", Entry: &Entry{
+			Version:    &models.Version{Major: 1, Minor: 3, Patch: 0},
+			Date:       date,
+			Changesets: []*models.Changeset{{ID: "loader", Message: message, Projects: map[string]models.BumpType{"shared": models.BumpMinor}}},
+		}},
+	}
+	require.NoError(t, cl.AppendBatch("/test", date, entries))
+
+	data, err := fs.ReadFile("/test/CHANGELOG.md")
+	require.NoError(t, err)
+	require.Contains(t, string(data), "## 2024-03-01\n\n"+
+		"### shared@1.3.0\n"+
+		"Add a config loader\n"+
+		"```sh\n# load the defaults\nloader --defaults\n```\n"+
+		"#config is read at startup\n"+
+		"#### Migration\n"+
+		"~~~~\n## not a heading\n~~~\n~~~~\n")
+}
+
 func resetChangelogTemplateCache() {
 	templateCacheLock.Lock()
 	defer templateCacheLock.Unlock()
-	templateCache = make(map[string]*template.Template)
+	templateCache = make(map[string]*changelogTemplate)
 }
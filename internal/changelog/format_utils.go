@@ -10,6 +10,7 @@ import (
 
 type formattedSection struct {
 	Title      string
+	Type       string
 	Changesets []*models.Changeset
 }
 
@@ -50,6 +51,39 @@ func buildSections(changesets []*models.Changeset, projectName string) []formatt
 	return sections
 }
 
+// buildCategorySections groups changesets by their category for projectName,
+// in the order of categories. Changesets without a known category are listed
+// last under "Other Changes".
+func buildCategorySections(changesets []*models.Changeset, projectName string, categories []models.Category) []formattedSection {
+	relevant := changesets
+	if projectName != "" {
+		relevant = changeset.FilterByProject(changesets, projectName)
+	}
+
+	byType := make(map[string][]*models.Changeset)
+	var other []*models.Changeset
+	for _, cs := range relevant {
+		changeType := cs.GetTypeForProject(projectName)
+		if changeType == "" || models.ValidateCategory(changeType, categories) != nil {
+			other = append(other, cs)
+			continue
+		}
+		byType[changeType] = append(byType[changeType], cs)
+	}
+
+	sections := make([]formattedSection, 0, len(categories)+1)
+	for _, category := range categories {
+		if len(byType[category.Type]) > 0 {
+			sections = append(sections, formattedSection{Title: category.Title, Type: category.Type, Changesets: byType[category.Type]})
+		}
+	}
+	if len(other) > 0 {
+		sections = append(sections, formattedSection{Title: "Other Changes", Changesets: other})
+	}
+
+	return sections
+}
+
 func determineBump(cs *models.Changeset, projectName string) (models.BumpType, bool) {
 	if projectName != "" {
 		bump, ok := cs.GetBumpForProject(projectName)
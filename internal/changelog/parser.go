@@ -0,0 +1,212 @@
+package changelog
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jakoblorz/go-changesets/internal/models"
+)
+
+// DefaultHeaderPattern matches the release headings of the default template,
+// e.g. "## auth@1.2.3 (2024-03-01)", as well as "## 1.2.3" and "## v1.2.3".
+var DefaultHeaderPattern = regexp.MustCompile(`^## (?:(?P<project>\S+)@)?v?(?P<version>\d+\.\d+\.\d+\S*)(?:\s+\((?P<date>[^)]*)\))?`)
+
+// headerDirective declares the header pattern of a custom template, e.g.
+// {{/* header: ^## Release (?P<version>\S+) */}}
+var headerDirective = regexp.MustCompile(`\{\{-?\s*/\*\s*header:\s*(.*?)\s*\*/\s*-?\}\}`)
+
+// NewHeaderPattern compiles a release heading pattern. It must have a named
+// group "version" and may have the groups "project" and "date" (YYYY-MM-DD).
+func NewHeaderPattern(expr string) (*regexp.Regexp, error) {
+	pattern, err := regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid header pattern: %w", err)
+	}
+	if pattern.SubexpIndex("version") == -1 {
+		return nil, fmt.Errorf("invalid header pattern %q: missing named group \"version\"", expr)
+	}
+	return pattern, nil
+}
+
+// headerPatternOf returns the header pattern declared by a template, or nil
+// if it declares none.
+func headerPatternOf(templateText string) (*regexp.Regexp, error) {
+	match := headerDirective.FindStringSubmatch(templateText)
+	if match == nil {
+		return nil, nil
+	}
+	return NewHeaderPattern(match[1])
+}
+
+// Document is a parsed CHANGELOG.md
+type Document struct {
+	// Preamble is the text above the first release, e.g. the "# Changelog" title
+	Preamble string
+
+	// Releases are the entries of the changelog, newest first
+	Releases []*Release
+}
+
+// Release is an entry of a parsed changelog. Entries below a "## " heading
+// that does not match the header pattern have no version, e.g. the dated
+// batches of the CHANGELOG.md at the workspace root.
+type Release struct {
+	// Heading is the heading line, e.g. "## auth@1.2.3 (2024-03-01)"
+	Heading string
+
+	Project string
+	Version *models.Version
+	Date    time.Time
+
+	Sections []Section
+
+	// Body is the raw text below the heading, up to the next entry
+	Body string
+}
+
+// Section is a "### " section of a release, e.g. "Minor Changes"
+type Section struct {
+	Title string
+	Body  string
+}
+
+// Parse splits content into its preamble and releases. Entries start at
+// lines matching header or starting with "## ". A nil header uses
+// DefaultHeaderPattern.
+func Parse(content string, header *regexp.Regexp) *Document {
+	if header == nil {
+		header = DefaultHeaderPattern
+	}
+
+	doc := &Document{}
+	var current *Release
+	var body strings.Builder
+
+	flush := func() {
+		if current == nil {
+			doc.Preamble = body.String()
+		} else {
+			current.Body = body.String()
+			current.Sections = parseSections(current.Body)
+			doc.Releases = append(doc.Releases, current)
+		}
+		body.Reset()
+	}
+
+	for _, line := range strings.SplitAfter(content, "\n") {
+		heading := strings.TrimRight(line, "\r\n")
+		match := header.FindStringSubmatch(heading)
+		if match == nil && !strings.HasPrefix(heading, "## ") {
+			body.WriteString(line)
+			continue
+		}
+
+		flush()
+		current = &Release{Heading: heading}
+		if match != nil {
+			current.parseHeading(header, match)
+		}
+		body.WriteString(line[len(heading):])
+	}
+	flush()
+
+	return doc
+}
+
+func (r *Release) parseHeading(header *regexp.Regexp, match []string) {
+	group := func(name string) string {
+		if i := header.SubexpIndex(name); i != -1 {
+			return match[i]
+		}
+		return ""
+	}
+
+	if version, err := models.ParseVersion(group("version")); err == nil {
+		r.Version = version
+	}
+	r.Project = group("project")
+	if date, err := time.Parse("2006-01-02", group("date")); err == nil {
+		r.Date = date
+	}
+}
+
+func parseSections(body string) []Section {
+	var sections []Section
+	var current *Section
+	var text strings.Builder
+
+	flush := func() {
+		if current != nil {
+			current.Body = strings.TrimSpace(text.String())
+			sections = append(sections, *current)
+		}
+		text.Reset()
+	}
+
+	for _, line := range strings.SplitAfter(body, "\n") {
+		if title, ok := strings.CutPrefix(strings.TrimRight(line, "\r\n"), "### "); ok {
+			flush()
+			current = &Section{Title: strings.TrimSpace(title)}
+			continue
+		}
+		text.WriteString(line)
+	}
+	flush()
+
+	return sections
+}
+
+// String returns the markdown of the release, its heading followed by its body
+func (r *Release) String() string {
+	return r.Heading + r.Body
+}
+
+// Notes returns the body of the release without surrounding whitespace,
+// e.g. for the release notes of a GitHub release
+func (r *Release) Notes() string {
+	return strings.TrimSpace(r.Body)
+}
+
+// String returns the markdown of the document. An unmodified document
+// returns the content it was parsed from.
+func (d *Document) String() string {
+	var buf strings.Builder
+	buf.WriteString(d.Preamble)
+	for _, release := range d.Releases {
+		buf.WriteString(release.String())
+	}
+	return buf.String()
+}
+
+// Find returns the newest release of version of project, or nil. Releases
+// whose heading names another project are skipped; an empty project matches
+// every release.
+func (d *Document) Find(project string, version *models.Version) *Release {
+	for _, release := range d.Releases {
+		if release.Version != nil && release.Version.String() == version.String() && release.matchesProject(project) {
+			return release
+		}
+	}
+	return nil
+}
+
+// Versions returns the versions of all releases of project, newest first.
+// Releases whose heading names another project are skipped; an empty project
+// matches every release.
+func (d *Document) Versions(project string) []*models.Version {
+	var versions []*models.Version
+	for _, release := range d.Releases {
+		if release.Version != nil && release.matchesProject(project) {
+			versions = append(versions, release.Version)
+		}
+	}
+	return versions
+}
+
+// matchesProject reports whether the release belongs to project. Headings
+// without a project, e.g. "## 1.2.3", belong to every project.
+func (r *Release) matchesProject(project string) bool {
+	return project == "" || r.Project == "" || r.Project == project
+}
@@ -0,0 +1,193 @@
+package changelog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jakoblorz/go-changesets/internal/filesystem"
+	"github.com/jakoblorz/go-changesets/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+const parsedChangelog = `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+## auth@1.2.30 (2024-03-02)
+### Patch Changes
+- Fix token expiry
+
+## auth@1.2.3 (2024-03-01)
+### Minor Changes
+- Add OAuth2 support
+  with PKCE
+
+### Patch Changes
+- Fix memory leak
+
+## 1.0.0
+- Initial release
+`
+
+func TestParse(t *testing.T) {
+	doc := Parse(parsedChangelog, nil)
+
+	require.Equal(t, "# Changelog\n\nAll notable changes to this project will be documented in this file.\n\n", doc.Preamble)
+	require.Len(t, doc.Releases, 3)
+	require.Equal(t, parsedChangelog, doc.String())
+
+	release := doc.Releases[1]
+	require.Equal(t, "## auth@1.2.3 (2024-03-01)", release.Heading)
+	require.Equal(t, "auth", release.Project)
+	require.Equal(t, "1.2.3", release.Version.String())
+	require.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC), release.Date)
+	require.Equal(t, []Section{
+		{Title: "Minor Changes", Body: "- Add OAuth2 support\n  with PKCE"},
+		{Title: "Patch Changes", Body: "- Fix memory leak"},
+	}, release.Sections)
+	require.Equal(t, "### Minor Changes\n- Add OAuth2 support\n  with PKCE\n\n### Patch Changes\n- Fix memory leak", release.Notes())
+
+	unversioned := doc.Releases[2]
+	require.Empty(t, unversioned.Project)
+	require.Equal(t, "1.0.0", unversioned.Version.String())
+	require.True(t, unversioned.Date.IsZero())
+}
+
+func TestDocument_Find(t *testing.T) {
+	doc := Parse(parsedChangelog, nil)
+
+	release := doc.Find("auth", &models.Version{Major: 1, Minor: 2, Patch: 3})
+	require.NotNil(t, release)
+	require.Equal(t, "## auth@1.2.3 (2024-03-01)", release.Heading)
+
+	require.Nil(t, doc.Find("auth", &models.Version{Major: 1, Minor: 2}))
+	require.Nil(t, doc.Find("api", &models.Version{Major: 1, Minor: 2, Patch: 3}))
+}
+
+func TestDocument_FindSeveralProjects(t *testing.T) {
+	// The CHANGELOG.md at the workspace root holds the entries of a project
+	// at the root next to those of other projects
+	doc := Parse("# Changelog\n\n## api@1.2.0\n\n- API change\n\n## cli@1.2.0\n\n- CLI change\n\n## 1.1.0\n\n- Shared entry\n", nil)
+
+	release := doc.Find("cli", &models.Version{Major: 1, Minor: 2})
+	require.NotNil(t, release)
+	require.Equal(t, "- CLI change", release.Notes())
+
+	release = doc.Find("api", &models.Version{Major: 1, Minor: 2})
+	require.NotNil(t, release)
+	require.Equal(t, "- API change", release.Notes())
+
+	// Headings without a project belong to every project
+	require.NotNil(t, doc.Find("cli", &models.Version{Major: 1, Minor: 1}))
+
+	var versions []string
+	for _, v := range doc.Versions("cli") {
+		versions = append(versions, v.String())
+	}
+	require.Equal(t, []string{"1.2.0", "1.1.0"}, versions)
+	require.Len(t, doc.Versions("web"), 1)
+}
+
+func TestDocument_Versions(t *testing.T) {
+	doc := Parse("# Changelog\n\n## 2024-03-01\n\n### auth@1.0.1 (2024-03-01)\n\n## 1.1.0-beta.0\n\n## v1.0.0\n", nil)
+
+	// The dated batch heading is an entry without a version
+	require.Len(t, doc.Releases, 3)
+	require.Nil(t, doc.Releases[0].Version)
+
+	var versions []string
+	for _, v := range doc.Versions("") {
+		versions = append(versions, v.String())
+	}
+	require.Equal(t, []string{"1.1.0-beta.0", "1.0.0"}, versions)
+}
+
+func TestNewHeaderPattern(t *testing.T) {
+	_, err := NewHeaderPattern(`^# Release (?P<version>\S+)`)
+	require.NoError(t, err)
+
+	_, err = NewHeaderPattern(`^# Release (\S+)`)
+	require.ErrorContains(t, err, `missing named group "version"`)
+
+	_, err = NewHeaderPattern(`^# Release (?P<version>`)
+	require.ErrorContains(t, err, "invalid header pattern")
+}
+
+func TestChangelog_CustomHeaderPattern(t *testing.T) {
+	resetChangelogTemplateCache()
+	t.Cleanup(resetChangelogTemplateCache)
+
+	fs := filesystem.NewMockFileSystem()
+	fs.AddFile("/repo/.changeset/changelog.tmpl", []byte(
+		"{{/* header: ^# Release (?P<version>\\S+) \\((?P<date>[^)]*)\\) */}}\n"+
+			"# Release {{.Version}} ({{.Date}})\n"+
+			"{{range .Items}}- {{.FirstLine}}\n{{end}}\n"))
+	fs.AddDir("/repo/auth")
+
+	cl := NewChangelog(fs)
+	for i, message := range []string{"Initial release", "Fix header parsing"} {
+		entry := &Entry{
+			Version:    &models.Version{Major: 1, Patch: i},
+			Date:       time.Date(2024, 3, 1+i, 0, 0, 0, 0, time.UTC),
+			Changesets: []*models.Changeset{{ID: "cs", Message: message, Projects: map[string]models.BumpType{"auth": models.BumpPatch}}},
+		}
+		require.NoError(t, cl.Append("/repo/auth", "auth", entry))
+	}
+
+	versions, err := cl.Versions("/repo/auth", "auth")
+	require.NoError(t, err)
+	require.Len(t, versions, 2)
+	require.Equal(t, "1.0.1", versions[0].String())
+
+	notes, err := cl.GetReleaseNotes("/repo/auth", "auth", &models.Version{Major: 1})
+	require.NoError(t, err)
+	require.Equal(t, "- Initial release", notes)
+
+	doc, err := cl.Read("/repo/auth")
+	require.NoError(t, err)
+	require.Equal(t, time.Date(2024, 3, 2, 0, 0, 0, 0, time.UTC), doc.Releases[0].Date)
+}
+
+func TestChangelog_InvalidHeaderPattern(t *testing.T) {
+	resetChangelogTemplateCache()
+	t.Cleanup(resetChangelogTemplateCache)
+
+	fs := filesystem.NewMockFileSystem()
+	fs.AddFile("/repo/.changeset/changelog.tmpl", []byte("{{/* header: ^# Release (\\S+) */}}\n# Release {{.Version}}\n"))
+	fs.AddFile("/repo/auth/CHANGELOG.md", []byte("# Release 1.0.0\n"))
+
+	_, err := NewChangelog(fs).Versions("/repo/auth", "auth")
+	require.ErrorContains(t, err, `missing named group "version"`)
+}
+
+func TestChangelog_GetEntryForVersion(t *testing.T) {
+	fs := filesystem.NewMockFileSystem()
+	fs.AddFile("/repo/auth/CHANGELOG.md", []byte(parsedChangelog))
+	cl := NewChangelog(fs)
+
+	entry, err := cl.GetEntryForVersion("/repo/auth", "auth", &models.Version{Major: 1, Minor: 2, Patch: 3})
+	require.NoError(t, err)
+	require.Equal(t, "## auth@1.2.3 (2024-03-01)\n### Minor Changes\n- Add OAuth2 support\n  with PKCE\n\n### Patch Changes\n- Fix memory leak\n\n", entry)
+
+	_, err = cl.GetEntryForVersion("/repo/auth", "auth", &models.Version{Major: 1, Minor: 2, Patch: 4})
+	require.ErrorContains(t, err, "version 1.2.4 not found in changelog")
+}
+
+func TestChangelog_Append_KeepsPreambleWithoutReleases(t *testing.T) {
+	resetChangelogTemplateCache()
+	t.Cleanup(resetChangelogTemplateCache)
+
+	fs := filesystem.NewMockFileSystem()
+	fs.AddFile("/repo/auth/CHANGELOG.md", []byte("# Changelog\n\nRelease notes of auth.\n\n"))
+
+	entry := &Entry{
+		Version:    &models.Version{Major: 1},
+		Date:       time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC),
+		Changesets: []*models.Changeset{{ID: "cs", Message: "Initial release", Projects: map[string]models.BumpType{"auth": models.BumpMajor}}},
+	}
+	require.NoError(t, NewChangelog(fs).Append("/repo/auth", "auth", entry))
+
+	data, err := fs.ReadFile("/repo/auth/CHANGELOG.md")
+	require.NoError(t, err)
+	require.Equal(t, "# Changelog\n\nRelease notes of auth.\n\n## auth@1.0.0 (2024-03-01)\n### Major Changes\n- Initial release\n\n", string(data))
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	"github.com/adrg/frontmatter"
@@ -91,9 +92,40 @@ func (m *Manager) Read(filePath string) (*models.Changeset, error) {
 	return m.Parse(filePath, data)
 }
 
+// typeKey is the reserved frontmatter key of the changeset's category. A
+// project named "type" must use the map form, e.g. "type: {bump: patch}".
+const typeKey = "type"
+
+// frontmatterValue is a frontmatter entry: a bump type ("auth: minor"), a
+// project with a category ("auth: {bump: minor, type: security}") or the
+// category of the changeset ("type: security").
+type frontmatterValue struct {
+	Scalar string
+	Bump   string
+	Type   string
+	IsMap  bool
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler of the frontmatter decoder
+func (v *frontmatterValue) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	if err := unmarshal(&v.Scalar); err == nil {
+		return nil
+	}
+
+	var project struct {
+		Bump string `yaml:"bump"`
+		Type string `yaml:"type"`
+	}
+	if err := unmarshal(&project); err != nil {
+		return fmt.Errorf("expected a bump type or {bump, type}")
+	}
+	v.Bump, v.Type, v.IsMap = project.Bump, project.Type, true
+	return nil
+}
+
 // Parse parses changeset data from bytes
 func (m *Manager) Parse(filePath string, data []byte) (*models.Changeset, error) {
-	var matter map[string]string
+	var matter map[string]frontmatterValue
 	var body bytes.Buffer
 
 	rest, err := frontmatter.Parse(bytes.NewReader(data), &matter)
@@ -106,12 +138,27 @@ func (m *Manager) Parse(filePath string, data []byte) (*models.Changeset, error)
 
 	// Parse projects and bump types from frontmatter
 	projects := make(map[string]models.BumpType)
-	for projectName, bumpStr := range matter {
+	projectTypes := make(map[string]string)
+	changeType := ""
+	for key, value := range matter {
+		if key == typeKey && !value.IsMap {
+			changeType = value.Scalar
+			continue
+		}
+
+		bumpStr := value.Scalar
+		if value.IsMap {
+			bumpStr = value.Bump
+			if value.Type != "" {
+				projectTypes[key] = value.Type
+			}
+		}
+
 		bump, err := models.ParseBumpType(bumpStr)
 		if err != nil {
-			return nil, fmt.Errorf("invalid bump type for project %s: %w", projectName, err)
+			return nil, fmt.Errorf("invalid bump type for project %s: %w", key, err)
 		}
-		projects[projectName] = bump
+		projects[key] = bump
 	}
 
 	if len(projects) == 0 {
@@ -124,6 +171,10 @@ func (m *Manager) Parse(filePath string, data []byte) (*models.Changeset, error)
 
 	changeset := models.NewChangeset(id, projects, strings.TrimSpace(body.String()))
 	changeset.FilePath = filePath
+	changeset.Type = changeType
+	if len(projectTypes) > 0 {
+		changeset.ProjectTypes = projectTypes
+	}
 
 	return changeset, nil
 }
@@ -140,10 +191,36 @@ func (m *Manager) Write(changeset *models.Changeset) error {
 	// Generate content with frontmatter
 	var buf bytes.Buffer
 
-	// Write frontmatter
+	// Write frontmatter (sorted for stable output in multi-project changesets)
+	projectNames := make([]string, 0, len(changeset.Projects))
+	for projectName := range changeset.Projects {
+		projectNames = append(projectNames, projectName)
+	}
+	sort.Strings(projectNames)
+
+	// A project named "type" takes the key of the changeset's category, so
+	// the category is written for every project instead
+	_, typeProject := changeset.Projects[typeKey]
+	foldType := typeProject && changeset.Type != ""
+
 	buf.WriteString("---\n")
-	for projectName, bump := range changeset.Projects {
-		buf.WriteString(fmt.Sprintf("%s: %s\n", projectName, bump))
+	if changeset.Type != "" && !foldType {
+		buf.WriteString(fmt.Sprintf("%s: %s\n", typeKey, changeset.Type))
+	}
+	for _, projectName := range projectNames {
+		bump := changeset.Projects[projectName]
+		projectType, hasType := changeset.ProjectTypes[projectName]
+		if foldType && !hasType {
+			projectType, hasType = changeset.Type, true
+		}
+		switch {
+		case hasType:
+			buf.WriteString(fmt.Sprintf("%s: {bump: %s, type: %s}\n", projectName, bump, projectType))
+		case projectName == typeKey:
+			buf.WriteString(fmt.Sprintf("%s: {bump: %s}\n", projectName, bump))
+		default:
+			buf.WriteString(fmt.Sprintf("%s: %s\n", projectName, bump))
+		}
 	}
 	buf.WriteString("---\n\n")
 
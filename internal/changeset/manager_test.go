@@ -0,0 +1,84 @@
+package changeset
+
+import (
+	"testing"
+
+	"github.com/jakoblorz/go-changesets/internal/filesystem"
+	"github.com/jakoblorz/go-changesets/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestManager_Write_MultiProject(t *testing.T) {
+	fs := filesystem.NewMockFileSystem()
+	manager := NewManager(fs, "/workspace/.changeset")
+
+	cs := models.NewChangeset("multi", map[string]models.BumpType{
+		"shared":  models.BumpMajor,
+		"backend": models.BumpPatch,
+	}, "Rename handler")
+	require.NoError(t, manager.Write(cs))
+
+	data, err := fs.ReadFile("/workspace/.changeset/multi.md")
+	require.NoError(t, err)
+	require.Equal(t, "---\nbackend: patch\nshared: major\n---\n\nRename handler\n", string(data))
+
+	parsed, err := manager.Read(cs.FilePath)
+	require.NoError(t, err)
+	require.Equal(t, cs.Projects, parsed.Projects)
+	require.Equal(t, "Rename handler", parsed.Message)
+}
+
+func TestManager_Types(t *testing.T) {
+	fs := filesystem.NewMockFileSystem()
+	manager := NewManager(fs, "/workspace/.changeset")
+
+	cs := models.NewChangeset("typed", map[string]models.BumpType{
+		"auth":    models.BumpPatch,
+		"backend": models.BumpMinor,
+	}, "Reject expired tokens")
+	cs.Type = "fix"
+	cs.ProjectTypes = map[string]string{"auth": "security"}
+	require.NoError(t, manager.Write(cs))
+
+	data, err := fs.ReadFile("/workspace/.changeset/typed.md")
+	require.NoError(t, err)
+	require.Equal(t, "---\ntype: fix\nauth: {bump: patch, type: security}\nbackend: minor\n---\n\nReject expired tokens\n", string(data))
+
+	parsed, err := manager.Read(cs.FilePath)
+	require.NoError(t, err)
+	require.Equal(t, cs.Projects, parsed.Projects)
+	require.Equal(t, "fix", parsed.Type)
+	require.Equal(t, "security", parsed.GetTypeForProject("auth"))
+	require.Equal(t, "fix", parsed.GetTypeForProject("backend"))
+
+	// A project named "type" uses the map form and gets the category itself
+	cs = models.NewChangeset("type-project", map[string]models.BumpType{
+		"backend": models.BumpMinor,
+		"type":    models.BumpPatch,
+	}, "Rename handler")
+	cs.Type = "feat"
+	require.NoError(t, manager.Write(cs))
+
+	data, err = fs.ReadFile("/workspace/.changeset/type-project.md")
+	require.NoError(t, err)
+	require.Equal(t, "---\nbackend: {bump: minor, type: feat}\ntype: {bump: patch, type: feat}\n---\n\nRename handler\n", string(data))
+
+	parsed, err = manager.Read(cs.FilePath)
+	require.NoError(t, err)
+	require.Equal(t, cs.Projects, parsed.Projects)
+	require.Equal(t, "feat", parsed.GetTypeForProject("type"))
+	require.Equal(t, "feat", parsed.GetTypeForProject("backend"))
+}
+
+func TestManager_Parse_BlockProjectForm(t *testing.T) {
+	manager := NewManager(filesystem.NewMockFileSystem(), "/workspace/.changeset")
+
+	parsed, err := manager.Parse("/workspace/.changeset/block.md", []byte("---\nauth:\n  bump: minor\n  type: feat\nbackend: patch\n---\n\nAdd SSO\n"))
+	require.NoError(t, err)
+	require.Equal(t, map[string]models.BumpType{"auth": models.BumpMinor, "backend": models.BumpPatch}, parsed.Projects)
+	require.Empty(t, parsed.Type)
+	require.Equal(t, "feat", parsed.GetTypeForProject("auth"))
+
+	_, err = manager.Parse("/workspace/.changeset/bad.md", []byte("---\nauth: [minor]\n---\n\nAdd SSO\n"))
+	require.Error(t, err)
+}
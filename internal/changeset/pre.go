@@ -0,0 +1,69 @@
+package changeset
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+
+	"github.com/jakoblorz/go-changesets/internal/models"
+)
+
+// preStateFile is the name of the prerelease mode state file in the .changeset directory
+const preStateFile = "pre.json"
+
+// PreStatePath returns the path to the prerelease mode state file
+func (m *Manager) PreStatePath() string {
+	return filepath.Join(m.changesetDir, preStateFile)
+}
+
+// ReadPreState reads the prerelease mode state. It returns nil if pre mode was never entered.
+func (m *Manager) ReadPreState() (*models.PreState, error) {
+	path := m.PreStatePath()
+	if !m.fs.Exists(path) {
+		return nil, nil
+	}
+
+	data, err := m.fs.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", preStateFile, err)
+	}
+
+	var state models.PreState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", preStateFile, err)
+	}
+	if state.Mode != models.PreModeActive && state.Mode != models.PreModeExit {
+		return nil, fmt.Errorf("invalid mode %q in %s (must be pre or exit)", state.Mode, preStateFile)
+	}
+	if err := models.ValidatePreTag(state.Tag); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", preStateFile, err)
+	}
+
+	return &state, nil
+}
+
+// WritePreState writes the prerelease mode state
+func (m *Manager) WritePreState(state *models.PreState) error {
+	if err := m.fs.MkdirAll(m.changesetDir, 0755); err != nil {
+		return fmt.Errorf("failed to create changeset directory: %w", err)
+	}
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal %s: %w", preStateFile, err)
+	}
+
+	if err := m.fs.WriteFile(m.PreStatePath(), append(data, '\n'), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", preStateFile, err)
+	}
+
+	return nil
+}
+
+// DeletePreState removes the prerelease mode state file
+func (m *Manager) DeletePreState() error {
+	if err := m.fs.Remove(m.PreStatePath()); err != nil {
+		return fmt.Errorf("failed to delete %s: %w", preStateFile, err)
+	}
+	return nil
+}
@@ -2,8 +2,14 @@ package cli
 
 import (
 	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
 
+	"github.com/jakoblorz/go-changesets/internal/changeset"
 	"github.com/jakoblorz/go-changesets/internal/filesystem"
+	"github.com/jakoblorz/go-changesets/internal/models"
 	"github.com/jakoblorz/go-changesets/internal/tui/add"
 	"github.com/jakoblorz/go-changesets/internal/workspace"
 	"github.com/spf13/cobra"
@@ -21,21 +27,49 @@ func NewAddCommand(fs filesystem.FileSystem) *cobra.Command {
 	cobraCmd := &cobra.Command{
 		Use:   "add",
 		Short: "Create a new changeset",
-		Long:  `Create a new changeset by selecting projects and describing changes.`,
-		RunE:  cmd.Run,
+		Long: `Create a new changeset by selecting projects and describing changes.
+
+Without flags an interactive form is shown. Passing --project creates the
+changeset non-interactively, which is useful for scripts, bots and CI.
+The message is taken from --message, --message-file (use "-" for STDIN),
+or STDIN when it is piped.`,
+		Example: `  # Interactive
+  changeset add
+
+  # Non-interactive, one changeset for two projects
+  changeset add --project shared:minor --project backend:patch --message "Add request tracing"
+
+  # Message from a file or STDIN
+  changeset add --project shared:minor --message-file notes.md
+  echo "Bump dependencies" | changeset add --project backend:patch
+
+  # With a change category
+  changeset add --project backend:patch --type security --message "Escape HTML in error pages"`,
+		RunE: cmd.Run,
 	}
 
+	cobraCmd.Flags().StringArray("project", nil, "Project and bump as name:bump (repeatable, enables non-interactive mode)")
+	cobraCmd.Flags().String("bump", "", "Default bump type for --project entries without an explicit bump")
+	cobraCmd.Flags().StringP("message", "m", "", "Changeset message")
+	cobraCmd.Flags().String("message-file", "", "Read the changeset message from a file (\"-\" reads STDIN)")
+	cobraCmd.Flags().String("type", "", "Change category, e.g. feat, fix or security (see changelog.categories)")
+
 	return cobraCmd
 }
 
 // Run executes the add command
 func (c *AddCommand) Run(cmd *cobra.Command, args []string) error {
 	// Detect workspace
-	ws := workspace.New(c.fs, workspaceOptionsFromCmd(cmd)...)
+	ws := workspace.New(c.fs, workspaceOptionsFromCmd(cmd, nil)...)
 	if err := ws.Detect(); err != nil {
 		return fmt.Errorf("failed to detect workspace: %w", err)
 	}
 
+	projectFlags, _ := cmd.Flags().GetStringArray("project")
+	if len(projectFlags) > 0 {
+		return c.runNonInteractive(cmd, ws, projectFlags)
+	}
+
 	flow := add.NewFlow(c.fs, ws)
 	result, err := flow.Run()
 	if err != nil {
@@ -50,3 +84,139 @@ func (c *AddCommand) Run(cmd *cobra.Command, args []string) error {
 
 	return nil
 }
+
+func (c *AddCommand) runNonInteractive(cmd *cobra.Command, ws *workspace.Workspace, projectFlags []string) error {
+	defaultBump, _ := cmd.Flags().GetString("bump")
+	changeType, _ := cmd.Flags().GetString("type")
+
+	if err := models.ValidateCategory(changeType, ws.Config.Categories()); err != nil {
+		return err
+	}
+
+	bumps, err := parseProjectBumps(projectFlags, defaultBump, ws.GetProjectNames())
+	if err != nil {
+		return err
+	}
+
+	message, err := c.readMessage(cmd)
+	if err != nil {
+		return err
+	}
+
+	csManager := changeset.NewManager(c.fs, ws.ChangesetDir())
+	id, err := csManager.GenerateID()
+	if err != nil {
+		return fmt.Errorf("failed to generate changeset ID: %w", err)
+	}
+
+	cs := models.NewChangeset(id, bumps, message)
+	cs.Type = changeType
+	if err := csManager.Write(cs); err != nil {
+		return fmt.Errorf("failed to write changeset: %w", err)
+	}
+
+	_, _ = fmt.Fprintln(cmd.OutOrStdout(), add.RenderSuccess(&add.Result{
+		SelectedProjects: sortedProjectNames(bumps),
+		Bumps:            bumps,
+		Type:             changeType,
+		Message:          message,
+		CreatedFile:      fmt.Sprintf("%s.md", id),
+	}))
+
+	return nil
+}
+
+// readMessage resolves the changeset message from --message, --message-file or piped STDIN.
+func (c *AddCommand) readMessage(cmd *cobra.Command) (string, error) {
+	message, _ := cmd.Flags().GetString("message")
+	messageFile, _ := cmd.Flags().GetString("message-file")
+
+	if message != "" && messageFile != "" {
+		return "", fmt.Errorf("--message and --message-file are mutually exclusive")
+	}
+
+	switch {
+	case message != "":
+	case messageFile == "-":
+		data, err := io.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return "", fmt.Errorf("failed to read message from STDIN: %w", err)
+		}
+		message = string(data)
+	case messageFile != "":
+		data, err := c.fs.ReadFile(messageFile)
+		if err != nil {
+			return "", fmt.Errorf("failed to read message file: %w", err)
+		}
+		message = string(data)
+	case stdinIsPiped(cmd.InOrStdin()):
+		data, err := io.ReadAll(cmd.InOrStdin())
+		if err != nil {
+			return "", fmt.Errorf("failed to read message from STDIN: %w", err)
+		}
+		message = string(data)
+	}
+
+	message = strings.TrimSpace(message)
+	if message == "" {
+		return "", fmt.Errorf("changeset message is required (use --message, --message-file or STDIN)")
+	}
+
+	return message, nil
+}
+
+// parseProjectBumps parses name:bump pairs and validates them against the workspace projects.
+func parseProjectBumps(values []string, defaultBump string, knownProjects []string) (map[string]models.BumpType, error) {
+	known := make(map[string]struct{}, len(knownProjects))
+	for _, name := range knownProjects {
+		known[name] = struct{}{}
+	}
+
+	bumps := make(map[string]models.BumpType, len(values))
+	for _, value := range values {
+		name, bumpStr, hasBump := strings.Cut(strings.TrimSpace(value), ":")
+		name = strings.TrimSpace(name)
+		if !hasBump {
+			bumpStr = defaultBump
+		}
+
+		if name == "" {
+			return nil, fmt.Errorf("invalid --project value %q (expected name:bump)", value)
+		}
+		if strings.TrimSpace(bumpStr) == "" {
+			return nil, fmt.Errorf("missing bump type for project %s (use %s:patch|minor|major or --bump)", name, name)
+		}
+
+		if _, ok := known[name]; !ok {
+			sorted := append([]string{}, knownProjects...)
+			sort.Strings(sorted)
+			return nil, fmt.Errorf("unknown project %q (available: %s)", name, strings.Join(sorted, ", "))
+		}
+		if _, dup := bumps[name]; dup {
+			return nil, fmt.Errorf("project %s specified more than once", name)
+		}
+
+		bump, err := models.ParseBumpType(strings.TrimSpace(bumpStr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid bump for project %s: %w", name, err)
+		}
+		bumps[name] = bump
+	}
+
+	return bumps, nil
+}
+
+// stdinIsPiped reports whether r is a non-terminal STDIN with data to read.
+func stdinIsPiped(r io.Reader) bool {
+	f, ok := r.(*os.File)
+	if !ok {
+		return r != nil
+	}
+
+	stat, err := f.Stat()
+	if err != nil {
+		return false
+	}
+
+	return (stat.Mode() & os.ModeCharDevice) == 0
+}
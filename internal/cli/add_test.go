@@ -0,0 +1,118 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/jakoblorz/go-changesets/internal/changeset"
+	"github.com/jakoblorz/go-changesets/internal/models"
+	"github.com/jakoblorz/go-changesets/internal/workspace"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdd_NonInteractive(t *testing.T) {
+	ws, fs := buildWorkspace(t, func(wb *workspace.WorkspaceBuilder) {
+		wb.AddProject("shared", "packages/shared", "github.com/example/shared")
+		wb.AddProject("backend", "apps/backend", "github.com/example/backend")
+	})
+
+	var out bytes.Buffer
+	cmd := NewAddCommand(fs)
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--project", "shared:minor", "--project", "backend:patch", "--message", "Add request tracing"})
+	require.NoError(t, cmd.Execute())
+
+	changesets, err := changeset.NewManager(fs, ws.ChangesetDir()).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, changesets, 1)
+	require.Equal(t, map[string]models.BumpType{
+		"shared":  models.BumpMinor,
+		"backend": models.BumpPatch,
+	}, changesets[0].Projects)
+	require.Equal(t, "Add request tracing", changesets[0].Message)
+	require.Contains(t, out.String(), changesets[0].ID+".md")
+}
+
+func TestAdd_NonInteractive_Type(t *testing.T) {
+	ws, fs := buildWorkspace(t, func(wb *workspace.WorkspaceBuilder) {
+		wb.AddProject("backend", "apps/backend", "github.com/example/backend")
+	})
+
+	cmd := NewAddCommand(fs)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--project", "backend:patch", "--type", "secruity", "--message", "Escape HTML"})
+	require.ErrorContains(t, cmd.Execute(), `unknown change type "secruity" (available: feat, fix, security, deprecation)`)
+
+	cmd = NewAddCommand(fs)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--project", "backend:patch", "--type", "security", "--message", "Escape HTML"})
+	require.NoError(t, cmd.Execute())
+
+	changesets, err := changeset.NewManager(fs, ws.ChangesetDir()).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, changesets, 1)
+	require.Equal(t, "security", changesets[0].Type)
+}
+
+func TestAdd_NonInteractive_MessageSources(t *testing.T) {
+	t.Run("message file", func(t *testing.T) {
+		ws, fs := buildWorkspace(t, func(wb *workspace.WorkspaceBuilder) {
+			wb.AddProject("shared", "packages/shared", "github.com/example/shared")
+		})
+		fs.AddFile("/tmp/notes.md", []byte("From a file\n"))
+
+		cmd := NewAddCommand(fs)
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetArgs([]string{"--project", "shared", "--bump", "major", "--message-file", "/tmp/notes.md"})
+		require.NoError(t, cmd.Execute())
+
+		changesets, err := changeset.NewManager(fs, ws.ChangesetDir()).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, changesets, 1)
+		require.Equal(t, models.BumpMajor, changesets[0].Projects["shared"])
+		require.Equal(t, "From a file", changesets[0].Message)
+	})
+
+	t.Run("stdin", func(t *testing.T) {
+		ws, fs := buildWorkspace(t, func(wb *workspace.WorkspaceBuilder) {
+			wb.AddProject("shared", "packages/shared", "github.com/example/shared")
+		})
+
+		cmd := NewAddCommand(fs)
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetIn(strings.NewReader("From STDIN"))
+		cmd.SetArgs([]string{"--project", "shared:patch"})
+		require.NoError(t, cmd.Execute())
+
+		changesets, err := changeset.NewManager(fs, ws.ChangesetDir()).ReadAll()
+		require.NoError(t, err)
+		require.Len(t, changesets, 1)
+		require.Equal(t, "From STDIN", changesets[0].Message)
+	})
+}
+
+func TestParseProjectBumps(t *testing.T) {
+	known := []string{"shared", "backend"}
+
+	tests := []struct {
+		name    string
+		values  []string
+		bump    string
+		wantErr string
+	}{
+		{name: "unknown project", values: []string{"legacy:patch"}, wantErr: `unknown project "legacy" (available: backend, shared)`},
+		{name: "missing bump", values: []string{"shared"}, wantErr: "missing bump type for project shared"},
+		{name: "invalid bump", values: []string{"shared:huge"}, wantErr: "invalid bump for project shared"},
+		{name: "duplicate", values: []string{"shared:patch", "shared:minor"}, wantErr: "specified more than once"},
+		{name: "empty name", values: []string{":patch"}, wantErr: "expected name:bump"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseProjectBumps(tt.values, tt.bump, known)
+			require.Error(t, err)
+			require.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
@@ -3,7 +3,6 @@ package cli
 import (
 	"fmt"
 
-	"github.com/jakoblorz/go-changesets/internal/changelog"
 	"github.com/jakoblorz/go-changesets/internal/changeset"
 	"github.com/jakoblorz/go-changesets/internal/filesystem"
 	"github.com/spf13/cobra"
@@ -47,7 +46,7 @@ The output can be captured in scripts or CI workflows for use in PR descriptions
 func (c *ChangelogCommand) Run(cmd *cobra.Command, args []string) error {
 	projectFlag, _ := cmd.Flags().GetString("project")
 
-	resolved, err := resolveProject(c.fs, projectFlag, workspaceOptionsFromCmd(cmd)...)
+	resolved, err := resolveProject(c.fs, projectFlag, workspaceOptionsFromCmd(cmd, nil)...)
 	if err != nil {
 		if projectFlag == "" {
 			return fmt.Errorf("--project flag required (or run via 'changeset each'): %w", err)
@@ -64,7 +63,7 @@ func (c *ChangelogCommand) Run(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	changelog := changelog.NewChangelog(c.fs)
+	changelog := newChangelog(c.fs, resolved.Workspace)
 	preview, err := changelog.FormatEntry(projectChangesets, resolved.Project.Name, resolved.Project.RootPath)
 	if err != nil {
 		return fmt.Errorf("failed to format changelog preview: %w", err)
@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jakoblorz/go-changesets/internal/changelog"
+	"github.com/jakoblorz/go-changesets/internal/config"
+	"github.com/jakoblorz/go-changesets/internal/filesystem"
+	"github.com/jakoblorz/go-changesets/internal/github"
+	"github.com/jakoblorz/go-changesets/internal/workspace"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// envPrefix is the prefix of environment variables that set flags, e.g.
+// CHANGESET_DEPENDENT_BUMP for --dependent-bump.
+const envPrefix = "CHANGESET_"
+
+// applyConfig sets every flag that was not given on the command line from its
+// CHANGESET_* environment variable or, failing that, from .changeset/config.json.
+func applyConfig(fs filesystem.FileSystem, cmd *cobra.Command) error {
+	opts := append(workspaceOptionsFromCmd(cmd, nil), workspace.WithWarningWriter(nil))
+	ws := workspace.New(fs, opts...)
+
+	var cfg *config.Config
+	if err := ws.Detect(); err != nil {
+		// Commands report workspace errors themselves; only an invalid config is fatal here
+		var validationErr *config.ValidationError
+		if errors.As(err, &validationErr) {
+			return validationErr
+		}
+	} else {
+		cfg = ws.Config
+	}
+
+	if cfg != nil {
+		if err := validateConfigFlags(cmd.Root(), cfg); err != nil {
+			return err
+		}
+	}
+
+	defaults := cfg.FlagDefaults(configCommandPath(cmd))
+
+	var applyErr error
+	cmd.Flags().VisitAll(func(flag *pflag.Flag) {
+		if applyErr != nil || flag.Changed || flag.Name == "help" {
+			return
+		}
+
+		values := defaults[flag.Name]
+		source := "config"
+		if value, ok := os.LookupEnv(envVarName(flag.Name)); ok {
+			values = []string{value}
+			source = envVarName(flag.Name)
+		}
+
+		for _, value := range values {
+			if err := cmd.Flags().Set(flag.Name, value); err != nil {
+				applyErr = fmt.Errorf("invalid value %q for --%s from %s: %w", value, flag.Name, source, err)
+				return
+			}
+		}
+	})
+
+	return applyErr
+}
+
+// validateConfigFlags checks that every command and flag of the flags section exists.
+func validateConfigFlags(root *cobra.Command, cfg *config.Config) error {
+	commands := make([]string, 0, len(cfg.Flags))
+	for command := range cfg.Flags {
+		commands = append(commands, command)
+	}
+	sort.Strings(commands)
+
+	for _, command := range commands {
+		cmd, _, err := root.Find(strings.Fields(command))
+		if err != nil || configCommandPath(cmd) != command {
+			return &config.ValidationError{Path: cfg.Path, Err: fmt.Errorf("flags.%s: unknown command %q", command, command)}
+		}
+
+		for flag := range cfg.Flags[command] {
+			if cmd.Flags().Lookup(flag) == nil && cmd.InheritedFlags().Lookup(flag) == nil {
+				return &config.ValidationError{Path: cfg.Path, Err: fmt.Errorf("flags.%s.%s: unknown flag --%s for command %q", command, flag, flag, command)}
+			}
+		}
+	}
+
+	return nil
+}
+
+// configCommandPath returns the command path below the root command, e.g. "gh pr open".
+func configCommandPath(cmd *cobra.Command) string {
+	return strings.TrimPrefix(strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()), " ")
+}
+
+func envVarName(flag string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(flag, "-", "_"))
+}
+
+// newChangelog returns a Changelog using the template configured for the workspace.
+func newChangelog(fs filesystem.FileSystem, ws *workspace.Workspace) *changelog.Changelog {
+	cl := changelog.NewChangelog(fs)
+	if ws != nil && ws.Config != nil {
+		cl.WithTemplate(configPath(ws, ws.Config.Changelog.Template))
+		cl.WithCategories(ws.Config.Categories())
+	}
+	return cl
+}
+
+// newPRRenderer returns a PRRenderer using the templates configured for the workspace.
+func newPRRenderer(fs filesystem.FileSystem, ws *workspace.Workspace) *github.PRRenderer {
+	renderer := github.NewPRRenderer(fs)
+	if ws != nil && ws.Config != nil {
+		renderer.WithTemplates(
+			configPath(ws, ws.Config.ReleasePR.TitleTemplate),
+			configPath(ws, ws.Config.ReleasePR.BodyTemplate),
+		)
+	}
+	return renderer
+}
+
+// configPath resolves a path from the config file relative to the workspace root.
+func configPath(ws *workspace.Workspace, path string) string {
+	if path == "" || filepath.IsAbs(path) {
+		return path
+	}
+	return filepath.Join(ws.RootPath, path)
+}
@@ -0,0 +1,86 @@
+package cli
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/jakoblorz/go-changesets/internal/filesystem"
+	"github.com/jakoblorz/go-changesets/internal/git"
+	"github.com/stretchr/testify/require"
+)
+
+func addConfig(fs *filesystem.MockFileSystem, content string) {
+	fs.AddFile(filepath.Join(testWorkspaceRoot, ".changeset", "config.json"), []byte(content))
+}
+
+func executeRoot(fs filesystem.FileSystem, args ...string) error {
+	cmd := NewRootCommand(fs, git.NewMockGitClient(), nil)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs(args)
+	return cmd.Execute()
+}
+
+func TestConfig_FlagDefaults(t *testing.T) {
+	configured := func() *filesystem.MockFileSystem {
+		fs := buildDependentsWorkspace(t)
+		addConfig(fs, `{"flags": {"version": {"dependent-bump": "minor"}}}`)
+		return fs
+	}
+
+	fs := configured()
+	require.NoError(t, executeRoot(fs, "version", "--project", "shared"))
+	requireVersion(t, fs, "backend", "1.1.0")
+
+	// Environment variables take precedence over the config
+	t.Setenv("CHANGESET_DEPENDENT_BUMP", "none")
+	fs = configured()
+	require.NoError(t, executeRoot(fs, "version", "--project", "shared"))
+	requireVersion(t, fs, "backend", "1.0.0")
+
+	// Flags take precedence over environment variables
+	fs = configured()
+	require.NoError(t, executeRoot(fs, "version", "--project", "shared", "--dependent-bump", "major"))
+	requireVersion(t, fs, "backend", "2.0.0")
+}
+
+func TestConfig_InvalidValue(t *testing.T) {
+	fs := buildDependentsWorkspace(t)
+
+	t.Setenv("CHANGESET_NO_MODULE_PATH_MIGRATION", "sometimes")
+	require.ErrorContains(t, executeRoot(fs, "version"), `invalid value "sometimes" for --no-module-path-migration from CHANGESET_NO_MODULE_PATH_MIGRATION`)
+}
+
+func TestConfig_UnknownFlags(t *testing.T) {
+	fs := buildDependentsWorkspace(t)
+
+	addConfig(fs, `{"flags": {"versoin": {"dependent-bump": "minor"}}}`)
+	require.ErrorContains(t, executeRoot(fs, "status"), `flags.versoin: unknown command "versoin"`)
+
+	addConfig(fs, `{"flags": {"gh pr open": {"label": "release"}}}`)
+	require.ErrorContains(t, executeRoot(fs, "status"), `flags.gh pr open.label: unknown flag --label`)
+
+	addConfig(fs, `{"flags": {"gh pr open": {"owner": "acme", "node-strict-workspace": true}}}`)
+	require.NoError(t, executeRoot(fs, "status"))
+}
+
+func TestConfig_UnknownKey(t *testing.T) {
+	fs := buildDependentsWorkspace(t)
+	addConfig(fs, `{"changelog": {"rooot": false}}`)
+
+	err := executeRoot(fs, "status")
+	require.ErrorContains(t, err, "invalid /test-workspace/.changeset/config.json")
+	require.ErrorContains(t, err, `unknown key "changelog.rooot" (known keys: template, root, categories)`)
+}
+
+func TestConfig_Changelog(t *testing.T) {
+	fs := buildDependentsWorkspace(t)
+	addConfig(fs, `{"changelog": {"root": false, "template": "tools/changelog.tmpl"}}`)
+	fs.AddFile(filepath.Join(testWorkspaceRoot, "tools", "changelog.tmpl"), []byte("## {{.Version}} (custom)\n"))
+
+	require.NoError(t, executeRoot(fs, "version", "--project", "shared"))
+
+	require.False(t, fs.Exists(filepath.Join(testWorkspaceRoot, "CHANGELOG.md")))
+	require.Contains(t, changelogEntry(t, fs, "shared", "1.3.0"), "(custom)")
+}
@@ -10,7 +10,6 @@ import (
 	"sort"
 	"strings"
 
-	"github.com/jakoblorz/go-changesets/internal/changelog"
 	"github.com/jakoblorz/go-changesets/internal/changeset"
 	"github.com/jakoblorz/go-changesets/internal/filesystem"
 	"github.com/jakoblorz/go-changesets/internal/git"
@@ -38,12 +37,7 @@ func (resolved *resolvedProject) ToCurrentProjectContext(fs filesystem.FileSyste
 		// when receiving context via each, we nee to update a few fields in case they are outdated (when run via each --from-tree-file, etc)
 
 		// always read in the current version, even if set via each. we need the "new" version (after running 'version') for the PR title/body
-		versionStore := versioning.NewVersionStore(fs, resolved.Project.Type)
-		if currentVer, err := versionStore.Read(resolved.Project.RootPath); err == nil {
-			ctx.CurrentVersion = currentVer.String()
-		} else {
-			ctx.CurrentVersion = "0.0.0"
-		}
+		ctx.CurrentVersion = currentVersion(fs, git, resolved.Workspace, resolved.Project)
 
 		// we are on the "latest" version after 'changeset version', so we are not "outdated"
 		ctx.IsOutdated = false
@@ -129,6 +123,7 @@ func newProjectContextBuilder(fs filesystem.FileSystem, gitClient git.GitClient,
 }
 
 func (b *projectContextBuilder) BuildFromTreeFile(tree TreeOutput) ([]*models.ProjectContext, error) {
+	var ws *workspace.Workspace
 	projects := make(map[string]*models.ProjectContext)
 	changesetsByProject := make(map[string]map[string]*models.Changeset)
 
@@ -139,23 +134,22 @@ func (b *projectContextBuilder) BuildFromTreeFile(tree TreeOutput) ([]*models.Pr
 				if err != nil {
 					return nil, fmt.Errorf("failed to resolve project %s: %w", proj.Name, err)
 				}
+				ws = project.Workspace
 
 				ctx := &models.ProjectContext{
 					Project:        project.Name,
 					ProjectPath:    project.Project.RootPath,
+					RelativePath:   relativeProjectPath(project.Workspace, project.Project),
 					ModulePath:     project.Project.ModulePath,
+					Type:           project.Project.Type,
 					Changesets:     []models.ChangesetSummary{},
 					HasVersionFile: hasVersionFile(b.fs, project.Project),
+					Group:          project.Project.Group,
 				}
 
-				versionStore := versioning.NewVersionStore(b.fs, project.Project.Type)
-				if currentVer, err := versionStore.Read(project.Project.RootPath); err == nil {
-					ctx.CurrentVersion = currentVer.String()
-				} else {
-					ctx.CurrentVersion = "0.0.0"
-				}
+				ctx.CurrentVersion = currentVersion(b.fs, b.git, project.Workspace, project.Project)
 
-				ctx.LatestTag = latestTagVersion(b.git, project.Name, project.Project.Type)
+				ctx.LatestTag = latestTagVersion(b.git, project.Project)
 
 				currentVer, _ := models.ParseVersion(ctx.CurrentVersion)
 				latestVer, _ := models.ParseVersion(ctx.LatestTag)
@@ -179,6 +173,12 @@ func (b *projectContextBuilder) BuildFromTreeFile(tree TreeOutput) ([]*models.Pr
 				}
 
 				aggregated.Projects[proj.Name] = models.BumpType(cs.Bump)
+				if cs.Type != "" {
+					if aggregated.ProjectTypes == nil {
+						aggregated.ProjectTypes = map[string]string{}
+					}
+					aggregated.ProjectTypes[proj.Name] = cs.Type
+				}
 				if cs.PR != nil {
 					aggregated.PR = &models.PullRequest{
 						Number: cs.PR.Number,
@@ -199,7 +199,7 @@ func (b *projectContextBuilder) BuildFromTreeFile(tree TreeOutput) ([]*models.Pr
 	sort.Strings(projectNames)
 
 	contexts := make([]*models.ProjectContext, 0, len(projectNames))
-	changelog := changelog.NewChangelog(b.fs)
+	changelog := newChangelog(b.fs, ws)
 
 	for _, projectName := range projectNames {
 		ctx := projects[projectName]
@@ -220,6 +220,7 @@ func (b *projectContextBuilder) BuildFromTreeFile(tree TreeOutput) ([]*models.Pr
 			ctx.Changesets = append(ctx.Changesets, models.ChangesetSummary{
 				ID:       cs.ID,
 				BumpType: bump,
+				Type:     cs.GetTypeForProject(projectName),
 				Message:  cs.Message,
 			})
 		}
@@ -247,6 +248,15 @@ func (b *projectContextBuilder) BuildFromEnv() (*models.ProjectContext, error) {
 	return ctx, nil
 }
 
+// relativeProjectPath returns the project root relative to the workspace root
+func relativeProjectPath(ws *workspace.Workspace, project *models.Project) string {
+	rel, err := filepath.Rel(ws.RootPath, project.RootPath)
+	if err != nil {
+		return ""
+	}
+	return filepath.ToSlash(rel)
+}
+
 func (b *projectContextBuilder) BuildFromWorkspace(ws *workspace.Workspace) ([]*models.ProjectContext, error) {
 	csManager := changeset.NewManager(b.fs, ws.ChangesetDir())
 	allChangesets, err := csManager.ReadAll()
@@ -260,9 +270,12 @@ func (b *projectContextBuilder) BuildFromWorkspace(ws *workspace.Workspace) ([]*
 		ctx := &models.ProjectContext{
 			Project:        project.Name,
 			ProjectPath:    project.RootPath,
+			RelativePath:   relativeProjectPath(ws, project),
 			ModulePath:     project.ModulePath,
+			Type:           project.Type,
 			Changesets:     []models.ChangesetSummary{},
 			HasVersionFile: hasVersionFile(b.fs, project),
+			Group:          project.Group,
 		}
 
 		projectChangesets := changeset.FilterByProject(allChangesets, project.Name)
@@ -273,25 +286,21 @@ func (b *projectContextBuilder) BuildFromWorkspace(ws *workspace.Workspace) ([]*
 			ctx.Changesets = append(ctx.Changesets, models.ChangesetSummary{
 				ID:       cs.ID,
 				BumpType: bump,
+				Type:     cs.GetTypeForProject(project.Name),
 				Message:  cs.Message,
 			})
 		}
 
-		versionStore := versioning.NewVersionStore(b.fs, project.Type)
-		if currentVer, err := versionStore.Read(project.RootPath); err == nil {
-			ctx.CurrentVersion = currentVer.String()
-		} else {
-			ctx.CurrentVersion = "0.0.0"
-		}
+		ctx.CurrentVersion = currentVersion(b.fs, b.git, ws, project)
 
-		ctx.LatestTag = latestTagVersion(b.git, project.Name, project.Type)
+		ctx.LatestTag = latestTagVersion(b.git, project)
 
 		currentVer, _ := models.ParseVersion(ctx.CurrentVersion)
 		latestVer, _ := models.ParseVersion(ctx.LatestTag)
 		ctx.IsOutdated = currentVer.Compare(latestVer) > 0
 
 		if len(projectChangesets) > 0 {
-			changelog := changelog.NewChangelog(b.fs)
+			changelog := newChangelog(b.fs, ws)
 			preview, err := changelog.FormatEntry(projectChangesets, project.Name, project.RootPath)
 			if err != nil {
 				return nil, fmt.Errorf("failed to format changelog preview for %s: %w", project.Name, err)
@@ -305,81 +314,163 @@ func (b *projectContextBuilder) BuildFromWorkspace(ws *workspace.Workspace) ([]*
 	return contexts, nil
 }
 
+// projectVersionStore returns the version store of project. Stores that read
+// CHANGELOG.md use the changelog template of the workspace.
+func projectVersionStore(fs filesystem.FileSystem, gitClient git.GitClient, ws *workspace.Workspace, project *models.Project) (versioning.VersionStore, error) {
+	return versioning.NewProjectVersionStore(versioning.StoreEnv{
+		FS:        fs,
+		Git:       gitClient,
+		Changelog: newChangelog(fs, ws),
+		Project:   project,
+	})
+}
+
 func hasVersionFile(fs filesystem.FileSystem, project *models.Project) bool {
-	if project.Type == models.ProjectTypeNode {
-		return fs.Exists(project.ManifestPath)
+	versionStore, err := versioning.NewProjectVersionStore(versioning.StoreEnv{FS: fs, Project: project})
+	if err != nil {
+		return false
 	}
 
-	return fs.Exists(filepath.Join(project.RootPath, "version.txt"))
+	files := versionStore.Files(project.RootPath)
+	return len(files) > 0 && fs.Exists(files[0])
 }
 
-func latestTagVersion(gitClient git.GitClient, projectName string, projectType models.ProjectType) string {
-	if gitClient == nil {
+// currentVersion returns the project's current version, or 0.0.0 if it cannot be read.
+func currentVersion(fs filesystem.FileSystem, gitClient git.GitClient, ws *workspace.Workspace, project *models.Project) string {
+	versionStore, err := projectVersionStore(fs, gitClient, ws, project)
+	if err != nil {
 		return "0.0.0"
 	}
 
-	prefix := tagPrefixPattern(projectName, projectType)
-	tags, err := gitClient.GetTagsWithPrefix(prefix)
-	if err != nil || len(tags) == 0 {
+	version, err := versionStore.Read(project.RootPath)
+	if err != nil {
 		return "0.0.0"
 	}
+	return version.String()
+}
 
-	parts := strings.Split(tags[0], "@")
-	if len(parts) != 2 {
+func latestTagVersion(gitClient git.GitClient, project *models.Project) string {
+	if gitClient == nil {
 		return "0.0.0"
 	}
 
-	version, err := models.ParseVersion(parts[1])
+	prefix := tagPrefixPattern(project)
+	tags, err := gitClient.GetTagsWithPrefix(prefix)
 	if err != nil {
 		return "0.0.0"
 	}
 
-	return version.String()
+	for _, tag := range tags {
+		// The pattern can match tags of nested modules, e.g. "auth/v*" matches "auth/vault/v1.0.0"
+		version, err := project.TagVersion(tag)
+		if err != nil {
+			continue
+		}
+		return version.String()
+	}
+
+	return "0.0.0"
 }
 
-func parseFilters(filters []string) ([]models.FilterType, error) {
+// parseFilters parses filter expressions, e.g. "open-changesets" or
+// "type==go && bump>=minor". A project has to match all of them.
+func parseFilters(filters []string) (models.Filter, error) {
 	if len(filters) == 0 {
-		return []models.FilterType{models.FilterAll}, nil
+		return models.FilterAll, nil
 	}
 
-	out := make([]models.FilterType, 0, len(filters))
+	out := make([]models.Filter, 0, len(filters))
 	for _, f := range filters {
-		ft, err := models.ParseFilterType(f)
+		filter, err := models.ParseFilter(f)
 		if err != nil {
 			return nil, err
 		}
-		out = append(out, ft)
+		out = append(out, filter)
 	}
-	return out, nil
+	if len(out) == 1 {
+		return out[0], nil
+	}
+	return models.AllFilters(out...), nil
 }
 
-// filterContexts applies AND logic across filters.
-func filterContexts(contexts []*models.ProjectContext, filters []models.FilterType) ([]*models.ProjectContext, error) {
-	if len(filters) == 0 {
+// filterContexts returns the contexts matching filter.
+func filterContexts(contexts []*models.ProjectContext, filter models.Filter) ([]*models.ProjectContext, error) {
+	if filter == nil || filter == models.FilterAll {
 		return contexts, nil
 	}
 
-	for _, f := range filters {
-		if f == models.FilterAll {
-			return contexts, nil
+	filtered := make([]*models.ProjectContext, 0, len(contexts))
+	for _, ctx := range contexts {
+		if filter.MatchesContext(ctx) {
+			filtered = append(filtered, ctx)
+		}
+	}
+
+	return filtered, nil
+}
+
+// setChangedSince records on the contexts which of their files changed since
+// the ref of the changed-since conditions of filter, so that the filter can
+// match them. With includeDependents, projects depending on a changed project
+// record it as a changed dependency.
+func setChangedSince(ws *workspace.Workspace, gitClient git.GitClient, contexts []*models.ProjectContext, filter models.Filter, includeDependents bool) error {
+	refs := models.ChangedSinceRefs(filter)
+	if len(refs) == 0 {
+		if includeDependents {
+			return fmt.Errorf("--include-dependents requires a changed-since filter")
+		}
+		return nil
+	}
+	if len(refs) > 1 {
+		return fmt.Errorf("only one changed-since ref is supported (got %s)", strings.Join(refs, ", "))
+	}
+	ref := refs[0]
+
+	if gitClient == nil {
+		return fmt.Errorf("changed-since=%s requires a git repository", ref)
+	}
+
+	files, err := gitClient.GetChangedFiles(ref)
+	if err != nil {
+		return fmt.Errorf("failed to get files changed since %s: %w", ref, err)
+	}
+
+	changed := make(map[string][]string)
+	for _, file := range files {
+		project := ws.ProjectForPath(file)
+		if project == nil {
+			continue
+		}
+		rel, err := filepath.Rel(ws.RootPath, file)
+		if err != nil {
+			continue
+		}
+		changed[project.Name] = append(changed[project.Name], filepath.ToSlash(rel))
+	}
+
+	var graph *workspace.DependencyGraph
+	if includeDependents {
+		graph, err = ws.DependencyGraph()
+		if err != nil {
+			return fmt.Errorf("failed to build dependency graph: %w", err)
 		}
 	}
 
-	filtered := make([]*models.ProjectContext, 0, len(contexts))
 	for _, ctx := range contexts {
-		matches := true
-		for _, filter := range filters {
-			if !filter.MatchesContext(ctx) {
-				matches = false
-				break
-			}
+		ctx.ChangedSince = ref
+		ctx.ChangedFiles = changed[ctx.Project]
+		ctx.ChangedDependencies = nil
+		if graph == nil {
+			continue
 		}
-		if matches {
-			filtered = append(filtered, ctx)
+		for _, dep := range graph.AllDependencies(ctx.Project) {
+			if len(changed[dep]) > 0 {
+				ctx.ChangedDependencies = append(ctx.ChangedDependencies, dep)
+			}
 		}
 	}
 
-	return filtered, nil
+	return nil
 }
 
 // filterContextsByName filters contexts by project names.
@@ -479,10 +570,10 @@ func enrichChangesetsWithPRInfo(git git.GitClient, ghClient github.GitHubClient,
 	}).EnrichChangesetsWithPRInfo(changesets, owner, repo, silent)
 }
 
-func getLatestNonRCVersion(git git.GitClient, projectName string, projectType models.ProjectType) (*models.Version, error) {
+func getLatestNonRCVersion(git git.GitClient, project *models.Project) (*models.Version, error) {
 	return (&gitOperator{
 		git: git,
-	}).GetLatestNonRCVersion(projectName, projectType)
+	}).GetLatestNonRCVersion(project)
 }
 
 func (c *gitOperator) EnrichChangesetsWithPRInfo(changesets []*models.Changeset, owner, repo string, silent bool) error {
@@ -513,12 +604,12 @@ func (c *gitOperator) EnrichChangesetsWithPRInfo(changesets []*models.Changeset,
 	return nil
 }
 
-func (c *gitOperator) GetLatestNonRCVersion(projectName string, projectType models.ProjectType) (*models.Version, error) {
+func (c *gitOperator) GetLatestNonRCVersion(project *models.Project) (*models.Version, error) {
 	if c.git == nil {
 		return nil, fmt.Errorf("git client not available")
 	}
 
-	prefix := tagPrefixPattern(projectName, projectType)
+	prefix := tagPrefixPattern(project)
 	tags, err := c.git.GetTagsWithPrefix(prefix)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get tags: %w", err)
@@ -530,12 +621,7 @@ func (c *gitOperator) GetLatestNonRCVersion(projectName string, projectType mode
 			continue
 		}
 
-		parts := strings.Split(tag, "@")
-		if len(parts) != 2 {
-			continue
-		}
-
-		version, err := models.ParseVersion(parts[1])
+		version, err := project.TagVersion(tag)
 		if err != nil {
 			continue
 		}
@@ -0,0 +1,166 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"github.com/jakoblorz/go-changesets/internal/filesystem"
+	"github.com/jakoblorz/go-changesets/internal/git"
+	"github.com/jakoblorz/go-changesets/internal/github"
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+)
+
+const dryRunFlag = "dry-run"
+
+// dryRun wraps the file system and clients shared by all commands, so that
+// --dry-run can record their changes instead of applying them.
+type dryRun struct {
+	fs  *filesystem.DryRunFileSystem
+	git *git.DryRunGitClient
+	gh  *github.DryRunClient
+}
+
+func newDryRun(fs filesystem.FileSystem, gitClient git.GitClient, ghClient github.GitHubClient) *dryRun {
+	d := &dryRun{fs: filesystem.NewDryRunFileSystem(fs)}
+	if gitClient != nil {
+		d.git = git.NewDryRunGitClient(gitClient)
+	}
+	if ghClient != nil {
+		d.gh = github.NewDryRunClient(ghClient)
+	}
+	return d
+}
+
+// clients returns the wrapped file system and clients; absent clients stay nil.
+func (d *dryRun) clients() (filesystem.FileSystem, git.GitClient, github.GitHubClient) {
+	var gitClient git.GitClient
+	if d.git != nil {
+		gitClient = d.git
+	}
+	var ghClient github.GitHubClient
+	if d.gh != nil {
+		ghClient = d.gh
+	}
+	return d.fs, gitClient, ghClient
+}
+
+// dryRunEnabled reports whether --dry-run is set for cmd.
+func dryRunEnabled(cmd *cobra.Command) bool {
+	if cmd == nil {
+		return false
+	}
+	enabled, _ := cmd.Flags().GetBool(dryRunFlag)
+	return enabled
+}
+
+// enable starts recording if --dry-run is set.
+func (d *dryRun) enable(cmd *cobra.Command) {
+	if !dryRunEnabled(cmd) {
+		return
+	}
+
+	d.fs.Enable()
+	if d.git != nil {
+		d.git.Enable()
+	}
+	if d.gh != nil {
+		d.gh.Enable()
+	}
+}
+
+// report prints a unified diff of every file that would change and every
+// git and GitHub operation that would be made.
+func (d *dryRun) report(w io.Writer) error {
+	if !d.fs.Enabled() {
+		return nil
+	}
+
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintln(w, "🔍 Dry run: nothing was written, tagged, pushed or sent to GitHub")
+
+	changes := d.fs.Changes()
+	var gitOps, ghOps []string
+	if d.git != nil {
+		gitOps = d.git.Operations()
+	}
+	if d.gh != nil {
+		ghOps = d.gh.Operations()
+	}
+
+	if len(changes) == 0 && len(gitOps) == 0 && len(ghOps) == 0 {
+		_, _ = fmt.Fprintln(w, "No changes would be made")
+		return nil
+	}
+
+	cwd, _ := d.fs.Getwd()
+	for _, change := range changes {
+		diff, err := unifiedDiff(change, displayPath(cwd, change.Path))
+		if err != nil {
+			return fmt.Errorf("failed to diff %s: %w", change.Path, err)
+		}
+		_, _ = fmt.Fprintln(w)
+		_, _ = fmt.Fprint(w, diff)
+	}
+
+	writeOperations(w, "Git", gitOps)
+	writeOperations(w, "GitHub", ghOps)
+	return nil
+}
+
+func writeOperations(w io.Writer, title string, operations []string) {
+	if len(operations) == 0 {
+		return
+	}
+
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintf(w, "%s:\n", title)
+	for _, op := range operations {
+		_, _ = fmt.Fprintf(w, "  %s\n", op)
+	}
+}
+
+func unifiedDiff(change filesystem.FileChange, path string) (string, error) {
+	from, to := "a/"+path, "b/"+path
+	if change.Before == nil {
+		from = "/dev/null"
+	}
+	if change.After == nil {
+		to = "/dev/null"
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        splitLines(change.Before),
+		B:        splitLines(change.After),
+		FromFile: from,
+		ToFile:   to,
+		Context:  3,
+	})
+}
+
+func splitLines(data []byte) []string {
+	if len(data) == 0 {
+		return nil
+	}
+	lines := strings.SplitAfter(string(data), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	} else {
+		lines[len(lines)-1] += "\n\\ No newline at end of file\n"
+	}
+	return lines
+}
+
+// displayPath returns path relative to dir if it is inside dir.
+func displayPath(dir, path string) string {
+	if dir == "" {
+		return path
+	}
+	rel, err := filepath.Rel(dir, path)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return path
+	}
+	return filepath.ToSlash(rel)
+}
@@ -0,0 +1,66 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jakoblorz/go-changesets/internal/git"
+	"github.com/jakoblorz/go-changesets/internal/workspace"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDryRun_Version(t *testing.T) {
+	fs := buildDependentsWorkspace(t)
+	before := make(map[string]string)
+	for path, file := range fs.GetFiles() {
+		before[path] = string(file.Content)
+	}
+
+	var out bytes.Buffer
+	cmd := NewRootCommand(fs, git.NewMockGitClient(), nil)
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--dry-run", "version", "--project", "shared"})
+	require.NoError(t, cmd.Execute())
+
+	after := make(map[string]string)
+	for path, file := range fs.GetFiles() {
+		after[path] = string(file.Content)
+	}
+	require.Equal(t, before, after)
+
+	report := out.String()
+	require.Contains(t, report, "--- a/shared/version.txt\n+++ b/shared/version.txt\n@@ -1 +1 @@\n-1.2.0\n+1.3.0\n")
+	require.Contains(t, report, "--- a/backend/version.txt\n+++ b/backend/version.txt\n@@ -1 +1 @@\n-1.0.0\n+1.0.1\n")
+	require.Contains(t, report, "--- /dev/null\n+++ b/shared/CHANGELOG.md\n")
+	require.Contains(t, report, "+- Add retry helpers\n")
+	require.Contains(t, report, "--- a/.changeset/shared-feature.md\n+++ /dev/null\n")
+}
+
+func TestDryRun_Publish(t *testing.T) {
+	_, fs := buildWorkspace(t, func(wb *workspace.WorkspaceBuilder) {
+		wb.AddProject("auth", "services/auth", "github.com/example/auth")
+		wb.SetVersion("auth", "1.3.0")
+	})
+	gitClient := git.NewMockGitClient()
+
+	var out bytes.Buffer
+	cmd := NewRootCommand(fs, gitClient, nil)
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--dry-run", "publish", "--project", "auth"})
+	require.NoError(t, cmd.Execute())
+
+	require.Empty(t, gitClient.GetAllTags())
+	require.Contains(t, out.String(), "Git:\n  create tag auth@v1.3.0\n  push tag auth@v1.3.0\n")
+}
+
+func TestDryRun_NoChanges(t *testing.T) {
+	fs := buildDependentsWorkspace(t)
+
+	var out bytes.Buffer
+	cmd := NewRootCommand(fs, git.NewMockGitClient(), nil)
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--dry-run", "status"})
+	require.NoError(t, cmd.Execute())
+
+	require.Contains(t, out.String(), "No changes would be made\n")
+}
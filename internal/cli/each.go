@@ -3,11 +3,14 @@ package cli
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/jakoblorz/go-changesets/internal/filesystem"
 	"github.com/jakoblorz/go-changesets/internal/git"
@@ -26,7 +29,72 @@ type EachCommand struct {
 	projects      string
 	workspaceOpts []workspace.Option
 
+	// dryRun is passed on to the command via CHANGESET_DRY_RUN
+	dryRun bool
+
+	// concurrency is the number of projects the command runs for at once
+	concurrency int
+
+	// output is how the output of concurrent runs is shown: prefix or group
+	output string
+
+	// includeDependents extends a changed-since filter to the projects
+	// depending on a changed project
+	includeDependents bool
+
+	// order is the order projects run in: discovery or topological
+	order string
+
+	// report is the path of the run report, written if set
+	report string
+
+	// reportFormat is the format of the run report: json or junit
+	reportFormat string
+
+	// dependencies are, per project, the projects of the run it depends on.
+	// They are only set for the topological order.
+	dependencies map[string][]string
+
 	stdoutWriter io.Writer
+
+	// now returns the current time, for the durations in the summary
+	now func() time.Time
+}
+
+const (
+	eachOutputPrefix = "prefix"
+	eachOutputGroup  = "group"
+
+	eachOrderDiscovery   = "discovery"
+	eachOrderTopological = "topological"
+)
+
+// eachResult is the outcome of running the command for one project
+type eachResult struct {
+	Project  string
+	Path     string
+	Err      error
+	ExitCode int
+	Duration time.Duration
+
+	// Skipped is set if the command did not run because a dependency failed
+	Skipped bool
+
+	// Stdout and Stderr are the tails of the command's output, kept for the report
+	Stdout string
+	Stderr string
+}
+
+// status returns the outcome of the result: success, failed or skipped
+func (r eachResult) status() string {
+	switch {
+	case r.Skipped:
+		return "skipped"
+	case r.Err != nil:
+		return "failed"
+	default:
+		return "success"
+	}
 }
 
 // NewEachCommand creates a new each command
@@ -48,9 +116,41 @@ Filters:
   outdated-versions - Projects where version.txt > latest git tag
   has-version       - Projects with a version source file
   no-version        - Projects without a version source file
+  unchanged         - Projects without changesets
+  grouped           - Projects in a fixed or linked version group
+
+Filters are expressions that combine these names and comparisons of project
+fields with && (and), || (or), ! (not) and parentheses, e.g.
+  type==go && bump>=minor && path=apps/** && !name=legacy-*
+Fields: name, path, type, group, category (glob match with = or ==, and !=),
+bump (none < patch < minor < major), version and latest (semantic versions)
+and changesets (count), compared with ==, !=, <, <=, > and >=.
+Relative paths are matched below the workspace root. Repeated --filter flags
+must all match, and so must the names in a comma-separated list such as
+open-changesets,outdated-versions.
+
+changed-since=<ref> selects projects with files changed between the merge base
+of <ref> and HEAD, including uncommitted files. With --include-dependents, it
+also selects the projects depending on them. The changed files are in the
+changedFiles and changedDependencies fields of the JSON context.
 
 The command receives a JSON object via STDIN with project context.
-Environment variables are also set: PROJECT, PROJECT_PATH, CURRENT_VERSION, LATEST_TAG`,
+Environment variables are also set: PROJECT, PROJECT_PATH, CURRENT_VERSION, LATEST_TAG.
+With --dry-run, CHANGESET_DRY_RUN=true is set so that changeset commands run as a dry run too.
+
+With --concurrency N, up to N projects run at once. Their output is either
+prefixed with the project name line by line (--output=prefix) or shown in one
+block per project when it finishes (--output=group). A summary of the status
+and duration of every project is printed at the end.
+
+With --order=topological, projects run after the workspace projects they
+depend on (go.mod requires, package.json dependencies); with --concurrency,
+independent projects run in parallel. Projects whose dependencies failed are
+skipped. The default order is the order projects were discovered in.
+
+With --report <path>, a report of the run is written for CI: the command, exit
+code, duration and last lines of stdout and stderr of every project, as JSON or, with
+--report-format=junit, as JUnit XML.`,
 		Example: `  # Version all projects with changesets
   changeset each --filter=open-changesets -- changeset version
 
@@ -60,19 +160,43 @@ Environment variables are also set: PROJECT, PROJECT_PATH, CURRENT_VERSION, LATE
   # Publish project1 only if outdated
   changeset each --filter=outdated-versions --projects=project1 -- changeset publish --owner org --repo repo
 
+  # Go projects below apps/ with at least a minor bump, except legacy ones
+  changeset each --filter='type==go && bump>=minor && path=apps/** && !name=legacy-*' -- changeset version
+
+  # Test the projects changed in a pull request and the projects depending on them
+  changeset each --filter=changed-since=origin/main --include-dependents -- bash -c 'cd "$PROJECT_PATH" && go test ./...'
+
   # Custom script
   changeset each --filter=open-changesets -- bash -c 'echo "Releasing $PROJECT"'
 
   # Custom script with context from before the versioning (captured via tree file)
-  changeset each --from-tree-file=/tmp/tree.json -- bash -c 'echo "Releasing $PROJECT"'`,
+  changeset each --from-tree-file=/tmp/tree.json -- bash -c 'echo "Releasing $PROJECT"'
+
+  # Publish dependencies before the projects requiring them
+  changeset each --filter=outdated-versions --order=topological -- changeset publish --owner org --repo repo
+
+  # Test 8 projects at a time, showing each project's output when it finishes
+  changeset each --concurrency 8 --output group -- bash -c 'cd "$PROJECT_PATH" && go test ./...'
+
+  # Report the result of every project to the CI as JUnit XML
+  changeset each --report=reports/each.xml --report-format=junit -- bash -c 'cd "$PROJECT_PATH" && go test ./...'`,
 		RunE: cmd.Run,
 	}
 
-	cobraCmd.Flags().StringSliceVar(&cmd.filters, "filter", []string{"all"},
-		"Filter projects (open-changesets, outdated-versions, has-version, no-version, unchanged, all)")
+	cobraCmd.Flags().StringArrayVar(&cmd.filters, "filter", []string{"all"},
+		"Filter expression, e.g. open-changesets or 'type==go && bump>=minor' (repeatable, all must match)")
 	cobraCmd.Flags().StringVar(&cmd.fromTreeFile, "from-tree-file", "",
 		"Read projects from a tree JSON file instead of workspace filters")
 	cobraCmd.Flags().StringVar(&cmd.projects, "projects", "", "Select specific projects, comma-separated")
+	cobraCmd.Flags().IntVar(&cmd.concurrency, "concurrency", 1, "Number of projects to run the command for at once")
+	cobraCmd.Flags().StringVar(&cmd.output, "output", eachOutputPrefix,
+		"Output of concurrent runs: prefix (lines prefixed with the project) or group (one block per project)")
+	cobraCmd.Flags().BoolVar(&cmd.includeDependents, "include-dependents", false,
+		"With a changed-since filter, also select projects depending on a changed project")
+	cobraCmd.Flags().StringVar(&cmd.order, "order", eachOrderDiscovery,
+		"Order to run projects in: discovery or topological (dependencies first)")
+	cobraCmd.Flags().StringVar(&cmd.report, "report", "", "Write a report of the run to this path")
+	cobraCmd.Flags().StringVar(&cmd.reportFormat, "report-format", eachReportJSON, "Format of the report: json or junit")
 
 	return cobraCmd
 }
@@ -85,13 +209,43 @@ func (c *EachCommand) getStdoutWriter() io.Writer {
 	return c.stdoutWriter
 }
 
+// clock returns the current time, using time.Now if now is not set
+func (c *EachCommand) clock() time.Time {
+	if c.now == nil {
+		return time.Now()
+	}
+	return c.now()
+}
+
 // Run executes the each command
 func (c *EachCommand) Run(cmd *cobra.Command, args []string) error {
 	if len(args) == 0 {
 		return fmt.Errorf("no command specified (use -- before command)")
 	}
 	c.command = args
-	c.workspaceOpts = workspaceOptionsFromCmd(cmd)
+	if c.concurrency < 0 {
+		return fmt.Errorf("invalid --concurrency: %d (must not be negative)", c.concurrency)
+	}
+	if c.output == "" {
+		c.output = eachOutputPrefix
+	}
+	if c.output != eachOutputPrefix && c.output != eachOutputGroup {
+		return fmt.Errorf("invalid --output: %s (must be %s or %s)", c.output, eachOutputPrefix, eachOutputGroup)
+	}
+	if c.order == "" {
+		c.order = eachOrderDiscovery
+	}
+	if c.order != eachOrderDiscovery && c.order != eachOrderTopological {
+		return fmt.Errorf("invalid --order: %s (must be %s or %s)", c.order, eachOrderDiscovery, eachOrderTopological)
+	}
+	if c.reportFormat == "" {
+		c.reportFormat = eachReportJSON
+	}
+	if c.reportFormat != eachReportJSON && c.reportFormat != eachReportJUnit {
+		return fmt.Errorf("invalid --report-format: %s (must be %s or %s)", c.reportFormat, eachReportJSON, eachReportJUnit)
+	}
+	c.workspaceOpts = workspaceOptionsFromCmd(cmd, c.git)
+	c.dryRun = dryRunEnabled(cmd)
 
 	if c.fromTreeFile != "" {
 		return c.runFromTreeFile()
@@ -117,12 +271,16 @@ func (c *EachCommand) runFromWorkspace() error {
 		return nil
 	}
 
-	filterTypes, err := parseFilters(c.filters)
+	filter, err := parseFilters(c.filters)
 	if err != nil {
 		return fmt.Errorf("failed to parse filters: %w", err)
 	}
 
-	filtered, err := filterContexts(contexts, filterTypes)
+	if err := setChangedSince(ws, c.git, contexts, filter, c.includeDependents); err != nil {
+		return err
+	}
+
+	filtered, err := filterContexts(contexts, filter)
 	if err != nil {
 		return fmt.Errorf("failed to filter projects: %w", err)
 	}
@@ -165,12 +323,22 @@ func (c *EachCommand) runFromTreeFile() error {
 		return nil
 	}
 
-	filterTypes, err := parseFilters(c.filters)
+	filter, err := parseFilters(c.filters)
 	if err != nil {
 		return fmt.Errorf("failed to parse filters: %w", err)
 	}
 
-	filtered, err := filterContexts(contexts, filterTypes)
+	if len(models.ChangedSinceRefs(filter)) > 0 || c.includeDependents {
+		ws := workspace.New(c.fs, c.workspaceOpts...)
+		if err := ws.Detect(); err != nil {
+			return fmt.Errorf("failed to detect workspace: %w", err)
+		}
+		if err := setChangedSince(ws, c.git, contexts, filter, c.includeDependents); err != nil {
+			return err
+		}
+	}
+
+	filtered, err := filterContexts(contexts, filter)
 	if err != nil {
 		return fmt.Errorf("failed to filter projects: %w", err)
 	}
@@ -193,9 +361,151 @@ func (c *EachCommand) runFromTreeFile() error {
 }
 
 func (c *EachCommand) executeForContexts(contexts []*models.ProjectContext) error {
-	fmt.Fprintf(c.getStdoutWriter(), "Running command for %d project(s)...\n\n", len(contexts))
+	if c.order == eachOrderTopological {
+		sorted, err := c.sortTopologically(contexts)
+		if err != nil {
+			return err
+		}
+		contexts = sorted
+	}
+
+	start := c.clock()
+	var results []eachResult
+	if c.concurrency > 1 && len(contexts) > 1 {
+		fmt.Fprintf(c.getStdoutWriter(), "Running command for %d project(s), %d at a time...\n\n", len(contexts), min(c.concurrency, len(contexts)))
+		results = c.executeConcurrently(contexts)
+	} else {
+		fmt.Fprintf(c.getStdoutWriter(), "Running command for %d project(s)...\n\n", len(contexts))
+		results = c.executeSequentially(contexts)
+	}
+
+	c.printSummary(results)
+
+	if c.report != "" {
+		if err := c.writeReport(results, c.clock().Sub(start)); err != nil {
+			return err
+		}
+	}
+
+	var failed, skipped []string
+	for _, result := range results {
+		switch {
+		case result.Skipped:
+			skipped = append(skipped, result.Project)
+		case result.Err != nil:
+			failed = append(failed, result.Project)
+		}
+	}
+
+	if len(failed) > 0 {
+		fmt.Fprintf(c.getStdoutWriter(), "\n⚠️  %d project(s) failed: %s\n", len(failed), strings.Join(failed, ", "))
+		if len(skipped) > 0 {
+			fmt.Fprintf(c.getStdoutWriter(), "⚠️  %d project(s) skipped: %s\n", len(skipped), strings.Join(skipped, ", "))
+		}
+		return fmt.Errorf("some projects failed")
+	}
+
+	return nil
+}
+
+// sortTopologically orders contexts so that projects come after the projects
+// they depend on, and records for every project the projects of the run it
+// has to wait for.
+func (c *EachCommand) sortTopologically(contexts []*models.ProjectContext) ([]*models.ProjectContext, error) {
+	ws := workspace.New(c.fs, c.workspaceOpts...)
+	if err := ws.Detect(); err != nil {
+		return nil, fmt.Errorf("failed to detect workspace: %w", err)
+	}
+
+	graph, err := ws.DependencyGraph()
+	if err != nil {
+		return nil, fmt.Errorf("failed to build dependency graph: %w", err)
+	}
+
+	byName := make(map[string]*models.ProjectContext, len(contexts))
+	names := make([]string, 0, len(contexts))
+	for _, ctx := range contexts {
+		byName[ctx.Project] = ctx
+		names = append(names, ctx.Project)
+	}
+
+	order, err := graph.TopologicalOrder(names)
+	if err != nil {
+		return nil, fmt.Errorf("failed to order projects topologically: %w", err)
+	}
+
+	c.dependencies = make(map[string][]string)
+	sorted := make([]*models.ProjectContext, 0, len(order))
+	for _, name := range order {
+		sorted = append(sorted, byName[name])
+		for _, dep := range graph.AllDependencies(name) {
+			if byName[dep] != nil {
+				c.dependencies[name] = append(c.dependencies[name], dep)
+			}
+		}
+	}
+	return sorted, nil
+}
+
+// failedDependency returns a project of the run that project depends on and
+// that failed, or "" if there is none. The dependencies are transitive, so a
+// skipped dependency always has a failed one among them.
+func (c *EachCommand) failedDependency(project string, resultOf func(string) eachResult) string {
+	for _, dep := range c.dependencies[project] {
+		if result := resultOf(dep); result.Err != nil && !result.Skipped {
+			return dep
+		}
+	}
+	return ""
+}
+
+// runProject runs the command for a project unless one of its dependencies
+// failed, in which case the project is skipped
+func (c *EachCommand) runProject(ctx *models.ProjectContext, stdout, stderr io.Writer, resultOf func(string) eachResult) eachResult {
+	if dep := c.failedDependency(ctx.Project, resultOf); dep != "" {
+		return eachResult{Project: ctx.Project, Path: ctx.ProjectPath, Skipped: true, Err: fmt.Errorf("dependency %s failed", dep)}
+	}
+
+	// The output is only copied for a report, so that without one the
+	// command writes to the terminal directly
+	var stdoutTail, stderrTail *tailWriter
+	if c.report != "" {
+		stdoutTail = newTailWriter(eachReportTailLines)
+		stderrTail = newTailWriter(eachReportTailLines)
+		stdout = io.MultiWriter(stdout, stdoutTail)
+		stderr = io.MultiWriter(stderr, stderrTail)
+	}
+
+	start := c.clock()
+	err := c.executeForProject(ctx, stdout, stderr)
+	result := eachResult{Project: ctx.Project, Path: ctx.ProjectPath, Err: err, ExitCode: exitCode(err), Duration: c.clock().Sub(start)}
+	if c.report != "" {
+		result.Stdout = stdoutTail.String()
+		result.Stderr = stderrTail.String()
+	}
+	return result
+}
+
+// exitCode returns the exit code of a command that returned err, or -1 if
+// the command could not be run
+func exitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return exitErr.ExitCode()
+	}
+	return -1
+}
+
+// executeSequentially runs the command for one project after another,
+// streaming its output
+func (c *EachCommand) executeSequentially(contexts []*models.ProjectContext) []eachResult {
+	results := make([]eachResult, 0, len(contexts))
+	byProject := make(map[string]eachResult, len(contexts))
+	resultOf := func(project string) eachResult { return byProject[project] }
 
-	var failed []string
 	for i, ctx := range contexts {
 		if i > 0 {
 			fmt.Fprintln(c.getStdoutWriter(), "\n"+strings.Repeat("-", 60)+"\n")
@@ -203,25 +513,125 @@ func (c *EachCommand) executeForContexts(contexts []*models.ProjectContext) erro
 
 		fmt.Fprintf(c.getStdoutWriter(), "📦 [%d/%d] %s\n", i+1, len(contexts), ctx.Project)
 
-		if err := c.executeForProject(ctx); err != nil {
-			fmt.Fprintf(c.getStdoutWriter(), "❌ Failed: %v\n", err)
-			failed = append(failed, ctx.Project)
-			continue
-		}
+		result := c.runProject(ctx, c.getStdoutWriter(), os.Stderr, resultOf)
+		results = append(results, result)
+		byProject[ctx.Project] = result
+
+		c.printStatus(result)
+	}
+	return results
+}
+
+// executeConcurrently runs the command for up to c.concurrency projects at
+// once. A project starts only after the projects it depends on finished.
+// The results are in the order of contexts.
+func (c *EachCommand) executeConcurrently(contexts []*models.ProjectContext) []eachResult {
+	results := make([]eachResult, len(contexts))
+	done := make([]chan struct{}, len(contexts))
+	index := make(map[string]int, len(contexts))
+	for i, ctx := range contexts {
+		done[i] = make(chan struct{})
+		index[ctx.Project] = i
+	}
+	resultOf := func(project string) eachResult { return results[index[project]] }
+
+	var mu sync.Mutex
+	finished := 0
+	sem := make(chan struct{}, c.concurrency)
+	var wg sync.WaitGroup
 
+	for i, ctx := range contexts {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			defer close(done[i])
+
+			for _, dep := range c.dependencies[ctx.Project] {
+				<-done[index[dep]]
+			}
+
+			sem <- struct{}{}
+			// exec copies stdout and stderr in parallel, so the group
+			// buffer they share needs a lock
+			var group bytes.Buffer
+			groupWriter := &lockedWriter{w: &group}
+			stdout := newPrefixWriter(&mu, c.getStdoutWriter(), ctx.Project)
+			stderr := newPrefixWriter(&mu, os.Stderr, ctx.Project)
+			if c.output == eachOutputGroup {
+				results[i] = c.runProject(ctx, groupWriter, groupWriter, resultOf)
+			} else {
+				results[i] = c.runProject(ctx, stdout, stderr, resultOf)
+				stdout.Flush()
+				stderr.Flush()
+			}
+			<-sem
+
+			mu.Lock()
+			defer mu.Unlock()
+			finished++
+
+			out := c.getStdoutWriter()
+			if c.output == eachOutputGroup {
+				if finished > 1 {
+					fmt.Fprintln(out, "\n"+strings.Repeat("-", 60)+"\n")
+				}
+				fmt.Fprintf(out, "📦 [%d/%d] %s\n", finished, len(contexts), ctx.Project)
+				_, _ = out.Write(group.Bytes())
+				c.printStatus(results[i])
+				return
+			}
+
+			result := results[i]
+			switch {
+			case result.Skipped:
+				fmt.Fprintf(out, "⏭️  [%d/%d] %s skipped: %v\n", finished, len(contexts), ctx.Project, result.Err)
+			case result.Err != nil:
+				fmt.Fprintf(out, "❌ [%d/%d] %s failed: %v\n", finished, len(contexts), ctx.Project, result.Err)
+			default:
+				fmt.Fprintf(out, "✓ [%d/%d] %s succeeded\n", finished, len(contexts), ctx.Project)
+			}
+		}()
+	}
+
+	wg.Wait()
+	return results
+}
+
+// printStatus prints the outcome of a project below its output
+func (c *EachCommand) printStatus(result eachResult) {
+	switch {
+	case result.Skipped:
+		fmt.Fprintf(c.getStdoutWriter(), "⏭️  Skipped: %v\n", result.Err)
+	case result.Err != nil:
+		fmt.Fprintf(c.getStdoutWriter(), "❌ Failed: %v\n", result.Err)
+	default:
 		fmt.Fprintln(c.getStdoutWriter(), "✓ Success")
 	}
+}
 
-	if len(failed) > 0 {
-		fmt.Fprintf(c.getStdoutWriter(), "\n⚠️  %d project(s) failed: %s\n", len(failed), strings.Join(failed, ", "))
-		return fmt.Errorf("some projects failed")
+// printSummary prints the status and duration of every project
+func (c *EachCommand) printSummary(results []eachResult) {
+	width := 0
+	for _, result := range results {
+		width = max(width, len(result.Project))
 	}
 
-	return nil
+	out := c.getStdoutWriter()
+	fmt.Fprintln(out, "\nSummary:")
+	for _, result := range results {
+		status := "✓ success"
+		switch {
+		case result.Skipped:
+			status = "⏭️  skipped"
+		case result.Err != nil:
+			status = "❌ failed "
+		}
+		fmt.Fprintf(out, "  %-*s  %s  %s\n", width, result.Project, status, result.Duration.Round(time.Millisecond))
+	}
 }
 
 // executeForProject executes the command for a single project
-func (c *EachCommand) executeForProject(ctx *models.ProjectContext) error {
+func (c *EachCommand) executeForProject(ctx *models.ProjectContext, stdout, stderr io.Writer) error {
 	jsonData, err := json.MarshalIndent(ctx, "", "  ")
 	if err != nil {
 		return fmt.Errorf("failed to marshal context: %w", err)
@@ -232,8 +642,8 @@ func (c *EachCommand) executeForProject(ctx *models.ProjectContext) error {
 
 	execCmd := exec.Command(cmdName, cmdArgs...)
 	execCmd.Stdin = bytes.NewReader(jsonData)
-	execCmd.Stdout = c.getStdoutWriter()
-	execCmd.Stderr = os.Stderr
+	execCmd.Stdout = stdout
+	execCmd.Stderr = stderr
 
 	execCmd.Env = append(os.Environ(),
 		fmt.Sprintf("PROJECT=%s", ctx.Project),
@@ -244,6 +654,67 @@ func (c *EachCommand) executeForProject(ctx *models.ProjectContext) error {
 		fmt.Sprintf("CHANGESET_CONTEXT=%s", string(jsonData)),
 		// make sure to update the each_test.go env cases if you add more variables
 	)
+	if c.dryRun {
+		execCmd.Env = append(execCmd.Env, envVarName(dryRunFlag)+"=true")
+	}
 
 	return execCmd.Run()
 }
+
+// prefixWriter writes the complete lines written to it to w, each prefixed
+// with "[name] ". Writers sharing mu never interleave within a line.
+type prefixWriter struct {
+	mu     *sync.Mutex
+	w      io.Writer
+	prefix []byte
+	buf    []byte
+}
+
+func newPrefixWriter(mu *sync.Mutex, w io.Writer, name string) *prefixWriter {
+	return &prefixWriter{mu: mu, w: w, prefix: []byte("[" + name + "] ")}
+}
+
+func (p *prefixWriter) Write(data []byte) (int, error) {
+	p.buf = append(p.buf, data...)
+
+	end := bytes.LastIndexByte(p.buf, '\n')
+	if end == -1 {
+		return len(data), nil
+	}
+
+	var out bytes.Buffer
+	for _, line := range bytes.SplitAfter(p.buf[:end+1], []byte("\n")) {
+		if len(line) > 0 {
+			out.Write(p.prefix)
+			out.Write(line)
+		}
+	}
+	p.buf = append(p.buf[:0], p.buf[end+1:]...)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if _, err := p.w.Write(out.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(data), nil
+}
+
+// Flush writes a trailing line that does not end in a newline
+func (p *prefixWriter) Flush() {
+	if len(p.buf) == 0 {
+		return
+	}
+	_, _ = p.Write([]byte("\n"))
+}
+
+// lockedWriter serializes writes to w
+type lockedWriter struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+func (l *lockedWriter) Write(data []byte) (int, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.w.Write(data)
+}
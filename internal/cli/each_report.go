@@ -0,0 +1,248 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	eachReportJSON  = "json"
+	eachReportJUnit = "junit"
+
+	// eachReportTailLines is the number of output lines kept per project
+	eachReportTailLines = 20
+)
+
+// eachReport is the JSON report of an each run
+type eachReport struct {
+	Command    []string            `json:"command"`
+	DurationMs int64               `json:"durationMs"`
+	Succeeded  int                 `json:"succeeded"`
+	Failed     int                 `json:"failed"`
+	Skipped    int                 `json:"skipped"`
+	Projects   []eachProjectReport `json:"projects"`
+}
+
+// eachProjectReport is the outcome of one project in the JSON report
+type eachProjectReport struct {
+	Project    string   `json:"project"`
+	Path       string   `json:"path"`
+	Command    []string `json:"command"`
+	Status     string   `json:"status"`
+	ExitCode   *int     `json:"exitCode,omitempty"`
+	DurationMs int64    `json:"durationMs"`
+	Error      string   `json:"error,omitempty"`
+	Stdout     string   `json:"stdout,omitempty"`
+	Stderr     string   `json:"stderr,omitempty"`
+}
+
+// JUnit XML, as read by GitHub, GitLab and Jenkins test reporters
+type junitTestSuites struct {
+	XMLName  xml.Name     `xml:"testsuites"`
+	Name     string       `xml:"name,attr"`
+	Tests    int          `xml:"tests,attr"`
+	Failures int          `xml:"failures,attr"`
+	Skipped  int          `xml:"skipped,attr"`
+	Time     string       `xml:"time,attr"`
+	Suites   []junitSuite `xml:"testsuite"`
+}
+
+type junitSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Errors    int             `xml:"errors,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	Time      string          `xml:"time,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut *junitOutput  `xml:"system-out,omitempty"`
+	SystemErr *junitOutput  `xml:"system-err,omitempty"`
+}
+
+type junitOutput struct {
+	Text string `xml:",cdata"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+	Text    string `xml:",cdata"`
+}
+
+// writeReport writes the report of results to c.report
+func (c *EachCommand) writeReport(results []eachResult, duration time.Duration) error {
+	var data []byte
+	var err error
+	if c.reportFormat == eachReportJUnit {
+		data, err = c.junitReport(results, duration)
+	} else {
+		data, err = c.jsonReport(results, duration)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to encode report: %w", err)
+	}
+
+	if dir := filepath.Dir(c.report); dir != "." {
+		if err := c.fs.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("failed to create report directory: %w", err)
+		}
+	}
+	if err := c.fs.WriteFile(c.report, data, 0644); err != nil {
+		return fmt.Errorf("failed to write report: %w", err)
+	}
+
+	fmt.Fprintf(c.getStdoutWriter(), "📝 Wrote %s report to %s\n", c.reportFormat, c.report)
+	return nil
+}
+
+func (c *EachCommand) jsonReport(results []eachResult, duration time.Duration) ([]byte, error) {
+	report := eachReport{
+		Command:    c.command,
+		DurationMs: duration.Milliseconds(),
+		Projects:   make([]eachProjectReport, 0, len(results)),
+	}
+
+	for _, result := range results {
+		project := eachProjectReport{
+			Project:    result.Project,
+			Path:       result.Path,
+			Command:    c.command,
+			Status:     result.status(),
+			DurationMs: result.Duration.Milliseconds(),
+			Stdout:     result.Stdout,
+			Stderr:     result.Stderr,
+		}
+		if !result.Skipped {
+			exitCode := result.ExitCode
+			project.ExitCode = &exitCode
+		}
+		if result.Err != nil {
+			project.Error = result.Err.Error()
+		}
+
+		switch {
+		case result.Skipped:
+			report.Skipped++
+		case result.Err != nil:
+			report.Failed++
+		default:
+			report.Succeeded++
+		}
+		report.Projects = append(report.Projects, project)
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+func (c *EachCommand) junitReport(results []eachResult, duration time.Duration) ([]byte, error) {
+	const name = "changeset each"
+	command := strings.Join(c.command, " ")
+
+	suite := junitSuite{
+		Name:  name,
+		Tests: len(results),
+		Time:  junitSeconds(duration),
+	}
+	for _, result := range results {
+		testCase := junitTestCase{
+			Name:      result.Project,
+			ClassName: name,
+			Time:      junitSeconds(result.Duration),
+		}
+		switch {
+		case result.Skipped:
+			suite.Skipped++
+			testCase.Skipped = &junitMessage{Message: result.Err.Error()}
+			suite.TestCases = append(suite.TestCases, testCase)
+			continue
+		case result.Err != nil:
+			suite.Failures++
+			testCase.Failure = &junitMessage{
+				Message: result.Err.Error(),
+				Type:    fmt.Sprintf("exit code %d", result.ExitCode),
+				Text:    result.Stderr,
+			}
+			if result.Stderr == "" {
+				testCase.Failure.Text = result.Stdout
+			}
+		}
+		testCase.SystemOut = &junitOutput{Text: "$ " + command + "\n" + result.Stdout}
+		if result.Stderr != "" {
+			testCase.SystemErr = &junitOutput{Text: result.Stderr}
+		}
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	report := junitTestSuites{
+		Name:     name,
+		Tests:    suite.Tests,
+		Failures: suite.Failures,
+		Skipped:  suite.Skipped,
+		Time:     suite.Time,
+		Suites:   []junitSuite{suite},
+	}
+
+	data, err := xml.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append([]byte(xml.Header), append(data, '\n')...), nil
+}
+
+// junitSeconds formats a duration as JUnit's fractional seconds
+func junitSeconds(d time.Duration) string {
+	return fmt.Sprintf("%.3f", d.Seconds())
+}
+
+// tailWriter keeps the last lines written to it
+type tailWriter struct {
+	mu    sync.Mutex
+	lines int
+	buf   []byte
+}
+
+func newTailWriter(lines int) *tailWriter {
+	return &tailWriter{lines: lines}
+}
+
+func (t *tailWriter) Write(data []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.buf = append(t.buf, data...)
+
+	// Keep the last t.lines lines, counting a trailing partial line
+	newlines := bytes.Count(t.buf, []byte("\n"))
+	if !bytes.HasSuffix(t.buf, []byte("\n")) {
+		newlines++
+	}
+	for ; newlines > t.lines; newlines-- {
+		t.buf = t.buf[bytes.IndexByte(t.buf, '\n')+1:]
+	}
+	return len(data), nil
+}
+
+// String returns the kept lines
+func (t *tailWriter) String() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return string(t.buf)
+}
@@ -2,11 +2,18 @@ package cli
 
 import (
 	"bytes"
+	"encoding/json"
 	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/gkampitakis/go-snaps/snaps"
 	"github.com/jakoblorz/go-changesets/internal/filesystem"
+	"github.com/jakoblorz/go-changesets/internal/git"
 	"github.com/jakoblorz/go-changesets/internal/workspace"
 	"github.com/stretchr/testify/require"
 )
@@ -147,6 +154,7 @@ func TestEach_FromTreeFile(t *testing.T) {
 		fromTreeFile: "/tmp/tree.json",
 		command:      []string{"sh", "-c", "echo $PROJECT:$PROJECT_PATH; echo $CHANGELOG_PREVIEW"},
 		stdoutWriter: &buf,
+		now:          fixedClock,
 	}
 
 	err := cmd.runFromTreeFile()
@@ -155,6 +163,393 @@ func TestEach_FromTreeFile(t *testing.T) {
 	snaps.MatchSnapshot(t, buf.String())
 }
 
+func fixedClock() time.Time {
+	return time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)
+}
+
+// addProjectsTreeFile writes a tree file listing projects with one changeset each
+func addProjectsTreeFile(fs *filesystem.MockFileSystem, projects ...string) {
+	var entries []string
+	for _, project := range projects {
+		entries = append(entries, fmt.Sprintf(`{"name": %q, "changesets": [{"id": "cs-%s", "bump": "patch", "message": "Fix %s"}]}`, project, project, project))
+	}
+	fs.AddFile("/tmp/tree.json", []byte(`{"groups": [{"commit": "abc123", "projects": [`+strings.Join(entries, ",")+`]}]}`))
+}
+
+func TestEach_Concurrency(t *testing.T) {
+	_, fs := buildWorkspace(t, func(wb *workspace.WorkspaceBuilder) {
+		wb.AddProject("auth", "auth", "github.com/example/auth")
+		wb.AddProject("api", "api", "github.com/example/api")
+		wb.AddProject("web", "web", "github.com/example/web")
+	})
+	addProjectsTreeFile(fs, "auth", "api", "web")
+
+	var buf bytes.Buffer
+	cmd := &EachCommand{
+		fs:           fs,
+		fromTreeFile: "/tmp/tree.json",
+		concurrency:  3,
+		stdoutWriter: &buf,
+		now:          fixedClock,
+	}
+
+	err := cmd.Run(nil, []string{"sh", "-c", `printf 'first\nsecond'; test "$PROJECT" != api`})
+	require.ErrorContains(t, err, "some projects failed")
+
+	output := buf.String()
+	require.Contains(t, output, "Running command for 3 project(s), 3 at a time...")
+	for _, project := range []string{"api", "auth", "web"} {
+		require.Contains(t, output, "["+project+"] first\n")
+		require.Contains(t, output, "["+project+"] second\n")
+	}
+	require.Contains(t, output, "api failed: exit status 1")
+	require.Contains(t, output, "\nSummary:\n  api   ❌ failed   0s\n  auth  ✓ success  0s\n  web   ✓ success  0s\n")
+	require.Contains(t, output, "1 project(s) failed: api")
+}
+
+func TestEach_ConcurrencyGroupOutput(t *testing.T) {
+	_, fs := buildWorkspace(t, func(wb *workspace.WorkspaceBuilder) {
+		wb.AddProject("auth", "auth", "github.com/example/auth")
+		wb.AddProject("api", "api", "github.com/example/api")
+	})
+	addProjectsTreeFile(fs, "auth", "api")
+
+	var buf bytes.Buffer
+	cmd := &EachCommand{
+		fs:           fs,
+		fromTreeFile: "/tmp/tree.json",
+		concurrency:  2,
+		output:       eachOutputGroup,
+		stdoutWriter: &buf,
+		now:          fixedClock,
+	}
+
+	require.NoError(t, cmd.Run(nil, []string{"sh", "-c", `echo "$PROJECT 1"; echo "$PROJECT 2" >&2`}))
+
+	output := buf.String()
+	for _, project := range []string{"api", "auth"} {
+		require.Regexp(t, `📦 \[\d/2\] `+project+`\n`+project+` 1\n`+project+` 2\n`, output)
+	}
+}
+
+func buildDependencyWorkspace(t *testing.T) *filesystem.MockFileSystem {
+	t.Helper()
+
+	_, fs := buildWorkspace(t, func(wb *workspace.WorkspaceBuilder) {
+		wb.AddProject("www", "www", "github.com/example/www")
+		wb.AddProject("tools", "tools", "github.com/example/tools")
+		wb.AddProject("backend", "backend", "github.com/example/backend")
+		wb.AddProject("shared", "shared", "github.com/example/shared")
+		wb.AddDependency("backend", "github.com/example/shared")
+		wb.AddDependency("www", "github.com/example/backend")
+	})
+	addProjectsTreeFile(fs, "www", "tools", "backend", "shared")
+	return fs
+}
+
+func TestEach_TopologicalOrder(t *testing.T) {
+	fs := buildDependencyWorkspace(t)
+	log := filepath.Join(t.TempDir(), "order.log")
+	t.Setenv("ORDER_LOG", log)
+
+	var buf bytes.Buffer
+	cmd := &EachCommand{
+		fs:           fs,
+		fromTreeFile: "/tmp/tree.json",
+		order:        eachOrderTopological,
+		concurrency:  4,
+		stdoutWriter: &buf,
+		now:          fixedClock,
+	}
+
+	require.NoError(t, cmd.Run(nil, []string{"sh", "-c", `sleep 0.05; echo "$PROJECT" >> "$ORDER_LOG"`}))
+
+	data, err := os.ReadFile(log)
+	require.NoError(t, err)
+	order := strings.Fields(string(data))
+	require.Len(t, order, 4)
+	require.Less(t, slices.Index(order, "shared"), slices.Index(order, "backend"))
+	require.Less(t, slices.Index(order, "backend"), slices.Index(order, "www"))
+
+	// The summary lists the projects in topological order
+	require.Contains(t, buf.String(), "Summary:\n  shared   ✓ success  0s\n  backend  ✓ success  0s\n  tools    ✓ success  0s\n  www      ✓ success  0s\n")
+}
+
+func TestEach_TopologicalOrderSkipsDependents(t *testing.T) {
+	fs := buildDependencyWorkspace(t)
+
+	var buf bytes.Buffer
+	cmd := &EachCommand{
+		fs:           fs,
+		fromTreeFile: "/tmp/tree.json",
+		order:        eachOrderTopological,
+		stdoutWriter: &buf,
+		now:          fixedClock,
+	}
+
+	err := cmd.Run(nil, []string{"sh", "-c", `test "$PROJECT" != shared`})
+	require.ErrorContains(t, err, "some projects failed")
+
+	output := buf.String()
+	require.Contains(t, output, "📦 [2/4] backend\n⏭️  Skipped: dependency shared failed\n")
+	require.Contains(t, output, "📦 [3/4] tools\n✓ Success\n")
+	require.Contains(t, output, "📦 [4/4] www\n⏭️  Skipped: dependency shared failed\n")
+	require.Contains(t, output, "1 project(s) failed: shared\n⚠️  2 project(s) skipped: backend, www\n")
+}
+
+func TestEach_Report(t *testing.T) {
+	fs := buildDependencyWorkspace(t)
+
+	var buf bytes.Buffer
+	cmd := &EachCommand{
+		fs:           fs,
+		fromTreeFile: "/tmp/tree.json",
+		order:        eachOrderTopological,
+		report:       "/reports/each.json",
+		stdoutWriter: &buf,
+		now:          fixedClock,
+	}
+
+	err := cmd.Run(nil, []string{"sh", "-c", `echo "testing $PROJECT"; if [ "$PROJECT" = shared ]; then echo boom >&2; exit 3; fi`})
+	require.ErrorContains(t, err, "some projects failed")
+	require.Contains(t, buf.String(), "📝 Wrote json report to /reports/each.json\n")
+
+	data, err := fs.ReadFile("/reports/each.json")
+	require.NoError(t, err)
+
+	var report eachReport
+	require.NoError(t, json.Unmarshal(data, &report))
+	require.Equal(t, 1, report.Succeeded)
+	require.Equal(t, 1, report.Failed)
+	require.Equal(t, 2, report.Skipped)
+	require.Len(t, report.Projects, 4)
+
+	shared := report.Projects[0]
+	require.Equal(t, "shared", shared.Project)
+	require.Equal(t, "failed", shared.Status)
+	require.Equal(t, 3, *shared.ExitCode)
+	require.Equal(t, "exit status 3", shared.Error)
+	require.Equal(t, "testing shared\n", shared.Stdout)
+	require.Equal(t, "boom\n", shared.Stderr)
+	require.Equal(t, cmd.command, shared.Command)
+
+	backend := report.Projects[1]
+	require.Equal(t, "skipped", backend.Status)
+	require.Nil(t, backend.ExitCode)
+	require.Equal(t, "dependency shared failed", backend.Error)
+
+	tools := report.Projects[2]
+	require.Equal(t, "success", tools.Status)
+	require.Equal(t, 0, *tools.ExitCode)
+	require.Equal(t, "testing tools\n", tools.Stdout)
+	require.Empty(t, tools.Stderr)
+}
+
+func TestEach_ReportJUnit(t *testing.T) {
+	fs := buildDependencyWorkspace(t)
+
+	cmd := &EachCommand{
+		fs:           fs,
+		fromTreeFile: "/tmp/tree.json",
+		order:        eachOrderTopological,
+		report:       "/reports/each.xml",
+		reportFormat: eachReportJUnit,
+		stdoutWriter: &bytes.Buffer{},
+		now:          fixedClock,
+	}
+
+	err := cmd.Run(nil, []string{"sh", "-c", `echo "testing $PROJECT"; [ "$PROJECT" != shared ] || { echo boom >&2; exit 1; }`})
+	require.ErrorContains(t, err, "some projects failed")
+
+	data, err := fs.ReadFile("/reports/each.xml")
+	require.NoError(t, err)
+	snaps.MatchSnapshot(t, string(data))
+}
+
+func TestEach_ReportConcurrentGroupOutput(t *testing.T) {
+	_, fs := buildWorkspace(t, func(wb *workspace.WorkspaceBuilder) {
+		wb.AddProject("auth", "auth", "github.com/example/auth")
+		wb.AddProject("api", "api", "github.com/example/api")
+	})
+	addProjectsTreeFile(fs, "auth", "api")
+
+	cmd := &EachCommand{
+		fs:           fs,
+		fromTreeFile: "/tmp/tree.json",
+		concurrency:  2,
+		output:       eachOutputGroup,
+		report:       "/reports/each.json",
+		stdoutWriter: &bytes.Buffer{},
+		now:          fixedClock,
+	}
+
+	script := `for i in 1 2 3 4 5; do echo "out $i"; echo "err $i" >&2; done`
+	require.NoError(t, cmd.Run(nil, []string{"sh", "-c", script}))
+
+	data, err := fs.ReadFile("/reports/each.json")
+	require.NoError(t, err)
+
+	var report eachReport
+	require.NoError(t, json.Unmarshal(data, &report))
+	for _, project := range report.Projects {
+		require.Equal(t, "out 1\nout 2\nout 3\nout 4\nout 5\n", project.Stdout)
+		require.Equal(t, "err 1\nerr 2\nerr 3\nerr 4\nerr 5\n", project.Stderr)
+	}
+}
+
+func TestEach_InvalidReportFormat(t *testing.T) {
+	cmd := &EachCommand{reportFormat: "tap"}
+	require.ErrorContains(t, cmd.Run(nil, []string{"echo"}), "invalid --report-format: tap (must be json or junit)")
+}
+
+func TestTailWriter(t *testing.T) {
+	tail := newTailWriter(2)
+	_, _ = tail.Write([]byte("one\ntwo\nthr"))
+	require.Equal(t, "two\nthr", tail.String())
+	_, _ = tail.Write([]byte("ee\nfour\n"))
+	require.Equal(t, "three\nfour\n", tail.String())
+}
+
+func TestEach_TopologicalOrderCycle(t *testing.T) {
+	_, fs := buildWorkspace(t, func(wb *workspace.WorkspaceBuilder) {
+		wb.AddProject("shared", "shared", "github.com/example/shared")
+		wb.AddProject("backend", "backend", "github.com/example/backend")
+		wb.AddDependency("backend", "github.com/example/shared")
+		wb.AddDependency("shared", "github.com/example/backend")
+	})
+	addProjectsTreeFile(fs, "shared", "backend")
+
+	cmd := &EachCommand{
+		fs:           fs,
+		fromTreeFile: "/tmp/tree.json",
+		order:        eachOrderTopological,
+		stdoutWriter: &bytes.Buffer{},
+	}
+
+	err := cmd.Run(nil, []string{"true"})
+	require.EqualError(t, err, "failed to order projects topologically: dependency cycle: backend -> shared -> backend")
+}
+
+func TestEach_FilterExpression(t *testing.T) {
+	_, fs := buildWorkspace(t, func(wb *workspace.WorkspaceBuilder) {
+		wb.AddProject("auth", "services/auth", "github.com/example/auth")
+		wb.AddProject("api", "services/api", "github.com/example/api")
+		wb.AddProject("web", "apps/web", "github.com/example/web")
+	})
+	addProjectsTreeFile(fs, "auth", "api", "web")
+
+	var buf bytes.Buffer
+	cmd := &EachCommand{
+		fs:           fs,
+		fromTreeFile: "/tmp/tree.json",
+		filters:      []string{"type==go && path=services/**", "!name=auth"},
+		stdoutWriter: &buf,
+		now:          fixedClock,
+	}
+	require.NoError(t, cmd.Run(nil, []string{"true"}))
+	require.Contains(t, buf.String(), "Running command for 1 project(s)...\n\n📦 [1/1] api\n")
+
+	cmd.filters = []string{"type==go &&"}
+	err := cmd.Run(nil, []string{"true"})
+	require.EqualError(t, err, `failed to parse filters: invalid filter "type==go &&": expected a condition but found end of filter at column 12`)
+}
+
+func TestEach_FilterFlagQuotedValues(t *testing.T) {
+	_, fs := buildWorkspace(t, func(wb *workspace.WorkspaceBuilder) {
+		wb.AddProject("auth", "services/auth", "github.com/example/auth")
+		wb.AddProject("web", "apps/web", "github.com/example/web")
+	})
+	addProjectsTreeFile(fs, "auth", "web")
+
+	var buf bytes.Buffer
+	cmd := NewEachCommand(fs, git.NewMockGitClient(), &buf)
+	cmd.SetArgs([]string{
+		"--from-tree-file", "/tmp/tree.json",
+		"--filter", `name="web" || name='auth,api'`,
+		"--filter", `!name="legacy api"`,
+		"--", "true",
+	})
+	require.NoError(t, cmd.Execute())
+	require.Contains(t, buf.String(), "Running command for 1 project(s)...\n\n📦 [1/1] web\n")
+}
+
+func TestEach_FilterFlagCommaSeparatedNames(t *testing.T) {
+	_, fs := buildWorkspace(t, func(wb *workspace.WorkspaceBuilder) {
+		wb.AddProject("auth", "services/auth", "github.com/example/auth")
+		wb.AddProject("api", "services/api", "github.com/example/api")
+		wb.AddProject("web", "apps/web", "github.com/example/web")
+		wb.SetVersion("auth", "1.0.0")
+		wb.SetVersion("web", "1.0.0")
+		wb.AddChangeset("api-fix", "api", "patch", "Fix api")
+		wb.AddChangeset("web-fix", "web", "patch", "Fix web")
+	})
+
+	var buf bytes.Buffer
+	cmd := NewEachCommand(fs, git.NewMockGitClient(), &buf)
+	cmd.SetArgs([]string{"--filter=has-version,open-changesets", "--", "true"})
+	require.NoError(t, cmd.Execute())
+	require.Contains(t, buf.String(), "Running command for 1 project(s)...\n\n📦 [1/1] web\n")
+}
+
+func TestEach_ChangedSince(t *testing.T) {
+	_, fs := buildWorkspace(t, func(wb *workspace.WorkspaceBuilder) {
+		wb.AddProject("shared", "packages/shared", "github.com/example/shared")
+		wb.AddProject("backend", "apps/backend", "github.com/example/backend")
+		wb.AddProject("www", "apps/www", "github.com/example/www")
+		wb.AddProject("tools", "tools", "github.com/example/tools")
+		wb.AddDependency("backend", "github.com/example/shared")
+		wb.AddDependency("www", "github.com/example/backend")
+	})
+
+	gitMock := git.NewMockGitClient()
+	require.NoError(t, gitMock.CreateBranch("feature"))
+	require.NoError(t, gitMock.CheckoutBranch("feature"))
+	commit := gitMock.CreateCommit("Fix shared helper")
+	gitMock.AddCommitFiles(commit, testWorkspaceRoot+"/packages/shared/util.go", testWorkspaceRoot+"/README.md")
+
+	run := func(includeDependents bool) string {
+		var buf bytes.Buffer
+		cmd := &EachCommand{
+			fs:                fs,
+			git:               gitMock,
+			filters:           []string{"changed-since=main"},
+			includeDependents: includeDependents,
+			stdoutWriter:      &buf,
+			now:               fixedClock,
+		}
+		require.NoError(t, cmd.Run(nil, []string{"cat"}))
+		return buf.String()
+	}
+
+	output := run(false)
+	require.Contains(t, output, "Running command for 1 project(s)...")
+	require.Contains(t, output, `"changedSince": "main",`+"\n"+`  "changedFiles": [`+"\n"+`    "packages/shared/util.go"`+"\n  ],")
+
+	output = run(true)
+	require.Contains(t, output, "Running command for 3 project(s)...")
+	require.Contains(t, output, "Summary:\n  shared   ✓ success  0s\n  backend  ✓ success  0s\n  www      ✓ success  0s\n")
+	require.Contains(t, output, `"changedDependencies": [`+"\n"+`    "shared"`+"\n  ],")
+	require.NotContains(t, output, "tools")
+}
+
+func TestEach_ChangedSinceErrors(t *testing.T) {
+	_, fs := buildWorkspace(t, func(wb *workspace.WorkspaceBuilder) {
+		wb.AddProject("shared", "packages/shared", "github.com/example/shared")
+	})
+	gitMock := git.NewMockGitClient()
+
+	cmd := &EachCommand{fs: fs, git: gitMock, filters: []string{"open-changesets"}, includeDependents: true, stdoutWriter: &bytes.Buffer{}}
+	require.EqualError(t, cmd.Run(nil, []string{"true"}), "--include-dependents requires a changed-since filter")
+
+	cmd = &EachCommand{fs: fs, git: gitMock, filters: []string{"changed-since=main || changed-since=v1"}, stdoutWriter: &bytes.Buffer{}}
+	require.EqualError(t, cmd.Run(nil, []string{"true"}), "only one changed-since ref is supported (got main, v1)")
+}
+
+func TestEach_InvalidOutput(t *testing.T) {
+	cmd := &EachCommand{output: "tee"}
+	require.ErrorContains(t, cmd.Run(nil, []string{"echo"}), "invalid --output: tee (must be prefix or group)")
+}
+
 func TestEach_NoCommand(t *testing.T) {
 	_, fs := buildWorkspace(t, func(wb *workspace.WorkspaceBuilder) {
 		wb.AddProject("auth", "auth", "github.com/example/auth")
@@ -0,0 +1,225 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jakoblorz/go-changesets/internal/changeset"
+	"github.com/jakoblorz/go-changesets/internal/conventional"
+	"github.com/jakoblorz/go-changesets/internal/filesystem"
+	"github.com/jakoblorz/go-changesets/internal/git"
+	"github.com/jakoblorz/go-changesets/internal/models"
+	"github.com/jakoblorz/go-changesets/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+// commitChangesetPrefix prefixes the IDs of changesets generated from commits.
+// The ID is derived from the commit hash so re-runs skip commits already
+// converted, including those whose changeset was consumed by 'changeset version'.
+const commitChangesetPrefix = "commit-"
+
+// FromCommitsCommand handles the from-commits command
+type FromCommitsCommand struct {
+	fs  filesystem.FileSystem
+	git git.GitClient
+}
+
+// NewFromCommitsCommand creates a new from-commits command
+func NewFromCommitsCommand(fs filesystem.FileSystem, gitClient git.GitClient) *cobra.Command {
+	cmd := &FromCommitsCommand{
+		fs:  fs,
+		git: gitClient,
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "from-commits",
+		Short: "Generate changesets from Conventional Commits",
+		Long: `Generates one changeset per Conventional Commit since a ref.
+
+The bump is derived from the commit: breaking changes ("!" or a
+BREAKING CHANGE footer) are major, feat is minor, fix and perf are patch.
+Other types are skipped.
+
+A commit's scopes select projects by name; when no scope names a project,
+the projects containing the touched files are used instead.
+
+Commits that already added a changeset, or whose generated changeset
+(commit-<hash>.md) still exists or is in the git history because a release
+consumed it, are skipped.`,
+		Example: `  # Preview changesets for commits since the last release
+  changeset from-commits --since auth@v1.2.0 --dry-run
+
+  # Write them
+  changeset from-commits --since auth@v1.2.0`,
+		RunE: cmd.Run,
+	}
+
+	cobraCmd.Flags().String("since", "", "Ref to start from, exclusive (required)")
+
+	return cobraCmd
+}
+
+// Run executes the from-commits command
+func (c *FromCommitsCommand) Run(cmd *cobra.Command, args []string) error {
+	since, _ := cmd.Flags().GetString("since")
+	dryRun := dryRunEnabled(cmd)
+
+	if since == "" {
+		return fmt.Errorf("--since is required")
+	}
+
+	ws := workspace.New(c.fs, workspaceOptionsFromCmd(cmd, c.git)...)
+	if err := ws.Detect(); err != nil {
+		return fmt.Errorf("failed to detect workspace: %w", err)
+	}
+
+	commits, err := c.git.GetCommitsSince(since)
+	if err != nil {
+		return fmt.Errorf("failed to read commits: %w", err)
+	}
+
+	csManager := changeset.NewManager(c.fs, ws.ChangesetDir())
+	out := cmd.OutOrStdout()
+
+	created, skipped := 0, 0
+	for _, commit := range commits {
+		short := shortHash(commit.Hash)
+
+		cs, reason := c.changesetFromCommit(ws, commit)
+		if cs == nil {
+			skipped++
+			_, _ = fmt.Fprintf(out, "- skipped %s: %s\n", short, reason)
+			continue
+		}
+
+		if err := csManager.Write(cs); err != nil {
+			return fmt.Errorf("failed to write changeset for %s: %w", short, err)
+		}
+		created++
+
+		verb := "Created"
+		if dryRun {
+			verb = "Would create"
+		}
+
+		_, _ = fmt.Fprintf(out, "✓ %s %s.md from %s\n", verb, cs.ID, short)
+		for _, name := range sortedProjectNames(cs.Projects) {
+			_, _ = fmt.Fprintf(out, "    %s: %s\n", name, cs.Projects[name])
+		}
+	}
+
+	_, _ = fmt.Fprintln(out)
+	if dryRun {
+		_, _ = fmt.Fprintf(out, "%d changeset(s) would be created, %d commit(s) skipped\n", created, skipped)
+	} else {
+		_, _ = fmt.Fprintf(out, "%d changeset(s) created, %d commit(s) skipped\n", created, skipped)
+	}
+
+	return nil
+}
+
+// changesetFromCommit builds the changeset for a commit, or returns the reason it is skipped.
+func (c *FromCommitsCommand) changesetFromCommit(ws *workspace.Workspace, commit git.Commit) (*models.Changeset, string) {
+	id := commitChangesetPrefix + shortHash(commit.Hash)
+	path := filepath.Join(ws.ChangesetDir(), id+".md")
+	if c.fs.Exists(path) {
+		return nil, "changeset already exists"
+	}
+	// 'changeset version' deletes the changesets it releases
+	if added, err := c.git.GetFileCreationCommit(path); err == nil && added != "" {
+		return nil, "changeset already released"
+	}
+
+	changesetDir := ws.ChangesetDir() + string(filepath.Separator)
+	for _, file := range commit.Files {
+		if strings.HasPrefix(file, changesetDir) && strings.HasSuffix(file, ".md") && !strings.EqualFold(filepath.Base(file), "README.md") {
+			return nil, "commit adds its own changeset"
+		}
+	}
+
+	parsed, err := conventional.Parse(commit.Message)
+	if err != nil {
+		return nil, "not a conventional commit"
+	}
+
+	bump, ok := parsed.Bump()
+	if !ok {
+		return nil, fmt.Sprintf("%s does not trigger a release", parsed.Type)
+	}
+
+	projects := projectsForCommit(ws, parsed, commit.Files)
+	if len(projects) == 0 {
+		return nil, "no matching project"
+	}
+
+	bumps := make(map[string]models.BumpType, len(projects))
+	for _, name := range projects {
+		bumps[name] = bump
+	}
+
+	return models.NewChangeset(id, bumps, commitChangesetMessage(parsed)), ""
+}
+
+// projectsForCommit resolves projects from the commit scopes, falling back to touched files.
+func projectsForCommit(ws *workspace.Workspace, commit *conventional.Commit, files []string) []string {
+	seen := make(map[string]struct{})
+	var projects []string
+	add := func(name string) {
+		if _, ok := seen[name]; ok {
+			return
+		}
+		seen[name] = struct{}{}
+		projects = append(projects, name)
+	}
+
+	for _, scope := range commit.Scopes {
+		if project, err := ws.GetProject(scope); err == nil {
+			add(project.Name)
+		}
+	}
+	if len(projects) > 0 {
+		return projects
+	}
+
+	changesetDir := ws.ChangesetDir() + string(filepath.Separator)
+	for _, file := range files {
+		if strings.HasPrefix(file, changesetDir) {
+			continue
+		}
+		if project := ws.ProjectForPath(file); project != nil {
+			add(project.Name)
+		}
+	}
+
+	sort.Strings(projects)
+	return projects
+}
+
+func commitChangesetMessage(commit *conventional.Commit) string {
+	parts := []string{commit.Description}
+	if commit.Body != "" {
+		parts = append(parts, commit.Body)
+	}
+	if commit.BreakingNote != "" {
+		parts = append(parts, "BREAKING CHANGE: "+commit.BreakingNote)
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+func sortedProjectNames(projects map[string]models.BumpType) []string {
+	names := make([]string, 0, len(projects))
+	for name := range projects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func shortHash(hash string) string {
+	if len(hash) > 7 {
+		return hash[:7]
+	}
+	return hash
+}
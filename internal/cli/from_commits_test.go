@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jakoblorz/go-changesets/internal/changeset"
+	"github.com/jakoblorz/go-changesets/internal/filesystem"
+	"github.com/jakoblorz/go-changesets/internal/git"
+	"github.com/jakoblorz/go-changesets/internal/models"
+	"github.com/jakoblorz/go-changesets/internal/workspace"
+	"github.com/stretchr/testify/require"
+)
+
+func setupFromCommits(t *testing.T) (*filesystem.MockFileSystem, *git.MockGitClient) {
+	t.Helper()
+
+	_, fs := buildWorkspace(t, func(wb *workspace.WorkspaceBuilder) {
+		wb.AddProject("auth", "services/auth", "github.com/example/auth")
+		wb.AddProject("shared", "packages/shared", "github.com/example/shared")
+	})
+
+	gitMock := git.NewMockGitClient()
+	gitMock.CreateTag("auth@v1.0.0", "Release 1.0.0")
+
+	return fs, gitMock
+}
+
+func runFromCommits(t *testing.T, fs *filesystem.MockFileSystem, gitMock *git.MockGitClient, args ...string) string {
+	t.Helper()
+
+	var out bytes.Buffer
+	cmd := NewFromCommitsCommand(fs, gitMock)
+	cmd.SetOut(&out)
+	cmd.SetArgs(args)
+	require.NoError(t, cmd.Execute())
+
+	return out.String()
+}
+
+func TestFromCommits_GeneratesChangesets(t *testing.T) {
+	fs, gitMock := setupFromCommits(t)
+
+	feat := gitMock.CreateCommit("feat(auth): add OAuth login")
+	fix := gitMock.CreateCommit("fix!: drop legacy tokens\n\nTokens issued before 2023 are rejected.")
+	gitMock.AddCommitFiles(fix, testWorkspaceRoot+"/packages/shared/token.go", testWorkspaceRoot+"/services/auth/token.go")
+	gitMock.CreateCommit("docs(auth): update README")
+	gitMock.CreateCommit("Update dependencies")
+
+	out := runFromCommits(t, fs, gitMock, "--since", "auth@v1.0.0")
+	require.Contains(t, out, "2 changeset(s) created, 2 commit(s) skipped")
+
+	csManager := changeset.NewManager(fs, testWorkspaceRoot+"/.changeset")
+
+	cs, err := csManager.Read(testWorkspaceRoot + "/.changeset/commit-" + feat + ".md")
+	require.NoError(t, err)
+	require.Equal(t, map[string]models.BumpType{"auth": models.BumpMinor}, cs.Projects)
+	require.Equal(t, "add OAuth login", cs.Message)
+
+	cs, err = csManager.Read(testWorkspaceRoot + "/.changeset/commit-" + fix + ".md")
+	require.NoError(t, err)
+	require.Equal(t, map[string]models.BumpType{"auth": models.BumpMajor, "shared": models.BumpMajor}, cs.Projects)
+	require.Equal(t, "drop legacy tokens\n\nTokens issued before 2023 are rejected.", cs.Message)
+
+	// A second run skips the commits that were already converted
+	out = runFromCommits(t, fs, gitMock, "--since", "auth@v1.0.0")
+	require.Contains(t, out, "0 changeset(s) created, 4 commit(s) skipped")
+}
+
+func TestFromCommits_SkipsCommitsWithChangesets(t *testing.T) {
+	fs, gitMock := setupFromCommits(t)
+
+	commit := gitMock.CreateCommit("feat(shared): add retry helper")
+	gitMock.AddCommitFiles(commit, testWorkspaceRoot+"/.changeset/brave-lions-dance.md")
+
+	out := runFromCommits(t, fs, gitMock, "--since", "auth@v1.0.0")
+	require.Contains(t, out, "commit adds its own changeset")
+	require.False(t, fs.Exists(testWorkspaceRoot+"/.changeset/commit-"+commit+".md"))
+}
+
+func TestFromCommits_DryRun(t *testing.T) {
+	fs, gitMock := setupFromCommits(t)
+
+	commit := gitMock.CreateCommit("feat(shared): add retry helper")
+
+	var out bytes.Buffer
+	cmd := NewRootCommand(fs, gitMock, nil)
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--dry-run", "from-commits", "--since", "auth@v1.0.0"})
+	require.NoError(t, cmd.Execute())
+
+	require.Contains(t, out.String(), "Would create commit-"+commit+".md")
+	require.Contains(t, out.String(), "shared: minor")
+	require.Contains(t, out.String(), "--- /dev/null\n+++ b/.changeset/commit-"+commit+".md\n")
+	require.False(t, fs.Exists(testWorkspaceRoot+"/.changeset/commit-"+commit+".md"))
+}
+
+func TestFromCommits_SkipsReleasedChangesets(t *testing.T) {
+	fs, gitMock := setupFromCommits(t)
+
+	commit := gitMock.CreateCommit("feat(auth): add OAuth login")
+	runFromCommits(t, fs, gitMock, "--since", "auth@v1.0.0")
+
+	path := testWorkspaceRoot + "/.changeset/commit-" + commit + ".md"
+	gitMock.SetFileCreationCommit(path, gitMock.CreateCommit("chore: add changesets"))
+	runVersion(t, fs, "auth")
+	require.False(t, fs.Exists(path))
+
+	// The released changeset is not created again for the same range
+	out := runFromCommits(t, fs, gitMock, "--since", "auth@v1.0.0")
+	require.Contains(t, out, "changeset already released")
+	require.False(t, fs.Exists(path))
+}
@@ -53,7 +53,7 @@ func (c *GHCloseCommand) Run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("--repo is required")
 	}
 
-	resolved, err := resolveProject(c.fs, projectFlag, workspaceOptionsFromCmd(cmd)...)
+	resolved, err := resolveProject(c.fs, projectFlag, workspaceOptionsFromCmd(cmd, c.git)...)
 	if err != nil {
 		if projectFlag == "" {
 			return fmt.Errorf("--project flag required (or run via 'changeset each'): %w", err)
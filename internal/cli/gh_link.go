@@ -75,7 +75,7 @@ func (c *GHLinkCommand) Run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to parse tree JSON: %w", err)
 	}
 
-	resolved, err := resolveProject(c.fs, projectFlag, workspaceOptionsFromCmd(cmd)...)
+	resolved, err := resolveProject(c.fs, projectFlag, workspaceOptionsFromCmd(cmd, c.git)...)
 	if err != nil {
 		if projectFlag == "" {
 			return fmt.Errorf("--project flag required (or run via 'changeset each'): %w", err)
@@ -93,7 +93,7 @@ func (c *GHLinkCommand) Run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get current git branch: %w", err)
 	}
 
-	mapping, err := github.ReadPRMapping(mappingFile)
+	mapping, err := github.ReadPRMapping(c.fs, mappingFile)
 	if err != nil {
 		return fmt.Errorf("failed to read mapping file: %w", err)
 	}
@@ -129,7 +129,7 @@ func (c *GHLinkCommand) Run(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
-	body, err := github.NewPRRenderer(c.fs).RenderBody(github.TemplateData{
+	body, err := newPRRenderer(c.fs, resolved.Workspace).RenderBody(github.TemplateData{
 		Project:          ctx.Project,
 		Version:          ctx.CurrentVersion,
 		ChangelogPreview: ctx.ChangelogPreview,
@@ -139,7 +139,7 @@ func (c *GHLinkCommand) Run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to build PR body: %w", err)
 	}
 
-	title, err := github.NewPRRenderer(c.fs).RenderTitle(github.TemplateData{
+	title, err := newPRRenderer(c.fs, resolved.Workspace).RenderTitle(github.TemplateData{
 		Project:          ctx.Project,
 		Version:          ctx.CurrentVersion,
 		ChangelogPreview: ctx.ChangelogPreview,
@@ -59,7 +59,7 @@ func (c *GHOpenCommand) Run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("--repo is required")
 	}
 
-	resolved, err := resolveProject(c.fs, projectFlag, workspaceOptionsFromCmd(cmd)...)
+	resolved, err := resolveProject(c.fs, projectFlag, workspaceOptionsFromCmd(cmd, c.git)...)
 	if err != nil {
 		if projectFlag == "" {
 			return fmt.Errorf("--project flag required (or run via 'changeset each'): %w", err)
@@ -77,7 +77,7 @@ func (c *GHOpenCommand) Run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to get current git branch: %w", err)
 	}
 
-	body, err := github.NewPRRenderer(c.fs).RenderBody(github.TemplateData{
+	body, err := newPRRenderer(c.fs, resolved.Workspace).RenderBody(github.TemplateData{
 		Project:          ctx.Project,
 		Version:          ctx.CurrentVersion,
 		ChangelogPreview: ctx.ChangelogPreview,
@@ -86,7 +86,7 @@ func (c *GHOpenCommand) Run(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("failed to build PR body: %w", err)
 	}
 
-	title, err := github.NewPRRenderer(c.fs).RenderTitle(github.TemplateData{
+	title, err := newPRRenderer(c.fs, resolved.Workspace).RenderTitle(github.TemplateData{
 		Project:          ctx.Project,
 		Version:          ctx.CurrentVersion,
 		ChangelogPreview: ctx.ChangelogPreview,
@@ -134,7 +134,7 @@ func (c *GHOpenCommand) Run(cmd *cobra.Command, args []string) error {
 }
 
 func (c *GHOpenCommand) updateMappingFile(path, project, version string, pr *github.PullRequest) error {
-	mapping, err := github.ReadPRMapping(path)
+	mapping, err := github.ReadPRMapping(c.fs, path)
 	if err != nil {
 		mapping = github.NewPRMapping()
 	}
@@ -145,5 +145,5 @@ func (c *GHOpenCommand) updateMappingFile(path, project, version string, pr *git
 		Project:     project,
 	})
 
-	return mapping.Write(path)
+	return mapping.Write(c.fs, path)
 }
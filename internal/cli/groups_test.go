@@ -0,0 +1,112 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/jakoblorz/go-changesets/internal/filesystem"
+	"github.com/jakoblorz/go-changesets/internal/git"
+	"github.com/jakoblorz/go-changesets/internal/models"
+	"github.com/jakoblorz/go-changesets/internal/workspace"
+	"github.com/stretchr/testify/require"
+)
+
+func buildGroupsWorkspace(t *testing.T) *filesystem.MockFileSystem {
+	t.Helper()
+
+	_, fs := buildWorkspace(t, func(wb *workspace.WorkspaceBuilder) {
+		wb.AddProject("api-client", "api/client", "github.com/example/api-client")
+		wb.AddProject("api-server", "api/server", "github.com/example/api-server")
+		wb.AddProject("api-types", "api/types", "github.com/example/api-types")
+		wb.AddProject("web", "web", "github.com/example/web")
+		wb.AddProject("cli", "cli", "github.com/example/cli")
+		wb.AddProject("docs", "docs", "github.com/example/docs")
+		wb.SetVersion("api-client", "1.1.0")
+		wb.SetVersion("api-server", "1.0.0")
+		wb.SetVersion("api-types", "1.2.0")
+		wb.SetVersion("web", "1.0.0")
+		wb.SetVersion("cli", "2.0.0")
+		wb.SetVersion("docs", "3.0.0")
+		wb.AddChangeset("client-feature", "api-client", "minor", "Add streaming client")
+		wb.AddChangeset("web-fix", "web", "patch", "Fix layout")
+		wb.AddChangeset("cli-fix", "cli", "patch", "Fix flag parsing")
+	})
+	addConfig(fs, `{"fixed": [["api-*"]], "linked": [["web", "cli", "docs"]]}`)
+	return fs
+}
+
+func TestVersion_FixedGroup(t *testing.T) {
+	fs := buildGroupsWorkspace(t)
+	runVersion(t, fs, "api-client")
+
+	requireVersion(t, fs, "api/client", "1.3.0")
+	requireVersion(t, fs, "api/server", "1.3.0")
+	requireVersion(t, fs, "api/types", "1.3.0")
+	requireVersion(t, fs, "web", "1.0.0")
+
+	require.Contains(t, changelogEntry(t, fs, "api/server", "1.3.0"), "Add streaming client")
+	require.Equal(t, changelogEntry(t, fs, "api/client", "1.3.0"), changelogEntry(t, fs, "api/types", "1.3.0"))
+}
+
+func TestVersion_LinkedGroup(t *testing.T) {
+	fs := buildGroupsWorkspace(t)
+	runVersion(t, fs, "web")
+
+	// Members with changesets share the highest version, docs is unchanged
+	requireVersion(t, fs, "web", "2.0.1")
+	requireVersion(t, fs, "cli", "2.0.1")
+	requireVersion(t, fs, "docs", "3.0.0")
+
+	require.Contains(t, changelogEntry(t, fs, "cli", "2.0.1"), "Fix flag parsing")
+	require.NotContains(t, changelogEntry(t, fs, "cli", "2.0.1"), "Fix layout")
+}
+
+func TestStatus_Groups(t *testing.T) {
+	fs := buildGroupsWorkspace(t)
+
+	var out bytes.Buffer
+	cmd := NewRootCommand(fs, git.NewMockGitClient(), nil)
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"status", "--format", "json"})
+	require.NoError(t, cmd.Execute())
+
+	var status StatusOutput
+	require.NoError(t, json.Unmarshal(out.Bytes(), &status))
+	require.Len(t, status.Releases, 5)
+
+	fixed := &models.VersionGroup{Kind: models.GroupKindFixed, Projects: []string{"api-client", "api-server", "api-types"}}
+	for _, release := range status.Releases[:3] {
+		require.Equal(t, "1.3.0", release.NextVersion)
+		require.Equal(t, fixed, release.Group)
+	}
+	require.Equal(t, "cli", status.Releases[4].Project)
+	require.Equal(t, "2.0.1", status.Releases[4].NextVersion)
+	require.Equal(t, models.GroupKindLinked, status.Releases[4].Group.Kind)
+
+	out.Reset()
+	cmd = NewRootCommand(fs, git.NewMockGitClient(), nil)
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"status"})
+	require.NoError(t, cmd.Execute())
+	require.Contains(t, out.String(), "  fixed group: api-client, api-server, api-types\n")
+}
+
+func TestFilterContexts_Grouped(t *testing.T) {
+	fs := buildGroupsWorkspace(t)
+	ws := workspace.New(fs)
+	require.NoError(t, ws.Detect())
+
+	contexts, err := newProjectContextBuilder(fs, git.NewMockGitClient()).BuildFromWorkspace(ws)
+	require.NoError(t, err)
+
+	filtered, err := filterContexts(contexts, models.AllFilters(models.FilterGrouped, models.FilterUnchanged))
+	require.NoError(t, err)
+
+	names := make([]string, 0, len(filtered))
+	for _, ctx := range filtered {
+		names = append(names, ctx.Project)
+	}
+	require.Equal(t, []string{"api-server", "api-types", "docs"}, names)
+	require.Equal(t, models.GroupKindLinked, filtered[2].Group.Kind)
+}
@@ -0,0 +1,27 @@
+package cli
+
+import (
+	"github.com/jakoblorz/go-changesets/internal/filesystem"
+	"github.com/jakoblorz/go-changesets/internal/git"
+	"github.com/spf13/cobra"
+)
+
+// NewPreCommand creates the pre command with its enter and exit subcommands
+func NewPreCommand(fs filesystem.FileSystem, gitClient git.GitClient) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "pre",
+		Short: "Enter or exit prerelease mode",
+		Long: `Manage prerelease mode.
+
+While in pre mode, 'changeset version' writes prerelease versions such as
+1.3.0-beta.0, 1.3.0-beta.1, ... and keeps the changesets, recording which
+ones were released in .changeset/pre.json. After 'changeset pre exit' the
+next 'changeset version' cuts the final version with a single changelog
+entry containing all changesets of the prerelease cycle.`,
+	}
+
+	cmd.AddCommand(NewPreEnterCommand(fs, gitClient))
+	cmd.AddCommand(NewPreExitCommand(fs))
+
+	return cmd
+}
@@ -0,0 +1,88 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/jakoblorz/go-changesets/internal/changeset"
+	"github.com/jakoblorz/go-changesets/internal/filesystem"
+	"github.com/jakoblorz/go-changesets/internal/git"
+	"github.com/jakoblorz/go-changesets/internal/models"
+	"github.com/jakoblorz/go-changesets/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+// PreEnterCommand handles the pre enter command
+type PreEnterCommand struct {
+	fs  filesystem.FileSystem
+	git git.GitClient
+}
+
+// NewPreEnterCommand creates a new pre enter command
+func NewPreEnterCommand(fs filesystem.FileSystem, gitClient git.GitClient) *cobra.Command {
+	cmd := &PreEnterCommand{fs: fs, git: gitClient}
+
+	return &cobra.Command{
+		Use:   "enter <tag>",
+		Short: "Enter prerelease mode with the given tag",
+		Long: `Enters prerelease mode by writing .changeset/pre.json with the tag and
+the current version of every project. Commit the file so CI picks it up.`,
+		Example: `  changeset pre enter beta
+  changeset version --project auth   # 1.2.0 -> 1.3.0-beta.0`,
+		Args: cobra.ExactArgs(1),
+		RunE: cmd.Run,
+	}
+}
+
+// Run executes the pre enter command
+func (c *PreEnterCommand) Run(cmd *cobra.Command, args []string) error {
+	tag := args[0]
+
+	ws := workspace.New(c.fs, workspaceOptionsFromCmd(cmd, c.git)...)
+	if err := ws.Detect(); err != nil {
+		return fmt.Errorf("failed to detect workspace: %w", err)
+	}
+
+	csManager := changeset.NewManager(c.fs, ws.ChangesetDir())
+	state, err := csManager.ReadPreState()
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case state != nil && state.IsActive():
+		return fmt.Errorf("already in pre mode with tag %q (run 'changeset pre exit' first)", state.Tag)
+	case state != nil:
+		// Re-entering before the final versions were cut continues the cycle
+		if err := models.ValidatePreTag(tag); err != nil {
+			return err
+		}
+		state.Mode = models.PreModeActive
+		state.Tag = tag
+	default:
+		initialVersions := make(map[string]string, len(ws.Projects))
+		for _, project := range ws.Projects {
+			versionStore, err := projectVersionStore(c.fs, c.git, ws, project)
+			if err != nil {
+				return err
+			}
+			version, err := versionStore.Read(project.RootPath)
+			if err != nil {
+				return fmt.Errorf("failed to read current version of %s: %w", project.Name, err)
+			}
+			initialVersions[project.Name] = version.String()
+		}
+
+		state, err = models.NewPreState(tag, initialVersions)
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := csManager.WritePreState(state); err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintf(cmd.OutOrStdout(), "✓ Entered pre mode with tag %q\n", tag)
+	_, _ = fmt.Fprintln(cmd.OutOrStdout(), "  'changeset version' now creates prerelease versions. Commit .changeset/pre.json.")
+	return nil
+}
@@ -0,0 +1,71 @@
+package cli
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jakoblorz/go-changesets/internal/changeset"
+	"github.com/jakoblorz/go-changesets/internal/filesystem"
+	"github.com/jakoblorz/go-changesets/internal/models"
+	"github.com/jakoblorz/go-changesets/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+// PreExitCommand handles the pre exit command
+type PreExitCommand struct {
+	fs filesystem.FileSystem
+}
+
+// NewPreExitCommand creates a new pre exit command
+func NewPreExitCommand(fs filesystem.FileSystem) *cobra.Command {
+	cmd := &PreExitCommand{fs: fs}
+
+	return &cobra.Command{
+		Use:   "exit",
+		Short: "Exit prerelease mode",
+		Long: `Exits prerelease mode. The next 'changeset version' of each prereleased
+project cuts the final version (e.g. 1.3.0-beta.2 -> 1.3.0) with one
+changelog entry for all changesets of the cycle, then deletes them.
+.changeset/pre.json is removed once every prereleased project is final.`,
+		Example: `  changeset pre exit
+  changeset each --filter open-changesets -- changeset version`,
+		Args: cobra.NoArgs,
+		RunE: cmd.Run,
+	}
+}
+
+// Run executes the pre exit command
+func (c *PreExitCommand) Run(cmd *cobra.Command, args []string) error {
+	ws := workspace.New(c.fs, workspaceOptionsFromCmd(cmd, nil)...)
+	if err := ws.Detect(); err != nil {
+		return fmt.Errorf("failed to detect workspace: %w", err)
+	}
+
+	csManager := changeset.NewManager(c.fs, ws.ChangesetDir())
+	state, err := csManager.ReadPreState()
+	if err != nil {
+		return err
+	}
+	if state == nil || !state.IsActive() {
+		return fmt.Errorf("not in pre mode (run 'changeset pre enter <tag>' first)")
+	}
+
+	out := cmd.OutOrStdout()
+	pending := state.PendingProjects()
+	if len(pending) == 0 {
+		if err := csManager.DeletePreState(); err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(out, "✓ Exited pre mode (no prereleases were versioned)")
+		return nil
+	}
+
+	state.Mode = models.PreModeExit
+	if err := csManager.WritePreState(state); err != nil {
+		return err
+	}
+
+	_, _ = fmt.Fprintln(out, "✓ Exited pre mode")
+	_, _ = fmt.Fprintf(out, "  Run 'changeset version' to cut final versions for: %s\n", strings.Join(pending, ", "))
+	return nil
+}
@@ -0,0 +1,135 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jakoblorz/go-changesets/internal/changelog"
+	"github.com/jakoblorz/go-changesets/internal/changeset"
+	"github.com/jakoblorz/go-changesets/internal/filesystem"
+	"github.com/jakoblorz/go-changesets/internal/git"
+	"github.com/jakoblorz/go-changesets/internal/models"
+	"github.com/jakoblorz/go-changesets/internal/workspace"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreMode_Cycle(t *testing.T) {
+	_, fs := buildWorkspace(t, func(wb *workspace.WorkspaceBuilder) {
+		wb.AddProject("auth", "services/auth", "github.com/example/auth")
+		wb.SetVersion("auth", "1.2.0")
+		wb.AddChangeset("add-oauth", "auth", "minor", "Add OAuth login")
+	})
+	csManager := changeset.NewManager(fs, testWorkspaceRoot+"/.changeset")
+
+	runPre(t, fs, "enter", "beta")
+
+	state, err := csManager.ReadPreState()
+	require.NoError(t, err)
+	require.Equal(t, &models.PreState{
+		Mode:            models.PreModeActive,
+		Tag:             "beta",
+		InitialVersions: map[string]string{"auth": "1.2.0"},
+		Changesets:      map[string][]string{},
+	}, state)
+
+	runVersion(t, fs, "auth")
+	requireVersion(t, fs, "services/auth", "1.3.0-beta.0")
+
+	// Consumed changesets are kept but not released again
+	require.True(t, fs.Exists(testWorkspaceRoot+"/.changeset/add-oauth.md"))
+	runVersion(t, fs, "auth")
+	requireVersion(t, fs, "services/auth", "1.3.0-beta.0")
+
+	require.NoError(t, csManager.Write(models.NewChangeset("fix-refresh", map[string]models.BumpType{"auth": models.BumpPatch}, "Fix token refresh")))
+	runVersion(t, fs, "auth")
+	requireVersion(t, fs, "services/auth", "1.3.0-beta.1")
+
+	entry := changelogEntry(t, fs, "services/auth", "1.3.0-beta.1")
+	require.Contains(t, entry, "Fix token refresh")
+	require.NotContains(t, entry, "Add OAuth login")
+
+	runPre(t, fs, "exit")
+	runVersion(t, fs, "auth")
+	requireVersion(t, fs, "services/auth", "1.3.0")
+
+	entry = changelogEntry(t, fs, "services/auth", "1.3.0")
+	require.Contains(t, entry, "Fix token refresh")
+	require.Contains(t, entry, "Add OAuth login")
+
+	remaining, err := csManager.ReadAll()
+	require.NoError(t, err)
+	require.Empty(t, remaining)
+	require.False(t, fs.Exists(csManager.PreStatePath()))
+}
+
+func TestPreMode_MajorChangesetRetargetsPrerelease(t *testing.T) {
+	_, fs := buildWorkspace(t, func(wb *workspace.WorkspaceBuilder) {
+		wb.AddProject("auth", "services/auth", "github.com/example/auth")
+		wb.SetVersion("auth", "1.2.0")
+		wb.AddChangeset("add-oauth", "auth", "minor", "Add OAuth login")
+	})
+	csManager := changeset.NewManager(fs, testWorkspaceRoot+"/.changeset")
+
+	runPre(t, fs, "enter", "rc")
+	runVersion(t, fs, "auth")
+	requireVersion(t, fs, "services/auth", "1.3.0-rc.0")
+
+	require.NoError(t, csManager.Write(models.NewChangeset("drop-v1", map[string]models.BumpType{"auth": models.BumpMajor}, "Drop v1 API")))
+	runVersion(t, fs, "auth")
+	requireVersion(t, fs, "services/auth", "2.0.0-rc.0")
+}
+
+func TestPreMode_Errors(t *testing.T) {
+	_, fs := buildWorkspace(t, func(wb *workspace.WorkspaceBuilder) {
+		wb.AddProject("auth", "services/auth", "github.com/example/auth")
+	})
+
+	err := executePre(fs, "exit")
+	require.ErrorContains(t, err, "not in pre mode")
+
+	err = executePre(fs, "enter", "beta.1")
+	require.ErrorContains(t, err, "must be a single identifier")
+
+	require.NoError(t, executePre(fs, "enter", "beta"))
+	err = executePre(fs, "enter", "alpha")
+	require.ErrorContains(t, err, `already in pre mode with tag "beta"`)
+
+	// Exiting without any prerelease removes the state right away
+	require.NoError(t, executePre(fs, "exit"))
+	require.False(t, fs.Exists(testWorkspaceRoot+"/.changeset/pre.json"))
+}
+
+func executePre(fs filesystem.FileSystem, args ...string) error {
+	cmd := NewPreCommand(fs, git.NewMockGitClient())
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs(args)
+	return cmd.Execute()
+}
+
+func runPre(t *testing.T, fs filesystem.FileSystem, args ...string) {
+	t.Helper()
+	require.NoError(t, executePre(fs, args...))
+}
+
+func runVersion(t *testing.T, fs filesystem.FileSystem, project string) {
+	t.Helper()
+	cmd := NewVersionCommand(fs, git.NewMockGitClient(), nil)
+	cmd.SetArgs([]string{"--project", project})
+	require.NoError(t, cmd.Execute())
+}
+
+func requireVersion(t *testing.T, fs *filesystem.MockFileSystem, projectPath, expected string) {
+	t.Helper()
+	data, err := fs.ReadFile(testWorkspaceRoot + "/" + projectPath + "/version.txt")
+	require.NoError(t, err)
+	require.Equal(t, expected, string(bytes.TrimSpace(data)))
+}
+
+func changelogEntry(t *testing.T, fs *filesystem.MockFileSystem, projectPath, version string) string {
+	t.Helper()
+	ver, err := models.ParseVersion(version)
+	require.NoError(t, err)
+	entry, err := changelog.NewChangelog(fs).GetEntryForVersion(testWorkspaceRoot+"/"+projectPath, "", ver)
+	require.NoError(t, err)
+	return entry
+}
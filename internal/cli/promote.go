@@ -0,0 +1,216 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jakoblorz/go-changesets/internal/filesystem"
+	"github.com/jakoblorz/go-changesets/internal/git"
+	"github.com/jakoblorz/go-changesets/internal/github"
+	"github.com/jakoblorz/go-changesets/internal/models"
+	"github.com/jakoblorz/go-changesets/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+// PromoteCommand handles the promote command
+type PromoteCommand struct {
+	fs       filesystem.FileSystem
+	git      git.GitClient
+	ghClient github.GitHubClient
+}
+
+// NewPromoteCommand creates a new promote command
+func NewPromoteCommand(fs filesystem.FileSystem, gitClient git.GitClient, ghClient github.GitHubClient) *cobra.Command {
+	cmd := &PromoteCommand{
+		fs:       fs,
+		git:      gitClient,
+		ghClient: ghClient,
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "promote",
+		Short: "Promotes a release candidate to the final release on the same commit",
+		Long: `Creates & pushes the final release tag on the commit of a release candidate (RC) tag, e.g. auth@v1.4.0 for auth@v1.4.0-rc3; Optionally turns the RC's GitHub pre-release into the final release, or creates one.
+
+--from is the RC's prerelease (rc3), version (1.4.0-rc3) or tag (auth@v1.4.0-rc3). A bare prerelease resolves to the highest version with that prerelease.
+The release notes are carried over from the RC's GitHub pre-release or tag annotation; use --regenerate-notes to take them from the project's CHANGELOG.md instead.
+Promotion is refused if the final tag already exists. Does not modify changesets or version files.`,
+		RunE: cmd.Run,
+	}
+
+	cobraCmd.Flags().StringP("project", "p", "", "Project name to promote (required unless run via 'changeset each')")
+	cobraCmd.Flags().String("from", "", "Release candidate to promote, e.g. rc3, 1.4.0-rc3 or auth@v1.4.0-rc3 (required)")
+	cobraCmd.Flags().Bool("regenerate-notes", false, "Take the release notes from CHANGELOG.md instead of the release candidate")
+	cobraCmd.Flags().StringP("owner", "o", "", "GitHub repository owner (optional, enables updating or creating a release)")
+	cobraCmd.Flags().StringP("repo", "r", "", "GitHub repository name (optional, enables updating or creating a release)")
+	_ = cobraCmd.MarkFlagRequired("from")
+
+	return cobraCmd
+}
+
+// Run executes the promote command
+func (c *PromoteCommand) Run(cmd *cobra.Command, args []string) error {
+	projectFlag, _ := cmd.Flags().GetString("project")
+	from, _ := cmd.Flags().GetString("from")
+	regenerateNotes, _ := cmd.Flags().GetBool("regenerate-notes")
+	owner, _ := cmd.Flags().GetString("owner")
+	repo, _ := cmd.Flags().GetString("repo")
+
+	if c.ghClient == nil && (owner != "" || repo != "") {
+		return fmt.Errorf("--owner and --repo flags require a GitHub client: authenticated GitHub client required to promote a release: %w", github.ErrGitHubTokenNotFound)
+	}
+	if c.ghClient != nil {
+		if owner == "" {
+			return fmt.Errorf("--owner flag required")
+		}
+		if repo == "" {
+			return fmt.Errorf("--repo flag required")
+		}
+	}
+
+	resolved, err := resolveProject(c.fs, projectFlag, workspaceOptionsFromCmd(cmd, c.git)...)
+	if err != nil {
+		if projectFlag == "" {
+			return fmt.Errorf("--project flag required (or run via 'changeset each'): %w", err)
+		}
+		return err
+	}
+
+	if resolved.ViaEach {
+		fmt.Printf("🚢 Promoting %s (via changeset each)\n\n", resolved.Name)
+	} else {
+		fmt.Printf("🚢 Promoting project: %s\n\n", resolved.Name)
+	}
+
+	rcTag, rcVersion, err := c.resolveCandidate(resolved.Project, from)
+	if err != nil {
+		return err
+	}
+
+	finalVersion := rcVersion.StripPrerelease()
+	finalTag := tagName(resolved.Project, finalVersion)
+	fmt.Printf("Release candidate: %s\n", rcTag)
+	fmt.Printf("Final release: %s\n\n", finalTag)
+
+	exists, err := c.git.TagExists(finalTag)
+	if err != nil {
+		return fmt.Errorf("failed to check tag %s: %w", finalTag, err)
+	}
+	if exists {
+		return fmt.Errorf("cannot promote %s: tag %s already exists", rcTag, finalTag)
+	}
+
+	ctx := context.Background()
+	var rcRelease *github.Release
+	if c.ghClient != nil {
+		if release, err := c.ghClient.GetReleaseByTag(ctx, owner, repo, finalTag); err == nil && release != nil {
+			return fmt.Errorf("cannot promote %s: release %s already exists", rcTag, finalTag)
+		}
+		if release, err := c.ghClient.GetReleaseByTag(ctx, owner, repo, rcTag); err == nil {
+			rcRelease = release
+		}
+	}
+
+	notes, err := c.releaseNotes(resolved.Workspace, resolved.Project, rcTag, rcRelease, finalVersion, regenerateNotes)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Creating git tag %s on the commit of %s\n", finalTag, rcTag)
+	if err := c.git.CreateTagAt(finalTag, rcTag, notes); err != nil {
+		return fmt.Errorf("failed to create tag: %w", err)
+	}
+
+	fmt.Printf("Pushing tag to remote...\n")
+	if err := c.git.PushTag(finalTag); err != nil {
+		fmt.Printf("⚠️  Warning: failed to push tag: %v\n", err)
+	}
+
+	if c.ghClient != nil {
+		if rcRelease != nil {
+			fmt.Printf("Promoting GitHub pre-release %s to release...\n", rcTag)
+			_, err = c.ghClient.UpdateRelease(ctx, owner, repo, rcRelease.ID, &github.UpdateReleaseRequest{
+				TagName:    finalTag,
+				Name:       finalTag,
+				Body:       notes,
+				Prerelease: false,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to update release: %w", err)
+			}
+		} else {
+			fmt.Println("Creating GitHub release...")
+			_, err = c.ghClient.CreateRelease(ctx, owner, repo, &github.CreateReleaseRequest{
+				TagName: finalTag,
+				Name:    finalTag,
+				Body:    notes,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to create release: %w", err)
+			}
+		}
+
+		fmt.Printf("Release URL: https://github.com/%s/%s/releases/tag/%s\n", owner, repo, finalTag)
+	}
+
+	fmt.Printf("\n🎉 Successfully promoted %s to %s@%s\n", rcTag, resolved.Name, finalVersion.String())
+
+	return nil
+}
+
+// resolveCandidate finds the release candidate tag named by from: a tag, a
+// version or a bare prerelease such as "rc3".
+func (c *PromoteCommand) resolveCandidate(project *models.Project, from string) (string, *models.Version, error) {
+	tags, err := c.git.GetTagsWithPrefix(tagPrefixPattern(project))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get tags: %w", err)
+	}
+
+	wantVersion := strings.TrimPrefix(strings.TrimPrefix(from, project.TagPrefix()), "v")
+
+	var rcTag string
+	var rcVersion *models.Version
+	for _, tag := range tags {
+		version, err := project.TagVersion(tag)
+		if err != nil || !version.IsPrerelease() {
+			continue
+		}
+		if version.String() != wantVersion && version.Prerelease != from {
+			continue
+		}
+		if rcVersion == nil || version.Compare(rcVersion) > 0 {
+			rcTag, rcVersion = tag, version
+		}
+	}
+
+	if rcVersion == nil {
+		return "", nil, fmt.Errorf("no release candidate tag of %s matches %q", project.Name, from)
+	}
+	return rcTag, rcVersion, nil
+}
+
+// releaseNotes returns the notes of the release candidate, from its GitHub
+// pre-release or tag annotation, or the CHANGELOG.md entry of the final version.
+func (c *PromoteCommand) releaseNotes(ws *workspace.Workspace, project *models.Project, rcTag string, rcRelease *github.Release, finalVersion *models.Version, regenerate bool) (string, error) {
+	if !regenerate {
+		if rcRelease != nil && strings.TrimSpace(rcRelease.Body) != "" {
+			return rcRelease.Body, nil
+		}
+		annotation, err := c.git.GetTagAnnotation(rcTag)
+		if err != nil {
+			return "", fmt.Errorf("failed to read annotation of %s: %w", rcTag, err)
+		}
+		if strings.TrimSpace(annotation) != "" {
+			return annotation, nil
+		}
+		fmt.Printf("⚠️  %s has no release notes; using CHANGELOG.md\n", rcTag)
+	}
+
+	notes, err := newChangelog(c.fs, ws).GetReleaseNotes(project.RootPath, project.Name, finalVersion)
+	if err != nil {
+		fmt.Printf("⚠️  Warning: could not read changelog entry: %v\n", err)
+		return fmt.Sprintf("Release %s", finalVersion.String()), nil
+	}
+	return notes, nil
+}
@@ -3,14 +3,15 @@ package cli
 import (
 	"context"
 	"fmt"
-	"strings"
+	"path/filepath"
 
-	"github.com/jakoblorz/go-changesets/internal/changelog"
+	"github.com/jakoblorz/go-changesets/internal/changeset"
 	"github.com/jakoblorz/go-changesets/internal/filesystem"
 	"github.com/jakoblorz/go-changesets/internal/git"
 	"github.com/jakoblorz/go-changesets/internal/github"
 	"github.com/jakoblorz/go-changesets/internal/models"
 	"github.com/jakoblorz/go-changesets/internal/versioning"
+	"github.com/jakoblorz/go-changesets/internal/workspace"
 	"github.com/spf13/cobra"
 )
 
@@ -49,7 +50,7 @@ func (c *PublishCommand) Run(cmd *cobra.Command, args []string) error {
 	owner, _ := cmd.Flags().GetString("owner")
 	repo, _ := cmd.Flags().GetString("repo")
 
-	resolved, err := resolveProject(c.fs, projectFlag, workspaceOptionsFromCmd(cmd)...)
+	resolved, err := resolveProject(c.fs, projectFlag, workspaceOptionsFromCmd(cmd, c.git)...)
 	if err != nil {
 		if projectFlag == "" {
 			return fmt.Errorf("--project flag required (or run via 'changeset each'): %w", err)
@@ -63,19 +64,18 @@ func (c *PublishCommand) Run(cmd *cobra.Command, args []string) error {
 		fmt.Printf("📦 Publishing project: %s\n\n", resolved.Name)
 	}
 
-	versionStore := versioning.NewVersionStore(c.fs, resolved.Project.Type)
+	versionStore, err := projectVersionStore(c.fs, c.git, resolved.Workspace, resolved.Project)
+	if err != nil {
+		return err
+	}
 	fileVersion, err := versionStore.Read(resolved.Project.RootPath)
 	if err != nil {
 		return fmt.Errorf("failed to read version: %w", err)
 	}
 
-	if resolved.Project.Type == models.ProjectTypeNode {
-		fmt.Printf("Version from package.json: %s\n", fileVersion.String())
-	} else {
-		fmt.Printf("Version from version.txt: %s\n", fileVersion.String())
-	}
+	fmt.Printf("Version from %s: %s\n", versionSource(versionStore, resolved.Project.RootPath), fileVersion.String())
 
-	tagVersion, err := getLatestNonRCVersion(c.git, resolved.Name, resolved.Project.Type)
+	tagVersion, err := getLatestNonRCVersion(c.git, resolved.Project)
 	if err != nil {
 		tagVersion = &models.Version{Major: 0, Minor: 0, Patch: 0}
 		fmt.Printf("No existing git tag found (first release)\n")
@@ -88,12 +88,25 @@ func (c *PublishCommand) Run(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 
+	// Outside pre mode, a prerelease in the version files is a snapshot
+	// written with 'snapshot --write-version' and already tagged by it
+	if fileVersion.IsPrerelease() {
+		pre, err := changeset.NewManager(c.fs, resolved.Workspace.ChangesetDir()).ReadPreState()
+		if err != nil {
+			return fmt.Errorf("failed to read pre mode state: %w", err)
+		}
+		if pre == nil {
+			fmt.Printf("\n⚠️  Version %s is a snapshot, run 'changeset version' to release it (skipping)\n", fileVersion.String())
+			return nil
+		}
+	}
+
 	fmt.Printf("\n🚀 Publishing new version: %s -> %s\n\n", tagVersion.String(), fileVersion.String())
 
-	tag := tagName(resolved.Name, resolved.Project.Type, fileVersion)
+	tag := tagName(resolved.Project, fileVersion)
 	fmt.Printf("Creating git tag: %s\n", tag)
 
-	changelogMsg, _ := c.getChangelogForVersion(resolved.Project.RootPath, fileVersion)
+	changelogMsg, _ := c.getChangelogForVersion(resolved.Workspace, resolved.Project, fileVersion)
 	if err := c.git.CreateTag(tag, changelogMsg); err != nil {
 		exists, _ := c.git.TagExists(tag)
 		if !exists {
@@ -128,20 +141,18 @@ func (c *PublishCommand) Run(cmd *cobra.Command, args []string) error {
 			return nil
 		}
 
-		changelog := changelog.NewChangelog(c.fs)
-		changelogEntry, err := changelog.GetEntryForVersion(resolved.Project.RootPath, fileVersion)
+		releaseNotes, err := newChangelog(c.fs, resolved.Workspace).GetReleaseNotes(resolved.Project.RootPath, resolved.Project.Name, fileVersion)
 		if err != nil {
 			fmt.Printf("⚠️  Warning: could not read changelog entry: %v\n", err)
-			changelogEntry = fmt.Sprintf("Release %s", fileVersion.String())
+			releaseNotes = fmt.Sprintf("Release %s", fileVersion.String())
 		}
 
-		releaseNotes := extractReleaseNotes(changelogEntry)
-
 		fmt.Println("Creating GitHub release...")
 		_, err = c.ghClient.CreateRelease(ctx, owner, repo, &github.CreateReleaseRequest{
-			TagName: tag,
-			Name:    tag,
-			Body:    releaseNotes,
+			TagName:    tag,
+			Name:       tag,
+			Body:       releaseNotes,
+			Prerelease: fileVersion.IsPrerelease(),
 		})
 		if err != nil {
 			return fmt.Errorf("failed to create release: %w", err)
@@ -155,26 +166,19 @@ func (c *PublishCommand) Run(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func (c *PublishCommand) getChangelogForVersion(projectRoot string, version *models.Version) (string, error) {
-	changelog := changelog.NewChangelog(c.fs)
-	return changelog.GetEntryForVersion(projectRoot, version)
+func (c *PublishCommand) getChangelogForVersion(ws *workspace.Workspace, project *models.Project, version *models.Version) (string, error) {
+	return newChangelog(c.fs, ws).GetEntryForVersion(project.RootPath, project.Name, version)
 }
 
-func extractReleaseNotes(entry string) string {
-	lines := strings.Split(entry, "\n")
-	if len(lines) == 0 {
-		return entry
+// versionSource names the file a project's version is read from, relative to
+// the project root, e.g. "version.txt". Stores without files read it from tags.
+func versionSource(versionStore versioning.VersionStore, projectRoot string) string {
+	files := versionStore.Files(projectRoot)
+	if len(files) == 0 {
+		return "tags and CHANGELOG.md"
 	}
-
-	var noteLines []string
-	skipFirst := false
-	for _, line := range lines {
-		if strings.HasPrefix(line, "## ") && !skipFirst {
-			skipFirst = true
-			continue
-		}
-		noteLines = append(noteLines, line)
+	if rel, err := filepath.Rel(projectRoot, files[0]); err == nil {
+		return filepath.ToSlash(rel)
 	}
-
-	return strings.TrimSpace(strings.Join(noteLines, "\n"))
+	return files[0]
 }
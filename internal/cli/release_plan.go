@@ -0,0 +1,389 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/jakoblorz/go-changesets/internal/changeset"
+	"github.com/jakoblorz/go-changesets/internal/filesystem"
+	"github.com/jakoblorz/go-changesets/internal/git"
+	"github.com/jakoblorz/go-changesets/internal/models"
+	"github.com/jakoblorz/go-changesets/internal/workspace"
+)
+
+// projectRelease describes the projected release of a single project.
+type projectRelease struct {
+	Project *models.Project
+
+	// Changesets are the changesets included in this release's changelog entry.
+	// In pre mode this excludes changesets already released as a prerelease.
+	Changesets []*models.Changeset
+
+	// Dependencies are the releases of workspace dependencies that caused
+	// this project to be released, or were released along with it.
+	Dependencies []*projectRelease
+
+	// GroupChangesets are the changesets of all members of a fixed group,
+	// so that every member gets a matching changelog entry.
+	GroupChangesets []*models.Changeset
+
+	Bump           models.BumpType
+	CurrentVersion *models.Version
+	NextVersion    *models.Version
+	Tag            string
+}
+
+// ChangelogChangesets returns the changesets listed in the release's changelog entry.
+func (r *projectRelease) ChangelogChangesets() []*models.Changeset {
+	if r.GroupChangesets != nil {
+		return r.GroupChangesets
+	}
+	return r.Changesets
+}
+
+// releasePlanner computes what 'changeset version' would do for the workspace.
+type releasePlanner struct {
+	fs  filesystem.FileSystem
+	git git.GitClient
+	ws  *workspace.Workspace
+
+	// dependentBump is applied to dependents of released projects; empty disables propagation
+	dependentBump models.BumpType
+}
+
+func newReleasePlanner(fs filesystem.FileSystem, gitClient git.GitClient, ws *workspace.Workspace) *releasePlanner {
+	return &releasePlanner{fs: fs, git: gitClient, ws: ws, dependentBump: models.BumpPatch}
+}
+
+// WithDependentBump sets the bump applied to dependents of released projects.
+// An empty bump disables propagation.
+func (p *releasePlanner) WithDependentBump(bump models.BumpType) *releasePlanner {
+	p.dependentBump = bump
+	return p
+}
+
+// Plan returns one release per project affected by the given changesets,
+// including dependents of released projects, in workspace project order.
+func (p *releasePlanner) Plan(changesets []*models.Changeset) ([]*projectRelease, error) {
+	if err := validateChangesetTypes(p.ws, changesets); err != nil {
+		return nil, err
+	}
+
+	pre, err := changeset.NewManager(p.fs, p.ws.ChangesetDir()).ReadPreState()
+	if err != nil {
+		return nil, err
+	}
+
+	var releases []*projectRelease
+	for _, project := range p.ws.Projects {
+		release, err := p.PlanProject(project, changeset.FilterByProject(changesets, project.Name), pre)
+		if err != nil {
+			return nil, err
+		}
+		if release != nil {
+			releases = append(releases, release)
+		}
+	}
+
+	return p.propagate(releases, changesets, pre)
+}
+
+// PlanRelease returns the release of a single project followed by the
+// releases of its dependents, or nil if there is nothing to release.
+// Dependents include their own pending changesets from changesets.
+func (p *releasePlanner) PlanRelease(project *models.Project, changesets []*models.Changeset, pre *models.PreState) ([]*projectRelease, error) {
+	if err := validateChangesetTypes(p.ws, changesets); err != nil {
+		return nil, err
+	}
+
+	release, err := p.PlanProject(project, changeset.FilterByProject(changesets, project.Name), pre)
+	if err != nil || release == nil {
+		return nil, err
+	}
+
+	releases, err := p.propagate([]*projectRelease{release}, changesets, pre)
+	if err != nil {
+		return nil, err
+	}
+
+	// Keep the requested project first
+	ordered := []*projectRelease{release}
+	for _, r := range releases {
+		if r != release {
+			ordered = append(ordered, r)
+		}
+	}
+	return ordered, nil
+}
+
+// PlanProject returns the release of a single project, or nil if there is
+// nothing to release. pre is the prerelease mode state and may be nil.
+func (p *releasePlanner) PlanProject(project *models.Project, projectChangesets []*models.Changeset, pre *models.PreState) (*projectRelease, error) {
+	if len(projectChangesets) == 0 {
+		return nil, nil
+	}
+
+	releaseChangesets := projectChangesets
+	if pre != nil && pre.IsActive() {
+		releaseChangesets = nil
+		for _, cs := range projectChangesets {
+			if !pre.IsConsumed(project.Name, cs.ID) {
+				releaseChangesets = append(releaseChangesets, cs)
+			}
+		}
+		if len(releaseChangesets) == 0 {
+			return nil, nil
+		}
+	}
+
+	csManager := changeset.NewManager(p.fs, p.ws.ChangesetDir())
+	return p.newRelease(project, releaseChangesets, csManager.GetHighestBump(projectChangesets, project.Name), pre)
+}
+
+// propagate adds releases for the dependents of the given releases and the
+// other members of their version groups, transitively, and returns all releases
+// in workspace project order. Dependents with pending changesets of their own
+// are released with the higher of both bumps. Members of a version group are
+// aligned to the group's highest bump.
+func (p *releasePlanner) propagate(releases []*projectRelease, changesets []*models.Changeset, pre *models.PreState) ([]*projectRelease, error) {
+	if len(releases) == 0 {
+		return releases, nil
+	}
+
+	var graph *workspace.DependencyGraph
+	if p.dependentBump != "" {
+		var err error
+		graph, err = p.ws.DependencyGraph()
+		if err != nil {
+			return nil, fmt.Errorf("failed to build dependency graph: %w", err)
+		}
+	}
+
+	planned := make(map[string]*projectRelease, len(releases))
+	queue := make([]*projectRelease, 0, len(releases))
+	for _, release := range releases {
+		planned[release.Project.Name] = release
+		queue = append(queue, release)
+	}
+
+	// plan returns the planned release of a project, planning it with its own
+	// changesets or the given bump if it is not planned yet. With an empty bump,
+	// a project without pending changesets is not planned and nil is returned.
+	plan := func(name string, bump models.BumpType) (*projectRelease, error) {
+		if release, ok := planned[name]; ok {
+			return release, nil
+		}
+
+		project, err := p.ws.GetProject(name)
+		if err != nil {
+			return nil, err
+		}
+
+		release, err := p.PlanProject(project, changeset.FilterByProject(changesets, name), pre)
+		if err != nil {
+			return nil, err
+		}
+		if release == nil {
+			if bump == "" {
+				return nil, nil
+			}
+			release, err = p.newRelease(project, nil, bump, pre)
+			if err != nil {
+				return nil, err
+			}
+		}
+
+		planned[name] = release
+		queue = append(queue, release)
+		return release, nil
+	}
+
+	for len(queue) > 0 {
+		release := queue[0]
+		queue = queue[1:]
+
+		// Fixed group members are always released together, linked members
+		// only if they have pending changesets
+		if group := release.Project.Group; group != nil {
+			bump := release.Bump
+			if group.Kind == models.GroupKindLinked {
+				bump = ""
+			}
+			for _, name := range group.Projects {
+				if _, err := plan(name, bump); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		if graph == nil {
+			continue
+		}
+
+		for _, name := range graph.Dependents(release.Project.Name) {
+			dependent, err := plan(name, p.dependentBump)
+			if err != nil {
+				return nil, err
+			}
+
+			dependent.Dependencies = append(dependent.Dependencies, release)
+			if bumpRank(p.dependentBump) > bumpRank(dependent.Bump) {
+				if err := p.setBump(dependent, p.dependentBump, pre); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	if err := p.alignGroups(planned, pre); err != nil {
+		return nil, err
+	}
+
+	ordered := make([]*projectRelease, 0, len(planned))
+	for _, project := range p.ws.Projects {
+		if release, ok := planned[project.Name]; ok {
+			ordered = append(ordered, release)
+		}
+	}
+	return ordered, nil
+}
+
+// alignGroups applies the highest bump of the released members of each
+// version group to all of them and moves them to the same next version, the
+// highest one among them. Fixed group members also share their changelog entry.
+func (p *releasePlanner) alignGroups(planned map[string]*projectRelease, pre *models.PreState) error {
+	for _, group := range p.ws.VersionGroups() {
+		var members []*projectRelease
+		var bump models.BumpType
+		for _, name := range group.Projects {
+			if release, ok := planned[name]; ok {
+				members = append(members, release)
+				if bumpRank(release.Bump) > bumpRank(bump) {
+					bump = release.Bump
+				}
+			}
+		}
+		if len(members) == 0 {
+			continue
+		}
+
+		var nextVersion *models.Version
+		for _, release := range members {
+			if err := p.setBump(release, bump, pre); err != nil {
+				return err
+			}
+			if nextVersion == nil || release.NextVersion.Compare(nextVersion) > 0 {
+				nextVersion = release.NextVersion
+			}
+		}
+
+		var groupChangesets []*models.Changeset
+		if group.Kind == models.GroupKindFixed {
+			groupChangesets = releasedChangesets(members)
+		}
+
+		for _, release := range members {
+			release.NextVersion = nextVersion
+			release.Tag = tagName(release.Project, nextVersion)
+			release.GroupChangesets = groupChangesets
+		}
+	}
+
+	return nil
+}
+
+func (p *releasePlanner) newRelease(project *models.Project, changesets []*models.Changeset, bump models.BumpType, pre *models.PreState) (*projectRelease, error) {
+	versionStore, err := projectVersionStore(p.fs, p.git, p.ws, project)
+	if err != nil {
+		return nil, err
+	}
+	currentVersion, err := versionStore.Read(project.RootPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read current version of %s: %w", project.Name, err)
+	}
+
+	release := &projectRelease{
+		Project:        project,
+		Changesets:     changesets,
+		CurrentVersion: currentVersion,
+	}
+	if err := p.setBump(release, bump, pre); err != nil {
+		return nil, err
+	}
+	return release, nil
+}
+
+// setBump sets the bump of a release and recomputes its next version and tag.
+func (p *releasePlanner) setBump(release *projectRelease, bump models.BumpType, pre *models.PreState) error {
+	// Bump from the version before pre mode so prereleases and the final
+	// release agree on the target, e.g. 1.2.0 -> 1.3.0-beta.N -> 1.3.0
+	baseVersion := release.CurrentVersion
+	if pre != nil {
+		initial, ok, err := pre.InitialVersion(release.Project.Name)
+		if err != nil {
+			return err
+		}
+		if ok {
+			baseVersion = initial
+		}
+	}
+
+	nextVersion := bumpVersion(baseVersion, bump)
+	if pre != nil && pre.IsActive() {
+		nextVersion = pre.PrereleaseVersion(nextVersion, release.CurrentVersion)
+	}
+
+	release.Bump = bump
+	release.NextVersion = nextVersion
+	release.Tag = tagName(release.Project, nextVersion)
+	return nil
+}
+
+// validateChangesetTypes checks the types of changesets against the configured
+// categories, so that a typo fails instead of landing in "Other Changes".
+func validateChangesetTypes(ws *workspace.Workspace, changesets []*models.Changeset) error {
+	categories := ws.Config.Categories()
+	for _, cs := range changesets {
+		if err := cs.ValidateCategories(categories); err != nil {
+			return fmt.Errorf("invalid changeset %s: %w", cs.ID, err)
+		}
+	}
+	return nil
+}
+
+// bumpVersion applies bump to version. A prerelease, e.g. a snapshot written
+// with 'snapshot --write-version', is released as its base version when the
+// bump does not go past it: 1.3.0-next.0 with a minor bump gives 1.3.0.
+func bumpVersion(version *models.Version, bump models.BumpType) *models.Version {
+	if !version.IsPrerelease() {
+		return version.Bump(bump)
+	}
+
+	base := version.StripPrerelease()
+	switch bump {
+	case models.BumpMajor:
+		if base.Minor == 0 && base.Patch == 0 {
+			return base
+		}
+	case models.BumpMinor:
+		if base.Patch == 0 {
+			return base
+		}
+	case models.BumpPatch:
+		return base
+	}
+	return base.Bump(bump)
+}
+
+// bumpRank orders bump types from patch to major.
+func bumpRank(bump models.BumpType) int {
+	switch bump {
+	case models.BumpMajor:
+		return 3
+	case models.BumpMinor:
+		return 2
+	case models.BumpPatch:
+		return 1
+	default:
+		return 0
+	}
+}
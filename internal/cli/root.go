@@ -11,6 +11,9 @@ import (
 
 // NewRootCommand creates the root command
 func NewRootCommand(fs filesystem.FileSystem, gitClient git.GitClient, ghClient github.GitHubClient) *cobra.Command {
+	dryRun := newDryRun(fs, gitClient, ghClient)
+	fs, gitClient, ghClient = dryRun.clients()
+
 	rootCmd := &cobra.Command{
 		Use:   "changeset",
 		Short: "Manage changesets for Go monorepos",
@@ -18,6 +21,16 @@ func NewRootCommand(fs filesystem.FileSystem, gitClient git.GitClient, ghClient
 		
 Changesets help track changes, version projects, and publish releases.`,
 		SilenceUsage: true,
+		PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+			if err := applyConfig(fs, cmd); err != nil {
+				return err
+			}
+			dryRun.enable(cmd)
+			return nil
+		},
+		PersistentPostRunE: func(cmd *cobra.Command, args []string) error {
+			return dryRun.report(cmd.OutOrStdout())
+		},
 		RunE: func(cmd *cobra.Command, args []string) error {
 			// Default to `changeset add` when no subcommand is provided.
 			return (&AddCommand{fs: fs}).Run(cmd, args)
@@ -25,14 +38,21 @@ Changesets help track changes, version projects, and publish releases.`,
 	}
 
 	rootCmd.PersistentFlags().Bool(nodeStrictWorkspaceFlag, false, "Limit Node discovery to workspace manifests")
+	rootCmd.PersistentFlags().Bool(dryRunFlag, false, "Print the file diffs, tags, pushes and GitHub calls a command would make, without making them")
+	rootCmd.PersistentFlags().StringSlice(tagSchemeFlag, nil, "Release tag scheme: project (name@v1.2.3) or module (path/to/module/v1.2.3); use name=scheme to set it for one project")
 
 	// Add subcommands
 	rootCmd.AddCommand(NewAddCommand(fs))
 	rootCmd.AddCommand(NewVersionCommand(fs, gitClient, ghClient))
 	rootCmd.AddCommand(NewChangelogCommand(fs))
 	rootCmd.AddCommand(NewTreeCommand(fs, gitClient, ghClient))
+	rootCmd.AddCommand(NewStatusCommand(fs, gitClient))
+	rootCmd.AddCommand(NewVerifyCommand(fs, gitClient))
+	rootCmd.AddCommand(NewFromCommitsCommand(fs, gitClient))
+	rootCmd.AddCommand(NewPreCommand(fs, gitClient))
 	rootCmd.AddCommand(NewPublishCommand(fs, gitClient, ghClient))
 	rootCmd.AddCommand(NewSnapshotCommand(fs, gitClient, ghClient))
+	rootCmd.AddCommand(NewPromoteCommand(fs, gitClient, ghClient))
 	rootCmd.AddCommand(NewEachCommand(fs, gitClient, nil))
 	rootCmd.AddCommand(NewGHCommand(fs, gitClient, ghClient))
 
@@ -1,11 +1,15 @@
 package cli
 
 import (
+	"bytes"
 	"context"
 	"fmt"
+	"regexp"
+	"strconv"
 	"strings"
+	"text/template"
+	"time"
 
-	"github.com/jakoblorz/go-changesets/internal/changelog"
 	"github.com/jakoblorz/go-changesets/internal/changeset"
 	"github.com/jakoblorz/go-changesets/internal/filesystem"
 	"github.com/jakoblorz/go-changesets/internal/git"
@@ -14,6 +18,11 @@ import (
 	"github.com/spf13/cobra"
 )
 
+const (
+	defaultSnapshotTemplate        = "{{.Next}}-rc{{.N}}"
+	defaultChannelSnapshotTemplate = "{{.Next}}-{{.Channel}}.{{.N}}"
+)
+
 // SnapshotCommand handles the snapshot command
 type SnapshotCommand struct {
 	fs       filesystem.FileSystem
@@ -32,13 +41,27 @@ func NewSnapshotCommand(fs filesystem.FileSystem, gitClient git.GitClient, ghCli
 	cobraCmd := &cobra.Command{
 		Use:   "snapshot",
 		Short: "Creates & pushes a new release candidate (RC) git tag; Optionally creates a release on GitHub",
-		Long:  `Creates & pushes a new pre-release candidate git tag with an -rc{N} suffix; Optionally creates a release on GitHub. Does not modify changesets or version files.`,
-		RunE:  cmd.Run,
+		Long: `Creates & pushes a new pre-release candidate git tag; Optionally creates a release on GitHub. Does not modify changesets, and only modifies version files with --write-version.
+
+The snapshot version is rendered from --template. The default is {{.Next}}-rc{{.N}},
+or {{.Next}}-{{.Channel}}.{{.N}} with --channel. Templates can use:
+  {{.Next}}       the next version, e.g. 1.3.0
+  {{.N}}          the next number of the snapshot sequence, starting at 0
+  {{.Channel}}    the --channel, e.g. beta, canary or next
+  {{.Timestamp}}  the current UTC time, e.g. 20240301120000
+  {{.SHA}}        the HEAD commit SHA prefixed with "g" ({{.ShortSHA}} for the first 7 characters, e.g. g1a2b3c4)
+  {{.Project}}    the project name
+
+{{.N}} is counted per version and channel, so every channel is numbered separately.`,
+		RunE: cmd.Run,
 	}
 
 	cobraCmd.Flags().StringP("project", "p", "", "Project name to snapshot (required unless run via 'changeset each')")
 	cobraCmd.Flags().StringP("owner", "o", "", "GitHub repository owner (optional, enables creating a release)")
 	cobraCmd.Flags().StringP("repo", "r", "", "GitHub repository name (optional, enables creating a release)")
+	cobraCmd.Flags().String("template", "", "Snapshot version template (default \""+defaultSnapshotTemplate+"\", or \""+defaultChannelSnapshotTemplate+"\" with --channel)")
+	cobraCmd.Flags().String("channel", "", "Snapshot channel with its own numbering, e.g. beta, canary or next")
+	cobraCmd.Flags().Bool("write-version", false, "Also write the snapshot version to the project's version files (changesets are kept)")
 
 	return cobraCmd
 }
@@ -48,8 +71,25 @@ func (c *SnapshotCommand) Run(cmd *cobra.Command, args []string) error {
 	projectFlag, _ := cmd.Flags().GetString("project")
 	owner, _ := cmd.Flags().GetString("owner")
 	repo, _ := cmd.Flags().GetString("repo")
+	templateText, _ := cmd.Flags().GetString("template")
+	channel, _ := cmd.Flags().GetString("channel")
+	writeVersion, _ := cmd.Flags().GetBool("write-version")
+
+	if templateText == "" {
+		templateText = defaultSnapshotTemplate
+		if channel != "" {
+			templateText = defaultChannelSnapshotTemplate
+		}
+	}
+	if channel != "" && !strings.Contains(templateText, ".Channel") {
+		return fmt.Errorf("--template must contain {{.Channel}} when --channel is set")
+	}
+	snapshotTmpl, err := template.New("snapshot").Option("missingkey=error").Parse(templateText)
+	if err != nil {
+		return fmt.Errorf("invalid snapshot template: %w", err)
+	}
 
-	resolved, err := resolveProject(c.fs, projectFlag, workspaceOptionsFromCmd(cmd)...)
+	resolved, err := resolveProject(c.fs, projectFlag, workspaceOptionsFromCmd(cmd, c.git)...)
 	if err != nil {
 		if projectFlag == "" {
 			return fmt.Errorf("--project flag required (or run via 'changeset each'): %w", err)
@@ -87,26 +127,38 @@ func (c *SnapshotCommand) Run(cmd *cobra.Command, args []string) error {
 	highestBump := csManager.GetHighestBump(projectChangesets, resolved.Name)
 	fmt.Printf("Highest bump type: %s\n\n", highestBump)
 
-	nextVersion, err := c.calculateNextVersion(resolved.Name, resolved.Project.Type, highestBump)
+	nextVersion, err := c.calculateNextVersion(resolved.Project, highestBump)
 	if err != nil {
 		return fmt.Errorf("failed to calculate next version: %w", err)
 	}
 
 	fmt.Printf("Next version: %s\n", nextVersion.String())
 
-	rcNumber, err := c.findNextRCNumber(resolved.Name, resolved.Project.Type, nextVersion)
+	snapshotVersion, err := c.renderSnapshotVersion(snapshotTmpl, resolved.Project, nextVersion, channel)
 	if err != nil {
-		return fmt.Errorf("failed to find next RC number: %w", err)
+		return err
 	}
+	tag := tagName(resolved.Project, snapshotVersion)
 
-	fmt.Printf("Next RC number: rc%d\n\n", rcNumber)
+	fmt.Printf("Snapshot version: %s\n\n", snapshotVersion.String())
 
-	rcVersion := nextVersion.WithPrerelease(fmt.Sprintf("rc%d", rcNumber))
-	tag := tagName(resolved.Name, resolved.Project.Type, rcVersion)
+	if writeVersion {
+		store, err := projectVersionStore(c.fs, c.git, resolved.Workspace, resolved.Project)
+		if err != nil {
+			return err
+		}
+		if err := store.Write(resolved.Project.RootPath, snapshotVersion); err != nil {
+			return fmt.Errorf("failed to write version: %w", err)
+		}
+		for _, file := range store.Files(resolved.Project.RootPath) {
+			fmt.Printf("✓ Updated %s\n", file)
+		}
+		fmt.Println()
+	}
 
 	fmt.Printf("Creating snapshot tag: %s\n", tag)
 
-	changelog := changelog.NewChangelog(c.fs)
+	changelog := newChangelog(c.fs, resolved.Workspace)
 	summary, err := changelog.FormatEntry(projectChangesets, resolved.Project.Name, resolved.Project.RootPath)
 	if err != nil {
 		return fmt.Errorf("failed to format changelog entry: %w", err)
@@ -147,9 +199,13 @@ func (c *SnapshotCommand) Run(cmd *cobra.Command, args []string) error {
 		}
 
 		fmt.Println("Creating GitHub pre-release...")
+		releaseName := tag
+		if channel != "" {
+			releaseName = fmt.Sprintf("%s (%s)", tag, channel)
+		}
 		_, err = c.ghClient.CreateRelease(ctx, owner, repo, &github.CreateReleaseRequest{
 			TagName:    tag,
-			Name:       tag,
+			Name:       releaseName,
 			Body:       summary,
 			Prerelease: true,
 		})
@@ -160,18 +216,21 @@ func (c *SnapshotCommand) Run(cmd *cobra.Command, args []string) error {
 		fmt.Printf("Release URL: https://github.com/%s/%s/releases/tag/%s\n", owner, repo, tag)
 	}
 
-	fmt.Printf("\n🎉 Successfully created snapshot %s@%s\n", resolved.Name, rcVersion.String())
+	fmt.Printf("\n🎉 Successfully created snapshot %s@%s\n", resolved.Name, snapshotVersion.String())
 
 	return nil
 }
 
-func (c *SnapshotCommand) calculateNextVersion(projectName string, projectType models.ProjectType, bump models.BumpType) (*models.Version, error) {
+func (c *SnapshotCommand) calculateNextVersion(project *models.Project, bump models.BumpType) (*models.Version, error) {
 	if c.git == nil {
 		return nil, fmt.Errorf("git client not available")
 	}
 
-	latestVersion, err := getLatestNonRCVersion(c.git, projectName, projectType)
+	latestVersion, err := c.latestReleaseVersion(project)
 	if err != nil {
+		return nil, err
+	}
+	if latestVersion == nil {
 		latestVersion = &models.Version{Major: 0, Minor: 0, Patch: 0}
 		fmt.Printf("No existing tags found (first release)\n")
 	} else {
@@ -182,39 +241,130 @@ func (c *SnapshotCommand) calculateNextVersion(projectName string, projectType m
 	return nextVersion, nil
 }
 
-func (c *SnapshotCommand) findNextRCNumber(projectName string, projectType models.ProjectType, version *models.Version) (int, error) {
-	if c.git == nil {
-		return 0, nil
+// latestReleaseVersion returns the highest version of the project's release
+// tags, ignoring snapshot and other prerelease tags, or nil if there is none.
+func (c *SnapshotCommand) latestReleaseVersion(project *models.Project) (*models.Version, error) {
+	tags, err := c.git.GetTagsWithPrefix(tagPrefixPattern(project))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tags: %w", err)
 	}
 
-	prefix := tagPrefixPattern(projectName, projectType)
-	allTags, err := c.git.GetTagsWithPrefix(prefix)
+	var latest *models.Version
+	for _, tag := range tags {
+		version, err := project.TagVersion(tag)
+		if err != nil || version.IsPrerelease() {
+			continue
+		}
+		if latest == nil || version.Compare(latest) > 0 {
+			latest = version
+		}
+	}
+	return latest, nil
+}
+
+// Placeholders for the template fields that vary between snapshots of the
+// same sequence, replaced by patterns to match existing snapshot tags.
+const (
+	snapshotNumberMarker = "\x00N\x00"
+	snapshotTimeMarker   = "\x00T\x00"
+	snapshotSHAMarker    = "\x00S\x00"
+)
+
+// renderSnapshotVersion renders the snapshot version of next. {{.N}} is one
+// more than the highest number of the existing tags that match the template
+// with every other field set.
+func (c *SnapshotCommand) renderSnapshotVersion(tmpl *template.Template, project *models.Project, next *models.Version, channel string) (*models.Version, error) {
+	data := map[string]any{
+		"Next":      next.String(),
+		"Channel":   channel,
+		"Project":   project.Name,
+		"N":         snapshotNumberMarker,
+		"Timestamp": snapshotTimeMarker,
+		"SHA":       snapshotSHAMarker,
+		"ShortSHA":  snapshotSHAMarker,
+	}
+	pattern, err := executeSnapshotTemplate(tmpl, data)
 	if err != nil {
-		return 0, err
+		return nil, err
 	}
 
-	versionPrefix := fmt.Sprintf("%s@%s-rc", projectName, tagVersionString(projectType, version))
-	var rcTags []string
-	for _, tag := range allTags {
-		if strings.HasPrefix(tag, versionPrefix) {
-			rcTags = append(rcTags, tag)
+	number := 0
+	if strings.Contains(pattern, snapshotNumberMarker) {
+		number, err = c.nextSnapshotNumber(project, pattern)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find next snapshot number: %w", err)
 		}
 	}
+	data["N"] = number
+
+	if strings.Contains(pattern, snapshotTimeMarker) {
+		data["Timestamp"] = time.Now().UTC().Format("20060102150405")
+	}
+
+	if strings.Contains(pattern, snapshotSHAMarker) {
+		sha, err := c.git.GetHeadCommit()
+		if err != nil {
+			return nil, err
+		}
+		// The "g" prefix keeps SHAs such as 0123456 from being read as
+		// numeric identifiers, which must not have leading zeros
+		data["SHA"] = "g" + sha
+		data["ShortSHA"] = "g" + sha[:min(len(sha), 7)]
+	}
+
+	rendered, err := executeSnapshotTemplate(tmpl, data)
+	if err != nil {
+		return nil, err
+	}
+	version, err := models.ParseVersion(rendered)
+	if err != nil {
+		return nil, fmt.Errorf("snapshot template rendered an invalid version %q: %w", rendered, err)
+	}
+	if !version.IsPrerelease() || version.StripPrerelease().Compare(next) != 0 {
+		return nil, fmt.Errorf("snapshot template rendered %q, expected a prerelease of %s", rendered, next.String())
+	}
+	return version, nil
+}
 
-	if len(rcTags) == 0 {
-		return 0, nil
+// nextSnapshotNumber returns one more than the highest {{.N}} of the project's
+// tags that match pattern, a rendered template containing markers.
+func (c *SnapshotCommand) nextSnapshotNumber(project *models.Project, pattern string) (int, error) {
+	expr := regexp.QuoteMeta(pattern)
+	expr = strings.Replace(expr, snapshotNumberMarker, `(\d+)`, 1)
+	expr = strings.ReplaceAll(expr, snapshotNumberMarker, `\d+`)
+	expr = strings.ReplaceAll(expr, snapshotTimeMarker, `\d+`)
+	expr = strings.ReplaceAll(expr, snapshotSHAMarker, `g[0-9a-f]+`)
+	re, err := regexp.Compile("^" + expr + "$")
+	if err != nil {
+		return 0, err
 	}
 
-	highestRC := -1
-	for _, tag := range rcTags {
-		rcNum, err := c.git.ExtractRCNumber(tag)
+	tags, err := c.git.GetTagsWithPrefix(tagPrefixPattern(project))
+	if err != nil {
+		return 0, err
+	}
+
+	next := 0
+	for _, tag := range tags {
+		version, err := project.TagVersion(tag)
 		if err != nil {
 			continue
 		}
-		if rcNum > highestRC {
-			highestRC = rcNum
+		match := re.FindStringSubmatch(version.String())
+		if match == nil {
+			continue
+		}
+		if n, err := strconv.Atoi(match[1]); err == nil && n >= next {
+			next = n + 1
 		}
 	}
+	return next, nil
+}
 
-	return highestRC + 1, nil
+func executeSnapshotTemplate(tmpl *template.Template, data map[string]any) (string, error) {
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render snapshot template: %w", err)
+	}
+	return strings.TrimSpace(buf.String()), nil
 }
@@ -0,0 +1,180 @@
+package cli
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jakoblorz/go-changesets/internal/changeset"
+	"github.com/jakoblorz/go-changesets/internal/filesystem"
+	"github.com/jakoblorz/go-changesets/internal/git"
+	"github.com/jakoblorz/go-changesets/internal/models"
+	"github.com/jakoblorz/go-changesets/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+// StatusCommand handles the status command
+type StatusCommand struct {
+	fs  filesystem.FileSystem
+	git git.GitClient
+}
+
+// StatusOutput is the JSON representation of the projected release plan.
+type StatusOutput struct {
+	Releases []StatusRelease `json:"releases"`
+}
+
+// StatusRelease describes the projected release of a single project.
+type StatusRelease struct {
+	Project        string   `json:"project"`
+	Bump           string   `json:"bump"`
+	CurrentVersion string   `json:"currentVersion"`
+	NextVersion    string   `json:"nextVersion"`
+	Tag            string   `json:"tag"`
+	Changesets     []string `json:"changesets"`
+
+	// Dependencies are the released workspace dependencies, e.g. "shared@1.3.0"
+	Dependencies []string `json:"dependencies,omitempty"`
+
+	// Group is the fixed or linked version group of the project, if any
+	Group *models.VersionGroup `json:"group,omitempty"`
+}
+
+// errNoPendingChangesets is returned by 'status --exit-code' when nothing would be released.
+var errNoPendingChangesets = fmt.Errorf("no pending changesets")
+
+// NewStatusCommand creates a new status command
+func NewStatusCommand(fs filesystem.FileSystem, gitClient git.GitClient) *cobra.Command {
+	cmd := &StatusCommand{fs: fs, git: gitClient}
+
+	cobraCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Show the projected release plan for pending changesets",
+		Long: `Shows what 'changeset version' would do right now.
+
+For every project with pending changesets, prints the highest bump type,
+the current version, the projected next version and the tag name that
+'changeset publish' would create. Workspace projects depending on a released
+project are included with a patch bump, and members of a fixed or linked
+version group with the group's highest bump. No files are modified.`,
+		Example: `  # Human-readable plan
+  changeset status
+
+  # JSON for scripting
+  changeset status --format json
+
+  # Fail in CI when no changesets are pending
+  changeset status --exit-code`,
+		RunE: cmd.Run,
+	}
+
+	cobraCmd.Flags().String("format", "text", "Output format: text or json")
+	cobraCmd.Flags().Bool("exit-code", false, "Exit with a non-zero code when no changesets are pending")
+
+	return cobraCmd
+}
+
+// Run executes the status command
+func (c *StatusCommand) Run(cmd *cobra.Command, args []string) error {
+	format, _ := cmd.Flags().GetString("format")
+	exitCode, _ := cmd.Flags().GetBool("exit-code")
+
+	if format != "text" && format != "json" {
+		return fmt.Errorf("invalid format: %s (must be text or json)", format)
+	}
+
+	opts := workspaceOptionsFromCmd(cmd, c.git)
+	if format == "json" {
+		opts = append(opts, workspace.WithWarningWriter(nil))
+	}
+
+	ws := workspace.New(c.fs, opts...)
+	if err := ws.Detect(); err != nil {
+		return fmt.Errorf("failed to detect workspace: %w", err)
+	}
+
+	csManager := changeset.NewManager(c.fs, ws.ChangesetDir())
+	allChangesets, err := csManager.ReadAll()
+	if err != nil {
+		return fmt.Errorf("failed to read changesets: %w", err)
+	}
+
+	releases, err := newReleasePlanner(c.fs, c.git, ws).Plan(allChangesets)
+	if err != nil {
+		return fmt.Errorf("failed to compute release plan: %w", err)
+	}
+
+	output := StatusOutput{Releases: make([]StatusRelease, 0, len(releases))}
+	for _, release := range releases {
+		ids := make([]string, 0, len(release.Changesets))
+		for _, cs := range release.Changesets {
+			ids = append(ids, cs.ID)
+		}
+
+		var dependencies []string
+		for _, dep := range release.Dependencies {
+			dependencies = append(dependencies, fmt.Sprintf("%s@%s", dep.Project.Name, dep.NextVersion.String()))
+		}
+
+		output.Releases = append(output.Releases, StatusRelease{
+			Project:        release.Project.Name,
+			Bump:           release.Bump.String(),
+			CurrentVersion: release.CurrentVersion.String(),
+			NextVersion:    release.NextVersion.String(),
+			Tag:            release.Tag,
+			Changesets:     ids,
+			Dependencies:   dependencies,
+			Group:          release.Project.Group,
+		})
+	}
+
+	if format == "json" {
+		if err := writeStatusJSON(cmd.OutOrStdout(), output); err != nil {
+			return err
+		}
+	} else {
+		writeStatusText(cmd.OutOrStdout(), output)
+	}
+
+	if exitCode && len(output.Releases) == 0 {
+		return errNoPendingChangesets
+	}
+
+	return nil
+}
+
+func writeStatusJSON(w io.Writer, output StatusOutput) error {
+	data, err := json.MarshalIndent(output, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+
+	_, _ = fmt.Fprintln(w, string(data))
+	return nil
+}
+
+func writeStatusText(w io.Writer, output StatusOutput) {
+	if len(output.Releases) == 0 {
+		_, _ = fmt.Fprintln(w, "No pending changesets")
+		return
+	}
+
+	_, _ = fmt.Fprintln(w, "📋 Release plan")
+	_, _ = fmt.Fprintln(w)
+
+	for _, release := range output.Releases {
+		_, _ = fmt.Fprintf(w, "%s (%s): %s -> %s\n", release.Project, release.Bump, release.CurrentVersion, release.NextVersion)
+		_, _ = fmt.Fprintf(w, "  tag: %s\n", release.Tag)
+		_, _ = fmt.Fprintf(w, "  changesets: %d\n", len(release.Changesets))
+		if len(release.Dependencies) > 0 {
+			_, _ = fmt.Fprintf(w, "  updated dependencies: %s\n", strings.Join(release.Dependencies, ", "))
+		}
+		if release.Group != nil {
+			_, _ = fmt.Fprintf(w, "  %s group: %s\n", release.Group.Kind, strings.Join(release.Group.Projects, ", "))
+		}
+	}
+
+	_, _ = fmt.Fprintln(w)
+	_, _ = fmt.Fprintf(w, "%d project(s) would be released\n", len(output.Releases))
+}
@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/jakoblorz/go-changesets/internal/git"
+	"github.com/jakoblorz/go-changesets/internal/workspace"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatus_JSON(t *testing.T) {
+	_, fs := buildWorkspace(t, func(wb *workspace.WorkspaceBuilder) {
+		wb.AddProject("shared", "packages/shared", "github.com/example/shared")
+		wb.AddProject("backend", "apps/backend", "github.com/example/backend")
+		wb.AddProject("www", "apps/www", "github.com/example/www")
+		wb.SetVersion("shared", "1.2.0")
+		wb.SetVersion("backend", "0.4.1")
+		wb.AddChangeset("shared-minor", "shared", "minor", "Add tracing")
+		wb.AddChangeset("shared-patch", "shared", "patch", "Fix header parsing")
+		wb.AddChangeset("backend-patch", "backend", "patch", "Fix timeout")
+	})
+
+	var out bytes.Buffer
+	cmd := NewStatusCommand(fs, git.NewMockGitClient())
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--format", "json"})
+	require.NoError(t, cmd.Execute())
+
+	var status StatusOutput
+	require.NoError(t, json.Unmarshal(out.Bytes(), &status))
+	require.Equal(t, []StatusRelease{
+		{
+			Project:        "shared",
+			Bump:           "minor",
+			CurrentVersion: "1.2.0",
+			NextVersion:    "1.3.0",
+			Tag:            "shared@v1.3.0",
+			Changesets:     []string{"shared-minor", "shared-patch"},
+		},
+		{
+			Project:        "backend",
+			Bump:           "patch",
+			CurrentVersion: "0.4.1",
+			NextVersion:    "0.4.2",
+			Tag:            "backend@v0.4.2",
+			Changesets:     []string{"backend-patch"},
+		},
+	}, status.Releases)
+}
+
+func TestStatus_IncludesDependents(t *testing.T) {
+	_, fs := buildWorkspace(t, func(wb *workspace.WorkspaceBuilder) {
+		wb.AddProject("shared", "packages/shared", "github.com/example/shared")
+		wb.AddProject("backend", "apps/backend", "github.com/example/backend")
+		wb.AddDependency("backend", "github.com/example/shared")
+		wb.SetVersion("shared", "1.2.0")
+		wb.SetVersion("backend", "0.4.1")
+		wb.AddChangeset("shared-minor", "shared", "minor", "Add tracing")
+	})
+
+	var out bytes.Buffer
+	cmd := NewStatusCommand(fs, git.NewMockGitClient())
+	cmd.SetOut(&out)
+	cmd.SetArgs([]string{"--format", "json"})
+	require.NoError(t, cmd.Execute())
+
+	var status StatusOutput
+	require.NoError(t, json.Unmarshal(out.Bytes(), &status))
+	require.Len(t, status.Releases, 2)
+	require.Equal(t, StatusRelease{
+		Project:        "backend",
+		Bump:           "patch",
+		CurrentVersion: "0.4.1",
+		NextVersion:    "0.4.2",
+		Tag:            "backend@v0.4.2",
+		Changesets:     []string{},
+		Dependencies:   []string{"shared@1.3.0"},
+	}, status.Releases[1])
+}
+
+func TestStatus_ExitCode(t *testing.T) {
+	_, fs := buildWorkspace(t, func(wb *workspace.WorkspaceBuilder) {
+		wb.AddProject("shared", "packages/shared", "github.com/example/shared")
+	})
+
+	var out bytes.Buffer
+	cmd := NewStatusCommand(fs, git.NewMockGitClient())
+	cmd.SetOut(&out)
+	cmd.SetErr(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--exit-code"})
+
+	err := cmd.Execute()
+	require.ErrorIs(t, err, errNoPendingChangesets)
+	require.Contains(t, out.String(), "No pending changesets")
+}
+
+func TestStatus_UnknownChangesetType(t *testing.T) {
+	_, fs := buildWorkspace(t, func(wb *workspace.WorkspaceBuilder) {
+		wb.AddProject("backend", "apps/backend", "github.com/example/backend")
+	})
+	fs.AddFile(testWorkspaceRoot+"/.changeset/typo.md", []byte("---\ntype: feet\nbackend: minor\n---\n\nAdd tracing\n"))
+
+	cmd := NewStatusCommand(fs, git.NewMockGitClient())
+	cmd.SetOut(&bytes.Buffer{})
+	require.ErrorContains(t, cmd.Execute(), `invalid changeset typo: unknown change type "feet"`)
+}
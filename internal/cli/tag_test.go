@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jakoblorz/go-changesets/internal/git"
+	"github.com/jakoblorz/go-changesets/internal/models"
+	"github.com/jakoblorz/go-changesets/internal/workspace"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPublish_ModuleTagScheme(t *testing.T) {
+	_, fs := buildWorkspace(t, func(wb *workspace.WorkspaceBuilder) {
+		wb.AddProject("backend", "apps/backend", "github.com/example/backend")
+		wb.AddProject("legacy", "libs/legacy", "github.com/example/legacy")
+		wb.SetVersion("backend", "1.2.0")
+		wb.SetVersion("legacy", "0.3.0")
+	})
+
+	gitClient := git.NewMockGitClient()
+	require.NoError(t, gitClient.CreateTag("apps/backend/v1.1.0", "Release 1.1.0"))
+
+	publish := func(project string) {
+		t.Helper()
+		cmd := NewRootCommand(fs, gitClient, nil)
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetArgs([]string{"--tag-scheme", "module", "--tag-scheme", "legacy=project", "publish", "--project", project})
+		require.NoError(t, cmd.Execute())
+	}
+
+	publish("backend")
+	publish("legacy")
+
+	tags := gitClient.GetAllTags()
+	require.Contains(t, tags, "apps/backend/v1.2.0")
+	require.Contains(t, tags, "legacy@v0.3.0")
+	require.NotContains(t, tags, "backend@v1.2.0")
+
+	// Publishing again finds the module tag and skips
+	publish("backend")
+	require.Len(t, gitClient.GetAllTags(), 3)
+}
+
+func TestLatestTagVersion_ModuleTagScheme(t *testing.T) {
+	ws, _ := buildWorkspace(t, func(wb *workspace.WorkspaceBuilder) {
+		wb.AddProject("auth", "services/auth", "github.com/example/auth")
+	})
+	project, err := ws.GetProject("auth")
+	require.NoError(t, err)
+
+	gitClient := git.NewMockGitClient()
+	require.NoError(t, gitClient.CreateTag("services/auth/v1.4.0", ""))
+	require.NoError(t, gitClient.CreateTag("services/auth/vault/v9.0.0", ""))
+	require.NoError(t, gitClient.CreateTag("auth@v2.0.0", ""))
+
+	require.Equal(t, "2.0.0", latestTagVersion(gitClient, project))
+
+	project.TagScheme = models.TagSchemeModule
+	require.Equal(t, "1.4.0", latestTagVersion(gitClient, project))
+}
+
+func TestTagOnlyVersioning(t *testing.T) {
+	_, fs := buildWorkspace(t, func(wb *workspace.WorkspaceBuilder) {
+		wb.AddProject("auth", "services/auth", "github.com/example/auth")
+		wb.AddChangeset("add-oauth", "auth", "minor", "Add OAuth support")
+	})
+	addConfig(fs, `{"versionStores": [{"type": "tag"}]}`)
+
+	gitClient := git.NewMockGitClient()
+	gitClient.AddTag("auth", "1.2.0", "Release 1.2.0")
+
+	run := func(args ...string) {
+		t.Helper()
+		cmd := NewRootCommand(fs, gitClient, nil)
+		cmd.SetOut(&bytes.Buffer{})
+		cmd.SetArgs(args)
+		require.NoError(t, cmd.Execute())
+	}
+
+	run("version", "--project", "auth")
+	require.False(t, fs.Exists(testWorkspaceRoot+"/services/auth/version.txt"))
+	require.False(t, fs.Exists(testWorkspaceRoot+"/.changeset/add-oauth.md"))
+	require.Contains(t, changelogEntry(t, fs, "services/auth", "1.3.0"), "Add OAuth support")
+	require.Len(t, gitClient.GetAllTags(), 1)
+
+	run("publish", "--project", "auth")
+	require.Contains(t, gitClient.GetAllTags(), "auth@v1.3.0")
+
+	// Publishing again finds the tag and skips
+	run("publish", "--project", "auth")
+	require.Len(t, gitClient.GetAllTags(), 2)
+}
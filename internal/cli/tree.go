@@ -6,7 +6,6 @@ import (
 	"sort"
 	"strings"
 
-	"github.com/jakoblorz/go-changesets/internal/changelog"
 	"github.com/jakoblorz/go-changesets/internal/changeset"
 	"github.com/jakoblorz/go-changesets/internal/filesystem"
 	"github.com/jakoblorz/go-changesets/internal/git"
@@ -45,6 +44,7 @@ type ChangesetInfo struct {
 	ID      string           `json:"id"`
 	File    string           `json:"file"`
 	Bump    string           `json:"bump"`
+	Type    string           `json:"type,omitempty"`
 	Message string           `json:"message"`
 	PR      *PullRequestInfo `json:"pr,omitempty"`
 }
@@ -140,11 +140,15 @@ coordinate reviews when a single feature affects multiple projects.`,
   changeset tree --filter open-changesets --format json > tree.json
   
   # Show all changesets (no filter)
-  changeset tree`,
+  changeset tree
+
+  # Only Go projects below apps/ with at least a minor bump
+  changeset tree --filter 'type==go && path=apps/** && bump>=minor'`,
 		RunE: cmd.Run,
 	}
 
-	cobraCmd.Flags().String("filter", "", "Filter projects (same filters as 'each' command)")
+	cobraCmd.Flags().String("filter", "", "Filter expression (same filters as 'each' command)")
+	cobraCmd.Flags().Bool("include-dependents", false, "With a changed-since filter, also show projects depending on a changed project")
 	cobraCmd.Flags().String("format", "text", "Output format: text or json")
 	cobraCmd.Flags().StringP("owner", "o", "", "GitHub repository owner (optional, enables PR links in changelog preview)")
 	cobraCmd.Flags().StringP("repo", "r", "", "GitHub repository name (optional, enables PR links in changelog preview)")
@@ -156,9 +160,10 @@ coordinate reviews when a single feature affects multiple projects.`,
 func (c *TreeCommand) Run(cmd *cobra.Command, args []string) error {
 	format, _ := cmd.Flags().GetString("format")
 	filter, _ := cmd.Flags().GetString("filter")
+	includeDependents, _ := cmd.Flags().GetBool("include-dependents")
 	owner, _ := cmd.Flags().GetString("owner")
 	repo, _ := cmd.Flags().GetString("repo")
-	c.workspaceOpts = workspaceOptionsFromCmd(cmd)
+	c.workspaceOpts = workspaceOptionsFromCmd(cmd, c.git)
 	if format == "json" {
 		c.workspaceOpts = append(c.workspaceOpts, workspace.WithWarningWriter(nil))
 	}
@@ -197,7 +202,7 @@ func (c *TreeCommand) Run(cmd *cobra.Command, args []string) error {
 
 	// Apply filter if specified
 	if filter != "" {
-		groups, err = c.applyFilter(groups, ws, csManager, filter)
+		groups, err = c.applyFilter(groups, ws, csManager, filter, includeDependents)
 		if err != nil {
 			return fmt.Errorf("failed to apply filter: %w", err)
 		}
@@ -205,7 +210,7 @@ func (c *TreeCommand) Run(cmd *cobra.Command, args []string) error {
 
 	// Output in requested format
 	if format == "json" {
-		return c.outputJSON(groups)
+		return c.outputJSON(ws, groups)
 	}
 
 	return c.outputText(groups)
@@ -271,48 +276,37 @@ func (c *TreeCommand) groupByCommit(changesets []*models.Changeset) ([]*Changese
 
 // applyFilter filters groups to only include projects matching the filter
 func (c *TreeCommand) applyFilter(groups []*ChangesetGroup, ws *workspace.Workspace,
-	csManager *changeset.Manager, filter string) ([]*ChangesetGroup, error) {
-
-	// Simple filter implementation
-	// For tree command, we only care about "open-changesets" filter mainly
-	// Other filters don't make much sense for viewing changeset relationships
+	csManager *changeset.Manager, filter string, includeDependents bool) ([]*ChangesetGroup, error) {
 
 	if filter == "open-changesets" || filter == "" {
 		// No filtering needed - we already only have projects with changesets
 		return groups, nil
 	}
 
-	// For other filters, filter to only projects matching
-	filteredProjects := make(map[string]bool)
+	parsed, err := parseFilters([]string{filter})
+	if err != nil {
+		return nil, err
+	}
 
-	switch filter {
-	case "all":
-		// Include all projects
-		for _, project := range ws.Projects {
-			filteredProjects[project.Name] = true
-		}
-	case "has-version", "no-version", "outdated-versions", "unchanged":
-		builder := newProjectContextBuilder(c.fs, c.git, c.workspaceOpts...)
-		contexts, err := builder.BuildFromWorkspace(ws)
-		if err != nil {
-			return nil, err
-		}
+	// Filter to only projects matching
+	builder := newProjectContextBuilder(c.fs, c.git, c.workspaceOpts...)
+	contexts, err := builder.BuildFromWorkspace(ws)
+	if err != nil {
+		return nil, err
+	}
 
-		filterTypes, err := parseFilters([]string{filter})
-		if err != nil {
-			return nil, err
-		}
+	if err := setChangedSince(ws, c.git, contexts, parsed, includeDependents); err != nil {
+		return nil, err
+	}
 
-		filtered, err := filterContexts(contexts, filterTypes)
-		if err != nil {
-			return nil, err
-		}
+	filtered, err := filterContexts(contexts, parsed)
+	if err != nil {
+		return nil, err
+	}
 
-		for _, ctx := range filtered {
-			filteredProjects[ctx.Project] = true
-		}
-	default:
-		return nil, fmt.Errorf("unknown filter: %s", filter)
+	filteredProjects := make(map[string]bool)
+	for _, ctx := range filtered {
+		filteredProjects[ctx.Project] = true
 	}
 
 	// Filter groups
@@ -428,8 +422,8 @@ func (c *TreeCommand) outputText(groups []*ChangesetGroup) error {
 }
 
 // outputJSON outputs the tree in JSON format
-func (c *TreeCommand) outputJSON(groups []*ChangesetGroup) error {
-	cl := changelog.NewChangelog(c.fs)
+func (c *TreeCommand) outputJSON(ws *workspace.Workspace, groups []*ChangesetGroup) error {
+	cl := newChangelog(c.fs, ws)
 
 	// Convert internal structure to output structure
 	output := TreeOutput{
@@ -477,6 +471,7 @@ func (c *TreeCommand) outputJSON(groups []*ChangesetGroup) error {
 					ID:      cs.ID,
 					File:    cs.FilePath,
 					Bump:    bump.String(),
+					Type:    cs.GetTypeForProject(projectName),
 					Message: cs.Message,
 				}
 
@@ -0,0 +1,184 @@
+package cli
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jakoblorz/go-changesets/internal/changeset"
+	"github.com/jakoblorz/go-changesets/internal/filesystem"
+	"github.com/jakoblorz/go-changesets/internal/git"
+	"github.com/jakoblorz/go-changesets/internal/models"
+	"github.com/jakoblorz/go-changesets/internal/pathglob"
+	"github.com/jakoblorz/go-changesets/internal/workspace"
+	"github.com/spf13/cobra"
+)
+
+// defaultVerifyAllow lists path globs that never require a changeset. Other
+// Markdown files may be embedded or published docs, so only these are allowed.
+var defaultVerifyAllow = []string{
+	"**/*_test.go",
+	"**/testdata/**",
+	"**/README.md",
+	"**/CHANGELOG.md",
+}
+
+// VerifyCommand handles the verify command
+type VerifyCommand struct {
+	fs  filesystem.FileSystem
+	git git.GitClient
+}
+
+// NewVerifyCommand creates a new verify command
+func NewVerifyCommand(fs filesystem.FileSystem, gitClient git.GitClient) *cobra.Command {
+	cmd := &VerifyCommand{
+		fs:  fs,
+		git: gitClient,
+	}
+
+	cobraCmd := &cobra.Command{
+		Use:   "verify",
+		Short: "Fail when changed projects have no changeset",
+		Long: `Checks that every project changed since a base ref has a changeset.
+
+Files changed between the merge base of --since and the working tree are
+mapped to the project that contains them. A project passes when a changeset
+added in the same range mentions it; editing an existing changeset does not
+count. Files matching an --allow
+glob (relative to the workspace root) never require a changeset.
+
+Default allowlist: ` + strings.Join(defaultVerifyAllow, ", "),
+		Example: `  # In a pull request workflow
+  changeset verify --since origin/main
+
+  # Also ignore changes to examples
+  changeset verify --since origin/main --allow "examples/**"`,
+		RunE: cmd.Run,
+	}
+
+	cobraCmd.Flags().String("since", "", "Base ref to diff against (required)")
+	cobraCmd.Flags().StringSlice("allow", defaultVerifyAllow, "Path globs that never require a changeset")
+
+	return cobraCmd
+}
+
+// Run executes the verify command
+func (c *VerifyCommand) Run(cmd *cobra.Command, args []string) error {
+	since, _ := cmd.Flags().GetString("since")
+	allow, _ := cmd.Flags().GetStringSlice("allow")
+
+	if since == "" {
+		return fmt.Errorf("--since is required")
+	}
+
+	ws := workspace.New(c.fs, workspaceOptionsFromCmd(cmd, c.git)...)
+	if err := ws.Detect(); err != nil {
+		return fmt.Errorf("failed to detect workspace: %w", err)
+	}
+
+	changedFiles, err := c.git.GetChangedFiles(since)
+	if err != nil {
+		return fmt.Errorf("failed to get changed files: %w", err)
+	}
+
+	addedFiles, err := c.git.GetAddedFiles(since)
+	if err != nil {
+		return fmt.Errorf("failed to get added files: %w", err)
+	}
+
+	changesetDir := ws.ChangesetDir()
+	csManager := changeset.NewManager(c.fs, changesetDir)
+	covered := make(map[string]bool)
+	for _, file := range addedFiles {
+		if filepath.Dir(file) != changesetDir {
+			continue
+		}
+		if err := c.coverFromChangeset(ws, csManager, file, covered); err != nil {
+			return err
+		}
+	}
+
+	changed := make(map[string][]string)
+	for _, file := range changedFiles {
+		rel, err := filepath.Rel(ws.RootPath, file)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			continue
+		}
+		rel = filepath.ToSlash(rel)
+
+		if strings.HasPrefix(file, changesetDir+string(filepath.Separator)) {
+			continue
+		}
+
+		if isAllowed(rel, allow) {
+			continue
+		}
+
+		project := ws.ProjectForPath(file)
+		if project == nil {
+			continue
+		}
+		changed[project.Name] = append(changed[project.Name], rel)
+	}
+
+	var missing []string
+	for name := range changed {
+		if !covered[name] {
+			missing = append(missing, name)
+		}
+	}
+	sort.Strings(missing)
+
+	out := cmd.OutOrStdout()
+	if len(missing) == 0 {
+		_, _ = fmt.Fprintf(out, "✓ All %d changed project(s) have a changeset\n", len(changed))
+		return nil
+	}
+
+	_, _ = fmt.Fprintf(out, "✗ Missing changesets since %s:\n", since)
+	for _, name := range missing {
+		_, _ = fmt.Fprintf(out, "  %s\n", name)
+		for _, file := range changed[name] {
+			_, _ = fmt.Fprintf(out, "    - %s\n", file)
+		}
+	}
+	_, _ = fmt.Fprintln(out)
+	_, _ = fmt.Fprintln(out, "Run 'changeset add' to describe these changes.")
+
+	return fmt.Errorf("%d project(s) changed without a changeset: %s", len(missing), strings.Join(missing, ", "))
+}
+
+// coverFromChangeset marks the projects of an added changeset file as covered.
+// Other files in the changeset directory are ignored.
+func (c *VerifyCommand) coverFromChangeset(ws *workspace.Workspace, csManager *changeset.Manager, path string, covered map[string]bool) error {
+	name := filepath.Base(path)
+	if !strings.HasSuffix(name, ".md") || strings.EqualFold(name, "README.md") {
+		return nil
+	}
+	if !c.fs.Exists(path) {
+		return nil
+	}
+
+	cs, err := csManager.Read(path)
+	if err != nil {
+		return fmt.Errorf("failed to read changeset %s: %w", name, err)
+	}
+	if err := validateChangesetTypes(ws, []*models.Changeset{cs}); err != nil {
+		return err
+	}
+
+	for project := range cs.Projects {
+		covered[project] = true
+	}
+	return nil
+}
+
+func isAllowed(path string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if pathglob.Match(pattern, path) {
+			return true
+		}
+	}
+	return false
+}
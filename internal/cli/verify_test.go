@@ -0,0 +1,134 @@
+package cli
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/jakoblorz/go-changesets/internal/git"
+	"github.com/jakoblorz/go-changesets/internal/workspace"
+	"github.com/stretchr/testify/require"
+)
+
+func setupVerify(t *testing.T) (*git.MockGitClient, *bytes.Buffer, func(args ...string) error) {
+	t.Helper()
+
+	_, fs := buildWorkspace(t, func(wb *workspace.WorkspaceBuilder) {
+		wb.AddProject("backend", "apps/backend", "github.com/example/backend")
+		wb.AddProject("shared", "packages/shared", "github.com/example/shared")
+		wb.AddChangeset("backend-fix", "backend", "patch", "Fix timeout")
+	})
+
+	gitMock := git.NewMockGitClient()
+	require.NoError(t, gitMock.CreateBranch("feature"))
+	require.NoError(t, gitMock.CheckoutBranch("feature"))
+
+	var out bytes.Buffer
+	run := func(args ...string) error {
+		cmd := NewVerifyCommand(fs, gitMock)
+		cmd.SetOut(&out)
+		cmd.SetArgs(args)
+		return cmd.Execute()
+	}
+
+	return gitMock, &out, run
+}
+
+func TestVerify_ChangedProjectsCovered(t *testing.T) {
+	gitMock, out, run := setupVerify(t)
+
+	commit := gitMock.CreateCommit("Fix timeout")
+	gitMock.AddCommitFiles(commit,
+		testWorkspaceRoot+"/apps/backend/server.go",
+		testWorkspaceRoot+"/.changeset/backend-fix.md",
+	)
+	gitMock.SetWorkingTreeChanges(
+		testWorkspaceRoot+"/packages/shared/util_test.go",
+		testWorkspaceRoot+"/packages/shared/README.md",
+		testWorkspaceRoot+"/go.work",
+	)
+
+	require.NoError(t, run("--since", "main"))
+	require.Contains(t, out.String(), "All 1 changed project(s) have a changeset")
+}
+
+func TestVerify_MissingChangeset(t *testing.T) {
+	gitMock, out, run := setupVerify(t)
+
+	commit := gitMock.CreateCommit("Touch shared")
+	gitMock.AddCommitFiles(commit,
+		testWorkspaceRoot+"/apps/backend/server.go",
+		testWorkspaceRoot+"/packages/shared/util.go",
+	)
+
+	// backend-fix.md exists but was not added in this range
+	err := run("--since", "main")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "2 project(s) changed without a changeset: backend, shared")
+	require.Contains(t, out.String(), "packages/shared/util.go")
+}
+
+func TestVerify_ModifiedChangesetDoesNotCount(t *testing.T) {
+	gitMock, _, run := setupVerify(t)
+
+	commit := gitMock.CreateCommit("Fix timeout")
+	gitMock.AddCommitFiles(commit,
+		testWorkspaceRoot+"/apps/backend/server.go",
+		testWorkspaceRoot+"/.changeset/backend-fix.md",
+	)
+	// backend-fix.md was only edited, it describes an earlier change
+	gitMock.SetBaseFiles(testWorkspaceRoot + "/.changeset/backend-fix.md")
+
+	err := run("--since", "main")
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "1 project(s) changed without a changeset: backend")
+}
+
+func TestVerify_MarkdownDocsRequireChangeset(t *testing.T) {
+	gitMock, out, run := setupVerify(t)
+
+	gitMock.SetWorkingTreeChanges(
+		testWorkspaceRoot+"/packages/shared/README.md",
+		testWorkspaceRoot+"/packages/shared/CHANGELOG.md",
+		testWorkspaceRoot+"/packages/shared/templates/welcome.md",
+	)
+
+	err := run("--since", "main")
+	require.Error(t, err)
+	require.Contains(t, out.String(), "packages/shared/templates/welcome.md")
+	require.NotContains(t, out.String(), "packages/shared/README.md")
+}
+
+func TestVerify_CustomAllowlist(t *testing.T) {
+	gitMock, _, run := setupVerify(t)
+
+	gitMock.SetWorkingTreeChanges(testWorkspaceRoot + "/packages/shared/examples/main.go")
+
+	require.Error(t, run("--since", "main"))
+	require.NoError(t, run("--since", "main", "--allow", "**/examples/**"))
+}
+
+func TestVerify_RequiresSince(t *testing.T) {
+	_, _, run := setupVerify(t)
+
+	err := run()
+	require.Error(t, err)
+	require.Contains(t, err.Error(), "--since is required")
+}
+
+func TestVerify_UnknownChangesetType(t *testing.T) {
+	_, fs := buildWorkspace(t, func(wb *workspace.WorkspaceBuilder) {
+		wb.AddProject("backend", "apps/backend", "github.com/example/backend")
+	})
+	fs.AddFile(testWorkspaceRoot+"/.changeset/typo.md", []byte("---\nbackend: {bump: patch, type: secuirty}\n---\n\nReject expired tokens\n"))
+
+	gitMock := git.NewMockGitClient()
+	gitMock.SetWorkingTreeChanges(
+		testWorkspaceRoot+"/apps/backend/auth.go",
+		testWorkspaceRoot+"/.changeset/typo.md",
+	)
+
+	cmd := NewVerifyCommand(fs, gitMock)
+	cmd.SetOut(&bytes.Buffer{})
+	cmd.SetArgs([]string{"--since", "main"})
+	require.ErrorContains(t, cmd.Execute(), `invalid changeset typo: backend: unknown change type "secuirty"`)
+}
@@ -2,6 +2,9 @@ package cli
 
 import (
 	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/jakoblorz/go-changesets/internal/changelog"
@@ -9,8 +12,9 @@ import (
 	"github.com/jakoblorz/go-changesets/internal/filesystem"
 	"github.com/jakoblorz/go-changesets/internal/git"
 	"github.com/jakoblorz/go-changesets/internal/github"
+	"github.com/jakoblorz/go-changesets/internal/gomod"
 	"github.com/jakoblorz/go-changesets/internal/models"
-	"github.com/jakoblorz/go-changesets/internal/versioning"
+	"github.com/jakoblorz/go-changesets/internal/workspace"
 	"github.com/spf13/cobra"
 )
 
@@ -32,13 +36,26 @@ func NewVersionCommand(fs filesystem.FileSystem, gitClient git.GitClient, ghClie
 	cobraCmd := &cobra.Command{
 		Use:   "version",
 		Short: "Version a project based on it's outstanding changesets",
-		Long:  `Applies all changesets for a project, updates version.txt or package.json, then edits project's CHANGELOG.md and the CHANGELOG.md in the root of the workspace.`,
-		RunE:  cmd.Run,
+		Long: `Applies all changesets for a project, updates the project's version stores (version.txt or package.json by default; none with tag-only versioning), then edits project's CHANGELOG.md and the CHANGELOG.md in the root of the workspace.
+
+Workspace projects that depend on the project (go.mod require, package.json
+dependencies/devDependencies) are versioned as well, with --dependent-bump or
+their own pending changesets, whichever is higher. Their changelog lists the
+new versions under "Updated Dependencies".
+
+With --all, every project with pending changesets is versioned in one
+operation. Changesets are only removed once all projects are written, a
+failure restores all written files, and the root CHANGELOG.md gets one
+combined entry.`,
+		RunE: cmd.Run,
 	}
 
-	cobraCmd.Flags().StringP("project", "p", "", "Project name to version (required unless run via 'changeset each')")
+	cobraCmd.Flags().StringP("project", "p", "", "Project name to version (required unless run via 'changeset each' or with --all)")
+	cobraCmd.Flags().Bool("all", false, "Version all projects with pending changesets in one operation")
 	cobraCmd.Flags().StringP("owner", "o", "", "GitHub repository owner (optional, enables PR links in changelog)")
 	cobraCmd.Flags().StringP("repo", "r", "", "GitHub repository name (optional, enables PR links in changelog)")
+	cobraCmd.Flags().Bool("no-module-path-migration", false, "Do not rewrite the Go module path (/vN suffix) and importers on major bumps to v2+")
+	cobraCmd.Flags().String("dependent-bump", "patch", "Bump applied to workspace projects depending on the versioned project: patch, minor, major or none")
 
 	return cobraCmd
 }
@@ -48,8 +65,24 @@ func (c *VersionCommand) Run(cmd *cobra.Command, args []string) error {
 	projectFlag, _ := cmd.Flags().GetString("project")
 	owner, _ := cmd.Flags().GetString("owner")
 	repo, _ := cmd.Flags().GetString("repo")
+	noMigration, _ := cmd.Flags().GetBool("no-module-path-migration")
+	dependentBumpFlag, _ := cmd.Flags().GetString("dependent-bump")
+
+	all, _ := cmd.Flags().GetBool("all")
 
-	resolved, err := resolveProject(c.fs, projectFlag, workspaceOptionsFromCmd(cmd)...)
+	dependentBump, err := parseDependentBump(dependentBumpFlag)
+	if err != nil {
+		return err
+	}
+
+	if all {
+		if projectFlag != "" {
+			return fmt.Errorf("--all cannot be combined with --project")
+		}
+		return c.runAll(cmd, owner, repo, dependentBump, noMigration)
+	}
+
+	resolved, err := resolveProject(c.fs, projectFlag, workspaceOptionsFromCmd(cmd, c.git)...)
 	if err != nil {
 		if projectFlag == "" {
 			return fmt.Errorf("--project flag required (or run via 'changeset each'): %w", err)
@@ -64,10 +97,11 @@ func (c *VersionCommand) Run(cmd *cobra.Command, args []string) error {
 	}
 
 	csManager := changeset.NewManager(c.fs, resolved.Workspace.ChangesetDir())
-	projectChangesets, err := csManager.ReadAllOfProject(resolved.Name)
+	allChangesets, err := csManager.ReadAll()
 	if err != nil {
 		return fmt.Errorf("failed to read changesets: %w", err)
 	}
+	projectChangesets := changeset.FilterByProject(allChangesets, resolved.Name)
 	if len(projectChangesets) == 0 {
 		fmt.Println("⚠️  No changesets found for this project")
 		return nil
@@ -79,71 +113,402 @@ func (c *VersionCommand) Run(cmd *cobra.Command, args []string) error {
 		fmt.Printf("  - %s (%s)\n", cs.ID, bump)
 	}
 
+	pre, err := csManager.ReadPreState()
+	if err != nil {
+		return fmt.Errorf("failed to read pre mode state: %w", err)
+	}
+
+	planner := newReleasePlanner(c.fs, c.git, resolved.Workspace).WithDependentBump(dependentBump)
+	releases, err := planner.PlanRelease(resolved.Project, allChangesets, pre)
+	if err != nil {
+		return err
+	}
+	if len(releases) == 0 {
+		fmt.Println("⚠️  No new changesets since the last prerelease")
+		return nil
+	}
+
 	if owner != "" && repo != "" {
-		if err := enrichChangesetsWithPRInfo(c.git, c.ghClient, projectChangesets, owner, repo, false); err != nil {
+		if err := enrichChangesetsWithPRInfo(c.git, c.ghClient, releasedChangesets(releases), owner, repo, false); err != nil {
 			return err
 		}
 	}
 
-	highestBump := csManager.GetHighestBump(projectChangesets, resolved.Name)
-	fmt.Printf("Highest bump type: %s\n\n", highestBump)
+	err = c.inTransaction(func(batch *VersionCommand) error {
+		return batch.applyProject(resolved.Workspace, pre, releases, noMigration)
+	})
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("\n🎉 Successfully versioned %s to %s\n", resolved.Name, releases[0].NextVersion.String())
+	for _, release := range releases[1:] {
+		fmt.Printf("   %s -> %s (%s)\n", release.Project.Name, release.NextVersion.String(), releaseReason(release))
+	}
+	return nil
+}
 
-	versionStore := versioning.NewVersionStore(c.fs, resolved.Project.Type)
-	currentVersion, err := versionStore.Read(resolved.Project.RootPath)
+// runAll versions all projects with pending changesets. All files are written
+// through a transaction that is rolled back if any project fails; changesets
+// are removed last.
+func (c *VersionCommand) runAll(cmd *cobra.Command, owner, repo string, dependentBump models.BumpType, noMigration bool) error {
+	ws := workspace.New(c.fs, workspaceOptionsFromCmd(cmd, c.git)...)
+	if err := ws.Detect(); err != nil {
+		return fmt.Errorf("failed to detect workspace: %w", err)
+	}
+
+	csManager := changeset.NewManager(c.fs, ws.ChangesetDir())
+	allChangesets, err := csManager.ReadAll()
 	if err != nil {
-		return fmt.Errorf("failed to read current version: %w", err)
+		return fmt.Errorf("failed to read changesets: %w", err)
 	}
 
-	fmt.Printf("Current version: %s\n", currentVersion.String())
+	pre, err := csManager.ReadPreState()
+	if err != nil {
+		return fmt.Errorf("failed to read pre mode state: %w", err)
+	}
 
-	newVersion := currentVersion.Bump(highestBump)
-	fmt.Printf("New version: %s\n\n", newVersion.String())
+	releases, err := newReleasePlanner(c.fs, c.git, ws).WithDependentBump(dependentBump).Plan(allChangesets)
+	if err != nil {
+		return err
+	}
+	if len(releases) == 0 {
+		fmt.Println("⚠️  No pending changesets")
+		return nil
+	}
 
-	if err := versionStore.Write(resolved.Project.RootPath, newVersion); err != nil {
-		return fmt.Errorf("failed to write version: %w", err)
+	if owner != "" && repo != "" {
+		if err := enrichChangesetsWithPRInfo(c.git, c.ghClient, releasedChangesets(releases), owner, repo, false); err != nil {
+			return err
+		}
 	}
 
-	if resolved.Project.Type == models.ProjectTypeNode {
-		fmt.Printf("✓ Updated %s/package.json\n", resolved.Project.RootPath)
-	} else {
-		fmt.Printf("✓ Updated %s/version.txt\n", resolved.Project.RootPath)
+	err = c.inTransaction(func(batch *VersionCommand) error {
+		return batch.applyAll(ws, pre, releases, noMigration)
+	})
+	if err != nil {
+		return err
 	}
 
-	cl := changelog.NewChangelog(c.fs)
-	entry := &changelog.Entry{
-		Version:    newVersion,
-		Date:       time.Now(),
-		Changesets: projectChangesets,
+	fmt.Printf("\n🎉 Successfully versioned %d project(s)\n", len(releases))
+	for _, release := range releases {
+		fmt.Printf("   %s -> %s\n", release.Project.Name, release.NextVersion.String())
+	}
+	return nil
+}
+
+// inTransaction runs apply on a copy of the command that writes through a
+// transaction, and rolls back every written file if apply fails.
+func (c *VersionCommand) inTransaction(apply func(batch *VersionCommand) error) error {
+	tx := filesystem.NewTransaction(c.fs)
+	batch := &VersionCommand{fs: tx, git: c.git, ghClient: c.ghClient}
+	if err := apply(batch); err != nil {
+		changed := len(tx.Changed())
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return fmt.Errorf("%w (rollback failed: %v)", err, rollbackErr)
+		}
+		fmt.Printf("\n↩️  Rolled back %d file(s)\n", changed)
+		return err
+	}
+	return nil
+}
+
+// applyProject writes the releases of a single project and its dependents,
+// with one root changelog entry each, then removes the consumed changesets or
+// records the prerelease.
+func (c *VersionCommand) applyProject(ws *workspace.Workspace, pre *models.PreState, releases []*projectRelease, noMigration bool) error {
+	date := time.Now()
+	for i, release := range releases {
+		if i > 0 {
+			fmt.Printf("\n📦 Versioning %s (%s)\n\n", release.Project.Name, releaseReason(release))
+		}
+		entry, err := c.applyRelease(ws, release, date, noMigration)
+		if err != nil {
+			return err
+		}
+		if err := c.appendRootChangelog(ws, release.Project, entry); err != nil {
+			return err
+		}
+	}
+
+	csManager := changeset.NewManager(c.fs, ws.ChangesetDir())
+	if pre != nil && pre.IsActive() {
+		return c.recordPrerelease(csManager, pre, releases)
 	}
 
-	if err := cl.Append(resolved.Project.RootPath, "", entry); err != nil {
-		return fmt.Errorf("failed to update changelog: %w", err)
+	c.removeChangesets(csManager, releases)
+	if pre != nil {
+		return c.finishPrerelease(csManager, pre, releases)
 	}
+	return nil
+}
 
-	fmt.Printf("✓ Updated %s/CHANGELOG.md\n\n", resolved.Project.RootPath)
+// applyAll writes the releases and one combined root changelog entry, then
+// removes the consumed changesets or records the prerelease.
+func (c *VersionCommand) applyAll(ws *workspace.Workspace, pre *models.PreState, releases []*projectRelease, noMigration bool) error {
+	date := time.Now()
+	var rootEntries []changelog.ProjectEntry
+	for _, release := range releases {
+		fmt.Printf("📦 Versioning %s\n\n", release.Project.Name)
 
-	if resolved.Workspace.RootPath != resolved.Project.RootPath {
-		rootEntry := &changelog.Entry{
-			Version:    newVersion,
-			Date:       entry.Date,
-			Changesets: projectChangesets,
+		entry, err := c.applyRelease(ws, release, date, noMigration)
+		if err != nil {
+			return fmt.Errorf("failed to version %s: %w", release.Project.Name, err)
+		}
+		if release.Project.RootPath != ws.RootPath {
+			rootEntries = append(rootEntries, changelog.ProjectEntry{Project: release.Project.Name, Entry: entry})
 		}
-		if err := cl.Append(resolved.Workspace.RootPath, resolved.Project.Name, rootEntry); err != nil {
+		fmt.Println()
+	}
+
+	if ws.Config.RootChangelog() && len(rootEntries) > 0 {
+		if err := newChangelog(c.fs, ws).AppendBatch(ws.RootPath, date, rootEntries); err != nil {
 			return fmt.Errorf("failed to update root changelog: %w", err)
 		}
+		fmt.Printf("✓ Updated ./CHANGELOG.md\n")
+	}
 
-		fmt.Printf("✓ Updated ./CHANGELOG.md\n\n")
+	csManager := changeset.NewManager(c.fs, ws.ChangesetDir())
+	if pre != nil && pre.IsActive() {
+		return c.recordPrerelease(csManager, pre, releases)
 	}
 
-	fmt.Println("Removing consumed changesets...")
-	for _, cs := range projectChangesets {
+	c.removeChangesets(csManager, releases)
+	if pre != nil {
+		return c.finishPrerelease(csManager, pre, releases)
+	}
+	return nil
+}
+
+// applyRelease writes the new version of a project and its changelog entry,
+// and returns the entry.
+func (c *VersionCommand) applyRelease(ws *workspace.Workspace, release *projectRelease, date time.Time, noMigration bool) (*changelog.Entry, error) {
+	project := release.Project
+
+	fmt.Printf("Highest bump type: %s\n\n", release.Bump)
+	fmt.Printf("Current version: %s\n", release.CurrentVersion.String())
+
+	newVersion := release.NextVersion
+	fmt.Printf("New version: %s\n\n", newVersion.String())
+
+	versionStore, err := projectVersionStore(c.fs, c.git, ws, project)
+	if err != nil {
+		return nil, err
+	}
+	if err := versionStore.Write(project.RootPath, newVersion); err != nil {
+		return nil, fmt.Errorf("failed to write version: %w", err)
+	}
+
+	for _, file := range versionStore.Files(project.RootPath) {
+		fmt.Printf("✓ Updated %s\n", file)
+	}
+
+	if !noMigration && needsModulePathMigration(release) {
+		if err := c.migrateModulePath(ws, release); err != nil {
+			return nil, err
+		}
+	}
+
+	dependencies := make([]changelog.Dependency, 0, len(release.Dependencies))
+	for _, dep := range release.Dependencies {
+		dependencies = append(dependencies, changelog.Dependency{Name: dep.Project.Name, Version: dep.NextVersion})
+	}
+
+	cl := newChangelog(c.fs, ws)
+	entry := &changelog.Entry{
+		Version:      newVersion,
+		Date:         date,
+		Changesets:   release.ChangelogChangesets(),
+		Dependencies: dependencies,
+	}
+
+	if err := cl.Append(project.RootPath, "", entry); err != nil {
+		return nil, fmt.Errorf("failed to update changelog: %w", err)
+	}
+
+	fmt.Printf("✓ Updated %s/CHANGELOG.md\n", project.RootPath)
+
+	return entry, nil
+}
+
+// appendRootChangelog adds the entry of a project to the CHANGELOG.md at the workspace root.
+func (c *VersionCommand) appendRootChangelog(ws *workspace.Workspace, project *models.Project, entry *changelog.Entry) error {
+	if ws.RootPath == project.RootPath || !ws.Config.RootChangelog() {
+		return nil
+	}
+
+	if err := newChangelog(c.fs, ws).Append(ws.RootPath, project.Name, entry); err != nil {
+		return fmt.Errorf("failed to update root changelog: %w", err)
+	}
+
+	fmt.Printf("✓ Updated ./CHANGELOG.md\n")
+	return nil
+}
+
+// removeChangesets removes the released projects from the changesets consumed
+// by the releases. A changeset that still lists other projects is kept for
+// their releases, and deleted once no projects are left.
+func (c *VersionCommand) removeChangesets(csManager *changeset.Manager, releases []*projectRelease) {
+	released := make(map[string]bool, len(releases))
+	for _, release := range releases {
+		released[release.Project.Name] = true
+	}
+
+	fmt.Println("\nRemoving consumed changesets...")
+	for _, cs := range releasedChangesets(releases) {
+		var removed []string
+		for name := range cs.Projects {
+			if released[name] {
+				removed = append(removed, name)
+				delete(cs.Projects, name)
+				delete(cs.ProjectTypes, name)
+			}
+		}
+		sort.Strings(removed)
+
+		if len(cs.Projects) > 0 {
+			// Another project still needs it for its own release
+			if err := csManager.Write(cs); err != nil {
+				fmt.Printf("⚠️  Warning: failed to update %s: %v\n", cs.ID, err)
+				continue
+			}
+			fmt.Printf("  ✓ Removed %s from %s.md\n", strings.Join(removed, ", "), cs.ID)
+			continue
+		}
 		if err := csManager.Delete(cs); err != nil {
 			fmt.Printf("⚠️  Warning: failed to delete %s: %v\n", cs.ID, err)
 			continue
 		}
 		fmt.Printf("  ✓ Removed %s.md\n", cs.ID)
 	}
+}
+
+// releasedChangesets returns the changesets of all releases without duplicates.
+func releasedChangesets(releases []*projectRelease) []*models.Changeset {
+	seen := make(map[string]bool)
+	var changesets []*models.Changeset
+	for _, release := range releases {
+		for _, cs := range release.Changesets {
+			if seen[cs.ID] {
+				continue
+			}
+			seen[cs.ID] = true
+			changesets = append(changesets, cs)
+		}
+	}
+	return changesets
+}
+
+// releaseReason describes why a project is released along with the requested one.
+func releaseReason(release *projectRelease) string {
+	if len(release.Dependencies) > 0 {
+		return "depends on " + strings.Join(dependencyNames(release), ", ")
+	}
+	if release.Project.Group != nil {
+		return fmt.Sprintf("%s group", release.Project.Group.Kind)
+	}
+	return "pending changesets"
+}
 
-	fmt.Printf("\n🎉 Successfully versioned %s to %s\n", resolved.Name, newVersion.String())
+func dependencyNames(release *projectRelease) []string {
+	names := make([]string, 0, len(release.Dependencies))
+	for _, dep := range release.Dependencies {
+		names = append(names, dep.Project.Name)
+	}
+	return names
+}
+
+// parseDependentBump parses the --dependent-bump flag; "none" disables propagation.
+func parseDependentBump(value string) (models.BumpType, error) {
+	if value == "none" {
+		return "", nil
+	}
+	bump, err := models.ParseBumpType(value)
+	if err != nil {
+		return "", fmt.Errorf("invalid --dependent-bump: %s (must be patch, minor, major or none)", value)
+	}
+	return bump, nil
+}
+
+// recordPrerelease keeps the changesets of a prerelease and marks them as consumed,
+// so the final release after 'changeset pre exit' can include all of them.
+func (c *VersionCommand) recordPrerelease(csManager *changeset.Manager, pre *models.PreState, releases []*projectRelease) error {
+	count := 0
+	for _, release := range releases {
+		if _, ok := pre.InitialVersions[release.Project.Name]; !ok {
+			pre.SetInitialVersion(release.Project.Name, release.CurrentVersion)
+		}
+
+		ids := make([]string, 0, len(release.Changesets))
+		for _, cs := range release.Changesets {
+			ids = append(ids, cs.ID)
+		}
+		pre.MarkConsumed(release.Project.Name, ids...)
+		count += len(ids)
+	}
+
+	if err := csManager.WritePreState(pre); err != nil {
+		return fmt.Errorf("failed to update pre mode state: %w", err)
+	}
+
+	fmt.Printf("\n✓ Recorded %d changeset(s) in .changeset/pre.json (kept until 'changeset pre exit')\n", count)
+	return nil
+}
+
+// finishPrerelease removes the released projects from the pre mode state after
+// their final release and deletes the state once every prereleased project is final.
+func (c *VersionCommand) finishPrerelease(csManager *changeset.Manager, pre *models.PreState, releases []*projectRelease) error {
+	for _, release := range releases {
+		pre.Finish(release.Project.Name)
+	}
+
+	if len(pre.PendingProjects()) == 0 {
+		if err := csManager.DeletePreState(); err != nil {
+			return fmt.Errorf("failed to remove pre mode state: %w", err)
+		}
+		fmt.Println("✓ Left pre mode (removed .changeset/pre.json)")
+		return nil
+	}
+
+	if err := csManager.WritePreState(pre); err != nil {
+		return fmt.Errorf("failed to update pre mode state: %w", err)
+	}
+	return nil
+}
+
+// needsModulePathMigration reports whether a major bump moves a Go module to v2 or later.
+func needsModulePathMigration(release *projectRelease) bool {
+	return release.Project.Type == models.ProjectTypeGo &&
+		release.Bump == models.BumpMajor &&
+		release.NextVersion.Major >= 2
+}
+
+// migrateModulePath adds the /vN suffix to the module path and rewrites importers in the workspace.
+func (c *VersionCommand) migrateModulePath(ws *workspace.Workspace, release *projectRelease) error {
+	newPath, err := gomod.MajorVersionPath(release.Project.ModulePath, release.NextVersion.Major)
+	if err != nil {
+		return fmt.Errorf("failed to compute module path for %s: %w (use --no-module-path-migration to skip)", release.Project.Name, err)
+	}
+	if newPath == release.Project.ModulePath {
+		return nil
+	}
+
+	moduleDirs, err := ws.GoModuleDirs()
+	if err != nil {
+		return err
+	}
+
+	migration, err := gomod.NewMigrator(c.fs).Migrate(release.Project.RootPath, newPath, release.NextVersion.Tag(), moduleDirs)
+	if err != nil {
+		return fmt.Errorf("failed to migrate module path: %w", err)
+	}
+
+	fmt.Printf("✓ Migrated module path %s -> %s\n", migration.OldPath, migration.NewPath)
+	for _, file := range migration.ChangedFiles {
+		if rel, err := filepath.Rel(ws.RootPath, file); err == nil {
+			file = rel
+		}
+		fmt.Printf("  - %s\n", file)
+	}
 	return nil
 }
@@ -0,0 +1,294 @@
+package cli
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jakoblorz/go-changesets/internal/filesystem"
+	"github.com/jakoblorz/go-changesets/internal/git"
+	"github.com/jakoblorz/go-changesets/internal/workspace"
+	"github.com/stretchr/testify/require"
+)
+
+func buildMajorBumpWorkspace(t *testing.T) *filesystem.MockFileSystem {
+	t.Helper()
+
+	_, fs := buildWorkspace(t, func(wb *workspace.WorkspaceBuilder) {
+		wb.AddProject("auth", "services/auth", "github.com/example/auth")
+		wb.AddProject("api", "services/api", "github.com/example/api")
+		wb.SetVersion("auth", "1.4.0")
+		wb.AddChangeset("drop-v1", "auth", "major", "Drop the v1 token format")
+	})
+
+	fs.AddFile(testWorkspaceRoot+"/services/api/go.mod", []byte(`module github.com/example/api
+
+go 1.24
+
+require github.com/example/auth v1.4.0
+`))
+	fs.AddFile(testWorkspaceRoot+"/services/api/main.go", []byte(`package main
+
+import "github.com/example/auth"
+
+func main() { auth.Run() }
+`))
+
+	return fs
+}
+
+func TestVersion_MajorBumpMigratesModulePath(t *testing.T) {
+	fs := buildMajorBumpWorkspace(t)
+
+	runVersion(t, fs, "auth")
+	requireVersion(t, fs, "services/auth", "2.0.0")
+
+	goMod, err := fs.ReadFile(testWorkspaceRoot + "/services/auth/go.mod")
+	require.NoError(t, err)
+	require.Equal(t, "module github.com/example/auth/v2\n\ngo 1.24\n", string(goMod))
+
+	apiMod, err := fs.ReadFile(testWorkspaceRoot + "/services/api/go.mod")
+	require.NoError(t, err)
+	require.Contains(t, string(apiMod), "require github.com/example/auth/v2 v2.0.0\n")
+
+	apiMain, err := fs.ReadFile(testWorkspaceRoot + "/services/api/main.go")
+	require.NoError(t, err)
+	require.Contains(t, string(apiMain), `import "github.com/example/auth/v2"`)
+
+	// The project keeps its name after the path change
+	ws := workspace.New(fs)
+	require.NoError(t, ws.Detect())
+	project, err := ws.GetProject("auth")
+	require.NoError(t, err)
+	require.Equal(t, "github.com/example/auth/v2", project.ModulePath)
+}
+
+func TestVersion_NoModulePathMigration(t *testing.T) {
+	fs := buildMajorBumpWorkspace(t)
+
+	cmd := NewVersionCommand(fs, git.NewMockGitClient(), nil)
+	cmd.SetArgs([]string{"--project", "auth", "--no-module-path-migration"})
+	require.NoError(t, cmd.Execute())
+	requireVersion(t, fs, "services/auth", "2.0.0")
+
+	goMod, err := fs.ReadFile(testWorkspaceRoot + "/services/auth/go.mod")
+	require.NoError(t, err)
+	require.Equal(t, "module github.com/example/auth\n\ngo 1.24\n", string(goMod))
+}
+
+func TestVersion_FailedMigrationRollsBack(t *testing.T) {
+	fs := buildMajorBumpWorkspace(t)
+	// An importer that cannot be parsed fails the migration after version.txt was written
+	fs.AddFile(testWorkspaceRoot+"/services/api/broken.go", []byte("package main\n\nimport (\n"))
+	before := make(map[string]string)
+	for path, file := range fs.GetFiles() {
+		before[path] = string(file.Content)
+	}
+
+	cmd := NewVersionCommand(fs, git.NewMockGitClient(), nil)
+	cmd.SetArgs([]string{"--project", "auth"})
+	require.ErrorContains(t, cmd.Execute(), "failed to migrate module path")
+
+	after := make(map[string]string)
+	for path, file := range fs.GetFiles() {
+		after[path] = string(file.Content)
+	}
+	require.Equal(t, before, after)
+}
+
+func TestVersion_UnknownChangesetType(t *testing.T) {
+	_, fs := buildWorkspace(t, func(wb *workspace.WorkspaceBuilder) {
+		wb.AddProject("auth", "services/auth", "github.com/example/auth")
+		wb.SetVersion("auth", "1.2.0")
+	})
+	fs.AddFile(testWorkspaceRoot+"/.changeset/config.json", []byte(`{"changelog": {"categories": [{"type": "feature", "title": "Features"}]}}`))
+	fs.AddFile(testWorkspaceRoot+"/.changeset/typo.md", []byte("---\ntype: feat\nauth: minor\n---\n\nAdd OAuth login\n"))
+
+	cmd := NewVersionCommand(fs, git.NewMockGitClient(), nil)
+	cmd.SetArgs([]string{"--project", "auth"})
+	require.ErrorContains(t, cmd.Execute(), `invalid changeset typo: unknown change type "feat" (available: feature)`)
+	requireVersion(t, fs, "services/auth", "1.2.0")
+}
+
+func TestVersion_MultiProjectChangesetOneProjectAtATime(t *testing.T) {
+	_, fs := buildWorkspace(t, func(wb *workspace.WorkspaceBuilder) {
+		wb.AddProject("a", "a", "github.com/example/a")
+		wb.AddProject("b", "b", "github.com/example/b")
+		wb.SetVersion("a", "1.0.0")
+		wb.SetVersion("b", "1.0.0")
+	})
+	fs.AddFile(testWorkspaceRoot+"/.changeset/multi.md", []byte("---\na: minor\nb: {bump: patch, type: fix}\n---\n\nShare the retry policy\n"))
+
+	// As run by 'changeset each --filter open-changesets -- changeset version'
+	runVersion(t, fs, "a")
+	requireVersion(t, fs, "a", "1.1.0")
+	data, err := fs.ReadFile(testWorkspaceRoot + "/.changeset/multi.md")
+	require.NoError(t, err)
+	require.Equal(t, "---\nb: {bump: patch, type: fix}\n---\n\nShare the retry policy\n", string(data))
+
+	runVersion(t, fs, "b")
+	requireVersion(t, fs, "b", "1.0.1")
+	require.False(t, fs.Exists(testWorkspaceRoot+"/.changeset/multi.md"))
+	require.Contains(t, changelogEntry(t, fs, "b", "1.0.1"), "Share the retry policy")
+}
+
+func buildDependentsWorkspace(t *testing.T) *filesystem.MockFileSystem {
+	t.Helper()
+
+	_, fs := buildWorkspace(t, func(wb *workspace.WorkspaceBuilder) {
+		wb.AddProject("shared", "shared", "github.com/example/shared")
+		wb.AddProject("backend", "backend", "github.com/example/backend")
+		wb.AddProject("www", "www", "github.com/example/www")
+		wb.AddProject("tools", "tools", "github.com/example/tools")
+		wb.AddDependency("backend", "github.com/example/shared")
+		wb.AddDependency("www", "github.com/example/backend")
+		wb.SetVersion("shared", "1.2.0")
+		wb.SetVersion("backend", "1.0.0")
+		wb.SetVersion("www", "0.4.0")
+		wb.SetVersion("tools", "0.1.0")
+		wb.AddChangeset("shared-feature", "shared", "minor", "Add retry helpers")
+	})
+	return fs
+}
+
+func TestVersion_BumpsDependents(t *testing.T) {
+	fs := buildDependentsWorkspace(t)
+
+	runVersion(t, fs, "shared")
+
+	requireVersion(t, fs, "shared", "1.3.0")
+	requireVersion(t, fs, "backend", "1.0.1")
+	requireVersion(t, fs, "www", "0.4.1")
+	requireVersion(t, fs, "tools", "0.1.0")
+
+	backendEntry := changelogEntry(t, fs, "backend", "1.0.1")
+	require.Contains(t, backendEntry, "### Updated Dependencies\n- shared@1.3.0\n")
+
+	wwwEntry := changelogEntry(t, fs, "www", "0.4.1")
+	require.Contains(t, wwwEntry, "### Updated Dependencies\n- backend@1.0.1\n")
+
+	rootChangelog, err := fs.ReadFile(testWorkspaceRoot + "/CHANGELOG.md")
+	require.NoError(t, err)
+	require.Contains(t, string(rootChangelog), "## backend@1.0.1")
+	require.NotContains(t, string(rootChangelog), "## tools@")
+}
+
+func TestVersion_DependentIncludesOwnChangesets(t *testing.T) {
+	fs := buildDependentsWorkspace(t)
+	fs.AddFile(testWorkspaceRoot+"/.changeset/backend-feature.md", []byte("---\nbackend: minor\n---\n\nAdd health endpoint\n"))
+
+	runVersion(t, fs, "shared")
+
+	requireVersion(t, fs, "backend", "1.1.0")
+	entry := changelogEntry(t, fs, "backend", "1.1.0")
+	require.Contains(t, entry, "### Minor Changes\n- Add health endpoint\n")
+	require.Contains(t, entry, "### Updated Dependencies\n- shared@1.3.0\n")
+	require.False(t, fs.Exists(testWorkspaceRoot+"/.changeset/backend-feature.md"))
+}
+
+func TestVersion_DependentBumpFlag(t *testing.T) {
+	fs := buildDependentsWorkspace(t)
+
+	cmd := NewVersionCommand(fs, git.NewMockGitClient(), nil)
+	cmd.SetArgs([]string{"--project", "shared", "--dependent-bump", "minor"})
+	require.NoError(t, cmd.Execute())
+	requireVersion(t, fs, "backend", "1.1.0")
+	requireVersion(t, fs, "www", "0.5.0")
+
+	fs = buildDependentsWorkspace(t)
+	cmd = NewVersionCommand(fs, git.NewMockGitClient(), nil)
+	cmd.SetArgs([]string{"--project", "shared", "--dependent-bump", "none"})
+	require.NoError(t, cmd.Execute())
+	requireVersion(t, fs, "shared", "1.3.0")
+	requireVersion(t, fs, "backend", "1.0.0")
+
+	cmd = NewVersionCommand(fs, git.NewMockGitClient(), nil)
+	cmd.SetArgs([]string{"--project", "shared", "--dependent-bump", "huge"})
+	require.ErrorContains(t, cmd.Execute(), "invalid --dependent-bump")
+}
+
+func buildVersionAllWorkspace(t *testing.T) *filesystem.MockFileSystem {
+	t.Helper()
+
+	fs := buildDependentsWorkspace(t)
+	fs.AddFile(testWorkspaceRoot+"/.changeset/shared-tools.md", []byte("---\nshared: patch\ntools: minor\n---\n\nAdd structured logging\n"))
+	fs.AddFile(testWorkspaceRoot+"/CHANGELOG.md", []byte("# Changelog\n\n## tools@0.1.0 (2024-01-01)\n"))
+	return fs
+}
+
+func TestVersion_All(t *testing.T) {
+	fs := buildVersionAllWorkspace(t)
+
+	cmd := NewVersionCommand(fs, git.NewMockGitClient(), nil)
+	cmd.SetArgs([]string{"--all"})
+	require.NoError(t, cmd.Execute())
+
+	requireVersion(t, fs, "shared", "1.3.0")
+	requireVersion(t, fs, "backend", "1.0.1")
+	requireVersion(t, fs, "www", "0.4.1")
+	requireVersion(t, fs, "tools", "0.2.0")
+
+	// The multi-project changeset reaches every project before it is removed
+	require.Contains(t, changelogEntry(t, fs, "shared", "1.3.0"), "Add structured logging")
+	require.Contains(t, changelogEntry(t, fs, "tools", "0.2.0"), "Add structured logging")
+	require.False(t, fs.Exists(testWorkspaceRoot+"/.changeset/shared-tools.md"))
+	require.False(t, fs.Exists(testWorkspaceRoot+"/.changeset/shared-feature.md"))
+
+	root, err := fs.ReadFile(testWorkspaceRoot + "/CHANGELOG.md")
+	require.NoError(t, err)
+	require.Equal(t, 2, strings.Count(string(root), "\n## "))
+	require.Regexp(t, `(?s)^# Changelog\n\n## \d{4}-\d{2}-\d{2}\n\n### shared@1\.3\.0 .*### backend@1\.0\.1 .*### www@0\.4\.1 .*### tools@0\.2\.0 .*## tools@0\.1\.0`, string(root))
+}
+
+func TestVersion_AllRollsBackOnFailure(t *testing.T) {
+	fs := buildVersionAllWorkspace(t)
+	// The last project's changelog cannot be rendered
+	fs.AddFile(testWorkspaceRoot+"/tools/.changeset/changelog.tmpl", []byte("{{.Broken"))
+	before := make(map[string]string)
+	for path, file := range fs.GetFiles() {
+		before[path] = string(file.Content)
+	}
+
+	cmd := NewVersionCommand(fs, git.NewMockGitClient(), nil)
+	cmd.SetArgs([]string{"--all"})
+	require.ErrorContains(t, cmd.Execute(), "failed to version tools")
+
+	after := make(map[string]string)
+	for path, file := range fs.GetFiles() {
+		after[path] = string(file.Content)
+	}
+	require.Equal(t, before, after)
+}
+
+func TestVersion_AllWithProject(t *testing.T) {
+	fs := buildVersionAllWorkspace(t)
+
+	cmd := NewVersionCommand(fs, git.NewMockGitClient(), nil)
+	cmd.SetArgs([]string{"--all", "--project", "shared"})
+	require.ErrorContains(t, cmd.Execute(), "--all cannot be combined with --project")
+}
+
+func TestVersion_ConfiguredVersionStores(t *testing.T) {
+	_, fs := buildWorkspace(t, func(wb *workspace.WorkspaceBuilder) {
+		wb.AddProject("auth", "services/auth", "github.com/example/auth")
+		wb.AddChangeset("add-oauth", "auth", "minor", "Add OAuth support")
+	})
+	addConfig(fs, `{"projects": {"auth": {"versionStores": [
+		{"type": "go-const", "file": "internal/buildinfo/version.go"},
+		{"type": "yaml", "file": "deploy/Chart.yaml", "key": "appVersion"}
+	]}}}`)
+	fs.AddFile(testWorkspaceRoot+"/services/auth/internal/buildinfo/version.go", []byte("package buildinfo\n\nconst Version = \"1.2.0\"\n"))
+	fs.AddFile(testWorkspaceRoot+"/services/auth/deploy/Chart.yaml", []byte("name: auth\nversion: 0.1.0\nappVersion: \"1.2.0\"\n"))
+
+	require.NoError(t, executeRoot(fs, "version", "--project", "auth"))
+
+	goSource, err := fs.ReadFile(testWorkspaceRoot + "/services/auth/internal/buildinfo/version.go")
+	require.NoError(t, err)
+	require.Equal(t, "package buildinfo\n\nconst Version = \"1.3.0\"\n", string(goSource))
+
+	chart, err := fs.ReadFile(testWorkspaceRoot + "/services/auth/deploy/Chart.yaml")
+	require.NoError(t, err)
+	require.Equal(t, "name: auth\nversion: 0.1.0\nappVersion: \"1.3.0\"\n", string(chart))
+
+	require.False(t, fs.Exists(testWorkspaceRoot+"/services/auth/version.txt"))
+	require.Contains(t, changelogEntry(t, fs, "services/auth", "1.3.0"), "Add OAuth support")
+}
@@ -2,17 +2,33 @@ package cli
 
 import (
 	"strconv"
+	"strings"
 
+	"github.com/jakoblorz/go-changesets/internal/git"
+	"github.com/jakoblorz/go-changesets/internal/models"
 	"github.com/jakoblorz/go-changesets/internal/workspace"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
-const nodeStrictWorkspaceFlag = "node-strict-workspace"
+const (
+	nodeStrictWorkspaceFlag = "node-strict-workspace"
+	tagSchemeFlag           = "tag-scheme"
+)
 
-func workspaceOptionsFromCmd(cmd *cobra.Command) []workspace.Option {
+// workspaceOptionsFromCmd returns the workspace options of the command's
+// flags. gitClient, if not nil, locates the repository root for module tags.
+func workspaceOptionsFromCmd(cmd *cobra.Command, gitClient git.GitClient) []workspace.Option {
 	var opts []workspace.Option
-	if nodeStrictWorkspace(cmd) {
-		opts = append(opts, workspace.WithNodeStrictWorkspace(true))
+	if gitClient != nil {
+		opts = append(opts, workspace.WithGitClient(gitClient))
+	}
+	if enabled, set := nodeStrictWorkspace(cmd); set {
+		opts = append(opts, workspace.WithNodeStrictWorkspace(enabled))
+	}
+
+	if scheme, projectSchemes := tagSchemes(cmd); scheme != "" || len(projectSchemes) > 0 {
+		opts = append(opts, workspace.WithTagScheme(scheme, projectSchemes))
 	}
 
 	if cmd != nil {
@@ -22,20 +38,53 @@ func workspaceOptionsFromCmd(cmd *cobra.Command) []workspace.Option {
 	return opts
 }
 
-func nodeStrictWorkspace(cmd *cobra.Command) bool {
+// nodeStrictWorkspace returns the --node-strict-workspace value and whether
+// it was set, so that an unset flag leaves the config default in place.
+func nodeStrictWorkspace(cmd *cobra.Command) (bool, bool) {
 	if cmd == nil {
-		return false
+		return false, false
 	}
 
 	flag := cmd.Flag(nodeStrictWorkspaceFlag)
 	if flag == nil {
-		return false
+		return false, false
 	}
 
 	enabled, err := strconv.ParseBool(flag.Value.String())
 	if err != nil {
-		return false
+		return false, false
+	}
+
+	return enabled, enabled || flag.Changed
+}
+
+// tagSchemes reads --tag-scheme values: "module" sets the default scheme and
+// "name=module" the scheme of a single project. Values are validated by the
+// workspace on Detect.
+func tagSchemes(cmd *cobra.Command) (models.TagScheme, map[string]models.TagScheme) {
+	if cmd == nil {
+		return "", nil
+	}
+
+	flag := cmd.Flag(tagSchemeFlag)
+	if flag == nil {
+		return "", nil
+	}
+
+	slice, ok := flag.Value.(pflag.SliceValue)
+	if !ok {
+		return "", nil
+	}
+
+	var scheme models.TagScheme
+	projectSchemes := make(map[string]models.TagScheme)
+	for _, value := range slice.GetSlice() {
+		if name, projectScheme, found := strings.Cut(value, "="); found {
+			projectSchemes[name] = models.TagScheme(projectScheme)
+			continue
+		}
+		scheme = models.TagScheme(value)
 	}
 
-	return enabled
+	return scheme, projectSchemes
 }
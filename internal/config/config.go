@@ -0,0 +1,396 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/jakoblorz/go-changesets/internal/filesystem"
+	"github.com/jakoblorz/go-changesets/internal/models"
+	"github.com/jakoblorz/go-changesets/internal/versioning"
+)
+
+// FileName is the name of the config file inside the .changeset directory.
+const FileName = "config.json"
+
+// Config holds repository-wide settings from .changeset/config.json.
+// Flags and environment variables take precedence over these values.
+type Config struct {
+	// GitHub is the default repository for --owner and --repo
+	GitHub GitHubConfig `json:"github"`
+
+	// Ignore lists projects (names or globs) excluded from the workspace
+	Ignore []string `json:"ignore"`
+
+	// NodeStrictWorkspace is the default for --node-strict-workspace
+	NodeStrictWorkspace bool `json:"nodeStrictWorkspace"`
+
+	// TagScheme is the default release tag scheme of all projects
+	TagScheme models.TagScheme `json:"tagScheme"`
+
+	// VersionStores are the default version stores of projects that do not
+	// configure their own, e.g. [{"type": "tag"}] for tag-only versioning
+	VersionStores []models.VersionStoreSpec `json:"versionStores"`
+
+	// Projects holds per-project settings by project name
+	Projects map[string]ProjectConfig `json:"projects"`
+
+	// Fixed lists groups of projects (names or globs) that always share a version
+	Fixed [][]string `json:"fixed"`
+
+	// Linked lists groups of projects (names or globs) that share the highest
+	// bump of the members released together
+	Linked [][]string `json:"linked"`
+
+	Changelog ChangelogConfig `json:"changelog"`
+	ReleasePR ReleasePRConfig `json:"releasePR"`
+
+	// Flags sets flag defaults per command, keyed by the command path below
+	// the root command, e.g. {"version": {"dependent-bump": "minor"}}
+	Flags map[string]map[string]any `json:"flags"`
+
+	// Path is the file the config was loaded from
+	Path string `json:"-"`
+}
+
+// GitHubConfig identifies the GitHub repository.
+type GitHubConfig struct {
+	Owner string `json:"owner"`
+	Repo  string `json:"repo"`
+}
+
+// ProjectConfig holds settings of a single project.
+type ProjectConfig struct {
+	TagScheme models.TagScheme `json:"tagScheme"`
+
+	// VersionStores are the files holding the project's version. The first
+	// one is read from, all of them are written.
+	VersionStores []models.VersionStoreSpec `json:"versionStores"`
+}
+
+// ChangelogConfig configures CHANGELOG.md generation.
+type ChangelogConfig struct {
+	// Template is the changelog template, relative to the workspace root.
+	// Defaults to the nearest .changeset/changelog.tmpl.
+	Template string `json:"template"`
+
+	// Root controls the combined CHANGELOG.md at the workspace root (default true)
+	Root *bool `json:"root"`
+
+	// Categories are the change types changesets can declare, in changelog
+	// order. Defaults to feat, fix, security and deprecation.
+	Categories []models.Category `json:"categories"`
+}
+
+// ReleasePRConfig configures 'changeset gh pr'.
+type ReleasePRConfig struct {
+	Base        string   `json:"base"`
+	Labels      []string `json:"labels"`
+	MappingFile string   `json:"mappingFile"`
+	TreeFile    string   `json:"treeFile"`
+
+	// TitleTemplate and BodyTemplate are relative to the workspace root.
+	// They default to the nearest .changeset/pr-title.tmpl and .changeset/pr-description.tmpl.
+	TitleTemplate string `json:"titleTemplate"`
+	BodyTemplate  string `json:"bodyTemplate"`
+}
+
+// ValidationError is returned for a config file that cannot be used.
+type ValidationError struct {
+	Path string
+	Err  error
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s: %v", e.Path, e.Err)
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// Load reads the config from changesetDir. It returns nil if there is no config file.
+func Load(fsys filesystem.FileSystem, changesetDir string) (*Config, error) {
+	path := filepath.Join(changesetDir, FileName)
+	data, err := fsys.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	cfg, err := Parse(data)
+	if err != nil {
+		return nil, &ValidationError{Path: path, Err: err}
+	}
+	cfg.Path = path
+	return cfg, nil
+}
+
+// Parse decodes and validates a config. Unknown keys are rejected.
+func Parse(data []byte) (*Config, error) {
+	var raw any
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return nil, err
+	}
+	if err := checkKeys(raw, reflect.TypeOf(Config{}), ""); err != nil {
+		return nil, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+
+	var cfg Config
+	if err := decoder.Decode(&cfg); err != nil {
+		return nil, describeDecodeError(err)
+	}
+	if err := decoder.Decode(&struct{}{}); err != io.EOF {
+		return nil, fmt.Errorf("unexpected content after the config object")
+	}
+
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+func (c *Config) validate() error {
+	if c.TagScheme != "" {
+		if _, err := models.ParseTagScheme(string(c.TagScheme)); err != nil {
+			return fmt.Errorf("tagScheme: %w", err)
+		}
+	}
+
+	for i, spec := range c.VersionStores {
+		if err := versioning.ValidateStoreSpec(spec); err != nil {
+			return fmt.Errorf("versionStores[%d]: %w", i, err)
+		}
+	}
+
+	for _, name := range sortedKeys(c.Projects) {
+		project := c.Projects[name]
+		if project.TagScheme != "" {
+			if _, err := models.ParseTagScheme(string(project.TagScheme)); err != nil {
+				return fmt.Errorf("projects.%s.tagScheme: %w", name, err)
+			}
+		}
+		for i, spec := range project.VersionStores {
+			if err := versioning.ValidateStoreSpec(spec); err != nil {
+				return fmt.Errorf("projects.%s.versionStores[%d]: %w", name, i, err)
+			}
+		}
+	}
+
+	for i, group := range c.Fixed {
+		if len(group) == 0 {
+			return fmt.Errorf("fixed[%d] is empty", i)
+		}
+	}
+	for i, group := range c.Linked {
+		if len(group) == 0 {
+			return fmt.Errorf("linked[%d] is empty", i)
+		}
+	}
+
+	seenCategories := make(map[string]bool)
+	for i, category := range c.Changelog.Categories {
+		if category.Type == "" {
+			return fmt.Errorf("changelog.categories[%d].type is required", i)
+		}
+		if category.Title == "" {
+			return fmt.Errorf("changelog.categories[%d].title is required", i)
+		}
+		if seenCategories[category.Type] {
+			return fmt.Errorf("changelog.categories[%d]: duplicate type %q", i, category.Type)
+		}
+		seenCategories[category.Type] = true
+	}
+
+	for _, command := range sortedKeys(c.Flags) {
+		for _, flag := range sortedKeys(c.Flags[command]) {
+			if _, err := FlagValues(c.Flags[command][flag]); err != nil {
+				return fmt.Errorf("flags.%s.%s: %w", command, flag, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// RootChangelog reports whether the combined root CHANGELOG.md is written.
+func (c *Config) RootChangelog() bool {
+	if c == nil || c.Changelog.Root == nil {
+		return true
+	}
+	return *c.Changelog.Root
+}
+
+// Categories returns the configured change categories, or the default ones.
+func (c *Config) Categories() []models.Category {
+	if c == nil || c.Changelog.Categories == nil {
+		return models.DefaultCategories
+	}
+	return c.Changelog.Categories
+}
+
+// IsIgnored reports whether the project is excluded by the ignore list.
+func (c *Config) IsIgnored(projectName string) bool {
+	if c == nil {
+		return false
+	}
+	for _, pattern := range c.Ignore {
+		if matched, err := filepath.Match(pattern, projectName); err == nil && matched {
+			return true
+		}
+	}
+	return false
+}
+
+// FlagDefaults returns the configured defaults for the flags of a command,
+// keyed by flag name. commandPath is the command path below the root command,
+// e.g. "version" or "gh pr open". Values from the flags section take
+// precedence over the dedicated sections (github, releasePR).
+func (c *Config) FlagDefaults(commandPath string) map[string][]string {
+	if c == nil {
+		return nil
+	}
+
+	defaults := make(map[string][]string)
+	set := func(name string, values ...string) {
+		if len(values) == 0 || (len(values) == 1 && values[0] == "") {
+			return
+		}
+		defaults[name] = values
+	}
+
+	set("owner", c.GitHub.Owner)
+	set("repo", c.GitHub.Repo)
+
+	if strings.HasPrefix(commandPath, "gh ") {
+		set("base", c.ReleasePR.Base)
+		if len(c.ReleasePR.Labels) > 0 {
+			set("labels", strings.Join(c.ReleasePR.Labels, ","))
+		}
+		set("mapping-file", c.ReleasePR.MappingFile)
+		set("tree-file", c.ReleasePR.TreeFile)
+	}
+
+	for flag, value := range c.Flags[commandPath] {
+		values, _ := FlagValues(value)
+		defaults[flag] = values
+	}
+
+	return defaults
+}
+
+// FlagValues converts a JSON flag value to flag arguments. Arrays become one
+// argument per element, for repeatable flags.
+func FlagValues(value any) ([]string, error) {
+	switch v := value.(type) {
+	case string:
+		return []string{v}, nil
+	case bool, float64:
+		return []string{fmt.Sprint(v)}, nil
+	case []any:
+		values := make([]string, 0, len(v))
+		for _, item := range v {
+			switch item.(type) {
+			case string, bool, float64:
+				values = append(values, fmt.Sprint(item))
+			default:
+				return nil, fmt.Errorf("array items must be strings, numbers or booleans")
+			}
+		}
+		return values, nil
+	default:
+		return nil, fmt.Errorf("value must be a string, number, boolean or array")
+	}
+}
+
+// checkKeys rejects object keys that have no matching field in t, naming the
+// full key path and the known keys.
+func checkKeys(value any, t reflect.Type, path string) error {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	if items, ok := value.([]any); ok && t.Kind() == reflect.Slice {
+		for i, item := range items {
+			if err := checkKeys(item, t.Elem(), fmt.Sprintf("%s[%d]", path, i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	object, ok := value.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	switch t.Kind() {
+	case reflect.Map:
+		for _, key := range sortedKeys(object) {
+			if err := checkKeys(object[key], t.Elem(), joinKey(path, key)); err != nil {
+				return err
+			}
+		}
+	case reflect.Struct:
+		fields := make(map[string]reflect.Type)
+		var known []string
+		for i := 0; i < t.NumField(); i++ {
+			name, _, _ := strings.Cut(t.Field(i).Tag.Get("json"), ",")
+			if name == "" || name == "-" {
+				continue
+			}
+			fields[name] = t.Field(i).Type
+			known = append(known, name)
+		}
+
+		for _, key := range sortedKeys(object) {
+			fieldType, ok := fields[key]
+			if !ok {
+				return fmt.Errorf("unknown key %q (known keys: %s)", joinKey(path, key), strings.Join(known, ", "))
+			}
+			if err := checkKeys(object[key], fieldType, joinKey(path, key)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func joinKey(path, key string) string {
+	if path == "" {
+		return key
+	}
+	return path + "." + key
+}
+
+// describeDecodeError names the offending key of type errors.
+func describeDecodeError(err error) error {
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		return fmt.Errorf("%s: expected %s, got %s", typeErr.Field, typeErr.Type, typeErr.Value)
+	}
+
+	return err
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
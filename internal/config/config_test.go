@@ -0,0 +1,151 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/jakoblorz/go-changesets/internal/filesystem"
+	"github.com/jakoblorz/go-changesets/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	cfg, err := Parse([]byte(`{
+		"github": {"owner": "acme", "repo": "mono"},
+		"ignore": ["internal-*"],
+		"tagScheme": "module",
+		"projects": {"legacy": {"tagScheme": "project"}},
+		"changelog": {"template": "tools/changelog.tmpl", "root": false},
+		"releasePR": {"base": "develop", "labels": ["release"]},
+		"flags": {"version": {"dependent-bump": "minor"}}
+	}`))
+	require.NoError(t, err)
+
+	require.Equal(t, "acme", cfg.GitHub.Owner)
+	require.Equal(t, models.TagSchemeModule, cfg.TagScheme)
+	require.Equal(t, models.TagSchemeProject, cfg.Projects["legacy"].TagScheme)
+	require.Equal(t, "tools/changelog.tmpl", cfg.Changelog.Template)
+	require.False(t, cfg.RootChangelog())
+	require.Equal(t, "develop", cfg.ReleasePR.Base)
+}
+
+func TestParse_UnknownKey(t *testing.T) {
+	_, err := Parse([]byte(`{"github": {"ownr": "acme"}}`))
+	require.EqualError(t, err, `unknown key "github.ownr" (known keys: owner, repo)`)
+
+	_, err = Parse([]byte(`{"projects": {"backend": {"tagscheme": "module"}}}`))
+	require.ErrorContains(t, err, `unknown key "projects.backend.tagscheme"`)
+}
+
+func TestParse_Invalid(t *testing.T) {
+	_, err := Parse([]byte(`{"tagScheme": "calver"}`))
+	require.ErrorContains(t, err, "tagScheme: invalid tag scheme: calver")
+
+	_, err = Parse([]byte(`{"ignore": "backend"}`))
+	require.ErrorContains(t, err, "ignore: expected []string, got string")
+
+	_, err = Parse([]byte(`{"flags": {"version": {"dependent-bump": {"kind": "minor"}}}}`))
+	require.ErrorContains(t, err, "flags.version.dependent-bump")
+}
+
+func TestLoad(t *testing.T) {
+	fs := filesystem.NewMockFileSystem()
+
+	cfg, err := Load(fs, "/workspace/.changeset")
+	require.NoError(t, err)
+	require.Nil(t, cfg)
+	require.True(t, cfg.RootChangelog())
+
+	fs.AddFile("/workspace/.changeset/config.json", []byte(`{"ignroe": []}`))
+	_, err = Load(fs, "/workspace/.changeset")
+	var validationErr *ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	require.ErrorContains(t, err, "invalid /workspace/.changeset/config.json: unknown key \"ignroe\"")
+}
+
+func TestConfig_FlagDefaults(t *testing.T) {
+	cfg, err := Parse([]byte(`{
+		"github": {"owner": "acme", "repo": "mono"},
+		"releasePR": {"base": "develop", "labels": ["release", "bot"]},
+		"flags": {
+			"gh pr open": {"base": "trunk"},
+			"each": {"filter": ["open-changesets", "outdated-versions"]}
+		}
+	}`))
+	require.NoError(t, err)
+
+	require.Equal(t, map[string][]string{
+		"owner":  {"acme"},
+		"repo":   {"mono"},
+		"base":   {"trunk"},
+		"labels": {"release,bot"},
+	}, cfg.FlagDefaults("gh pr open"))
+
+	defaults := cfg.FlagDefaults("each")
+	require.Equal(t, []string{"open-changesets", "outdated-versions"}, defaults["filter"])
+	require.NotContains(t, defaults, "base")
+}
+
+func TestConfig_IsIgnored(t *testing.T) {
+	cfg := &Config{Ignore: []string{"legacy", "example-*"}}
+
+	require.True(t, cfg.IsIgnored("legacy"))
+	require.True(t, cfg.IsIgnored("example-web"))
+	require.False(t, cfg.IsIgnored("backend"))
+}
+
+func TestParse_VersionGroups(t *testing.T) {
+	cfg, err := Parse([]byte(`{"fixed": [["api-client", "api-server"]], "linked": [["web", "cli"]]}`))
+	require.NoError(t, err)
+	require.Equal(t, [][]string{{"api-client", "api-server"}}, cfg.Fixed)
+	require.Equal(t, [][]string{{"web", "cli"}}, cfg.Linked)
+
+	_, err = Parse([]byte(`{"linked": [[]]}`))
+	require.EqualError(t, err, "linked[0] is empty")
+}
+
+func TestParse_VersionStores(t *testing.T) {
+	cfg, err := Parse([]byte(`{"projects": {"auth": {"versionStores": [
+		{"type": "version-file", "file": "VERSION"},
+		{"type": "yaml", "file": "openapi.yaml", "key": "info.version"}
+	]}}}`))
+	require.NoError(t, err)
+	require.Equal(t, []models.VersionStoreSpec{
+		{Type: "version-file", File: "VERSION"},
+		{Type: "yaml", File: "openapi.yaml", Key: "info.version"},
+	}, cfg.Projects["auth"].VersionStores)
+
+	cfg, err = Parse([]byte(`{"versionStores": [{"type": "tag"}]}`))
+	require.NoError(t, err)
+	require.Equal(t, []models.VersionStoreSpec{{Type: "tag"}}, cfg.VersionStores)
+
+	_, err = Parse([]byte(`{"versionStores": [{"type": "tag", "file": "VERSION"}]}`))
+	require.EqualError(t, err, "versionStores[0]: tag: file and key are not supported")
+
+	_, err = Parse([]byte(`{"projects": {"auth": {"versionStores": [{"type": "go-const", "file": "v.go", "const": "Version"}]}}}`))
+	require.ErrorContains(t, err, `unknown key "projects.auth.versionStores[0].const" (known keys: type, file, key)`)
+
+	_, err = Parse([]byte(`{"projects": {"auth": {"versionStores": [{"type": "version-file"}, {"type": "json", "file": "app.json"}]}}}`))
+	require.EqualError(t, err, "projects.auth.versionStores[1]: json: key is required")
+}
+
+func TestParse_Categories(t *testing.T) {
+	cfg, err := Parse([]byte(`{}`))
+	require.NoError(t, err)
+	require.Equal(t, models.DefaultCategories, cfg.Categories())
+
+	cfg, err = Parse([]byte(`{"changelog": {"categories": [
+		{"type": "security", "title": "Security Fixes"},
+		{"type": "feat", "title": "New Features"}
+	]}}`))
+	require.NoError(t, err)
+	require.Equal(t, []models.Category{
+		{Type: "security", Title: "Security Fixes"},
+		{Type: "feat", Title: "New Features"},
+	}, cfg.Categories())
+
+	_, err = Parse([]byte(`{"changelog": {"categories": [{"type": "feat", "title": "A"}, {"type": "feat", "title": "B"}]}}`))
+	require.EqualError(t, err, `changelog.categories[1]: duplicate type "feat"`)
+
+	_, err = Parse([]byte(`{"changelog": {"categories": [{"type": "feat"}]}}`))
+	require.EqualError(t, err, "changelog.categories[0].title is required")
+}
@@ -0,0 +1,110 @@
+package conventional
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/jakoblorz/go-changesets/internal/models"
+)
+
+// headerPattern matches "type(scope)!: description"
+var headerPattern = regexp.MustCompile(`^([a-zA-Z]+)(?:\(([^()]*)\))?(!)?: (.+)$`)
+
+// footerPattern matches a git trailer such as "Refs: #123" or "BREAKING CHANGE: ..."
+var footerPattern = regexp.MustCompile(`^(BREAKING CHANGE|BREAKING-CHANGE|[A-Za-z][A-Za-z-]*)(?:: | #)(.*)$`)
+
+// Commit is a parsed Conventional Commits message.
+// See https://www.conventionalcommits.org/en/v1.0.0/
+type Commit struct {
+	// Type is the lowercased commit type, e.g. "feat" or "fix"
+	Type string
+
+	// Scopes are the comma-separated scopes from the header, e.g. "auth,api"
+	Scopes []string
+
+	// Breaking is set by a "!" in the header or a BREAKING CHANGE footer
+	Breaking bool
+
+	// Description is the header text after the colon
+	Description string
+
+	// Body is the message between header and footers
+	Body string
+
+	// BreakingNote is the text of a BREAKING CHANGE footer, if present
+	BreakingNote string
+}
+
+// Parse parses a commit message. It returns an error if the header does not
+// follow the Conventional Commits format.
+func Parse(message string) (*Commit, error) {
+	message = strings.ReplaceAll(strings.TrimSpace(message), "\r\n", "\n")
+	header, rest, _ := strings.Cut(message, "\n")
+
+	match := headerPattern.FindStringSubmatch(strings.TrimSpace(header))
+	if match == nil {
+		return nil, fmt.Errorf("not a conventional commit: %q", header)
+	}
+
+	commit := &Commit{
+		Type:        strings.ToLower(match[1]),
+		Breaking:    match[3] == "!",
+		Description: strings.TrimSpace(match[4]),
+	}
+	for _, scope := range strings.Split(match[2], ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			commit.Scopes = append(commit.Scopes, scope)
+		}
+	}
+
+	paragraphs := splitParagraphs(rest)
+	if n := len(paragraphs); n > 0 && isFooterParagraph(paragraphs[n-1]) {
+		for _, line := range strings.Split(paragraphs[n-1], "\n") {
+			m := footerPattern.FindStringSubmatch(line)
+			if m != nil && (m[1] == "BREAKING CHANGE" || m[1] == "BREAKING-CHANGE") {
+				commit.Breaking = true
+				commit.BreakingNote = strings.TrimSpace(m[2])
+			}
+		}
+		paragraphs = paragraphs[:n-1]
+	}
+	commit.Body = strings.Join(paragraphs, "\n\n")
+
+	return commit, nil
+}
+
+// Bump returns the version bump implied by the commit. Breaking changes are
+// major, feat is minor, fix and perf are patch. Other types (docs, chore,
+// refactor, ...) return false.
+func (c *Commit) Bump() (models.BumpType, bool) {
+	switch {
+	case c.Breaking:
+		return models.BumpMajor, true
+	case c.Type == "feat":
+		return models.BumpMinor, true
+	case c.Type == "fix", c.Type == "perf":
+		return models.BumpPatch, true
+	default:
+		return "", false
+	}
+}
+
+func splitParagraphs(text string) []string {
+	var paragraphs []string
+	for _, p := range strings.Split(text, "\n\n") {
+		if p = strings.TrimSpace(p); p != "" {
+			paragraphs = append(paragraphs, p)
+		}
+	}
+	return paragraphs
+}
+
+func isFooterParagraph(paragraph string) bool {
+	for _, line := range strings.Split(paragraph, "\n") {
+		if !footerPattern.MatchString(line) {
+			return false
+		}
+	}
+	return true
+}
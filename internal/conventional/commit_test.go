@@ -0,0 +1,73 @@
+package conventional
+
+import (
+	"testing"
+
+	"github.com/jakoblorz/go-changesets/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		message  string
+		expected *Commit
+		bump     models.BumpType
+	}{
+		{
+			name:     "feat with scope",
+			message:  "feat(auth): add OAuth login",
+			expected: &Commit{Type: "feat", Scopes: []string{"auth"}, Description: "add OAuth login"},
+			bump:     models.BumpMinor,
+		},
+		{
+			name:     "breaking marker without scope",
+			message:  "fix!: drop legacy token format",
+			expected: &Commit{Type: "fix", Breaking: true, Description: "drop legacy token format"},
+			bump:     models.BumpMajor,
+		},
+		{
+			name:     "multiple scopes",
+			message:  "perf(api, shared): cache lookups",
+			expected: &Commit{Type: "perf", Scopes: []string{"api", "shared"}, Description: "cache lookups"},
+			bump:     models.BumpPatch,
+		},
+		{
+			name:    "body and breaking footer",
+			message: "feat(api): new pagination\n\nResponses are now paged.\n\nBREAKING CHANGE: offset parameter removed\nRefs: #42",
+			expected: &Commit{
+				Type:         "feat",
+				Scopes:       []string{"api"},
+				Breaking:     true,
+				Description:  "new pagination",
+				Body:         "Responses are now paged.",
+				BreakingNote: "offset parameter removed",
+			},
+			bump: models.BumpMajor,
+		},
+		{
+			name:     "non-release type",
+			message:  "docs: fix typo",
+			expected: &Commit{Type: "docs", Description: "fix typo"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commit, err := Parse(tt.message)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, commit)
+
+			bump, ok := commit.Bump()
+			require.Equal(t, tt.bump != "", ok)
+			require.Equal(t, tt.bump, bump)
+		})
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, message := range []string{"Merge branch 'main'", "feat add thing", "feat(: x", ""} {
+		_, err := Parse(message)
+		require.Error(t, err, message)
+	}
+}
@@ -0,0 +1,251 @@
+package filesystem
+
+import (
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"time"
+)
+
+// DryRunFileSystem passes all operations to the wrapped FileSystem until it
+// is enabled. Once enabled, writes and removals are kept in memory instead of
+// being applied, and reads see them, so a command can run as usual and its
+// changes can be reported afterwards.
+type DryRunFileSystem struct {
+	FileSystem
+
+	enabled bool
+
+	// pending holds the new content or removal of every changed path
+	pending map[string]*pendingFile
+	dirs    map[string]bool
+	order   []string
+}
+
+type pendingFile struct {
+	data    []byte
+	perm    fs.FileMode
+	removed bool
+}
+
+// FileChange describes a file that would be written or removed.
+type FileChange struct {
+	Path string
+
+	// Before is the original content; nil if the file did not exist
+	Before []byte
+
+	// After is the new content; nil if the file would be removed
+	After []byte
+}
+
+// NewDryRunFileSystem wraps fsys in a DryRunFileSystem
+func NewDryRunFileSystem(fsys FileSystem) *DryRunFileSystem {
+	return &DryRunFileSystem{
+		FileSystem: fsys,
+		pending:    make(map[string]*pendingFile),
+		dirs:       make(map[string]bool),
+	}
+}
+
+// Enable keeps all further writes and removals in memory
+func (d *DryRunFileSystem) Enable() {
+	d.enabled = true
+}
+
+// Enabled reports whether writes and removals are kept in memory
+func (d *DryRunFileSystem) Enabled() bool {
+	return d.enabled
+}
+
+// Unwrap returns the wrapped FileSystem
+func (d *DryRunFileSystem) Unwrap() FileSystem {
+	return d.FileSystem
+}
+
+// Changes returns the files that would be written or removed, in the order
+// they were first changed. Files written with their original content are omitted.
+func (d *DryRunFileSystem) Changes() []FileChange {
+	var changes []FileChange
+	for _, path := range d.order {
+		change := FileChange{Path: path}
+		if data, err := d.FileSystem.ReadFile(path); err == nil {
+			change.Before = data
+		}
+
+		if file := d.pending[path]; !file.removed {
+			change.After = file.data
+			if change.After == nil {
+				change.After = []byte{}
+			}
+		}
+
+		if change.Before == nil && change.After == nil {
+			continue
+		}
+		if change.Before != nil && change.After != nil && string(change.Before) == string(change.After) {
+			continue
+		}
+		changes = append(changes, change)
+	}
+	return changes
+}
+
+func (d *DryRunFileSystem) ReadFile(path string) ([]byte, error) {
+	if file, ok := d.pending[filepath.Clean(path)]; ok {
+		if file.removed {
+			return nil, fs.ErrNotExist
+		}
+		return file.data, nil
+	}
+	return d.FileSystem.ReadFile(path)
+}
+
+func (d *DryRunFileSystem) WriteFile(path string, data []byte, perm fs.FileMode) error {
+	if !d.enabled {
+		return d.FileSystem.WriteFile(path, data, perm)
+	}
+	d.set(path, &pendingFile{data: append([]byte(nil), data...), perm: perm})
+	return nil
+}
+
+func (d *DryRunFileSystem) Remove(path string) error {
+	if !d.enabled {
+		return d.FileSystem.Remove(path)
+	}
+	if !d.Exists(path) {
+		return fs.ErrNotExist
+	}
+	d.set(path, &pendingFile{removed: true})
+	return nil
+}
+
+func (d *DryRunFileSystem) MkdirAll(path string, perm fs.FileMode) error {
+	if !d.enabled {
+		return d.FileSystem.MkdirAll(path, perm)
+	}
+	for dir := filepath.Clean(path); !d.FileSystem.Exists(dir); dir = filepath.Dir(dir) {
+		d.dirs[dir] = true
+		if dir == filepath.Dir(dir) {
+			break
+		}
+	}
+	return nil
+}
+
+func (d *DryRunFileSystem) Stat(path string) (fs.FileInfo, error) {
+	clean := filepath.Clean(path)
+	if file, ok := d.pending[clean]; ok {
+		if file.removed {
+			return nil, fs.ErrNotExist
+		}
+		return &pendingFileInfo{name: filepath.Base(clean), size: int64(len(file.data)), mode: file.perm}, nil
+	}
+	if d.dirs[clean] {
+		return &pendingFileInfo{name: filepath.Base(clean), mode: fs.ModeDir | 0755}, nil
+	}
+	return d.FileSystem.Stat(path)
+}
+
+func (d *DryRunFileSystem) Exists(path string) bool {
+	_, err := d.Stat(path)
+	return err == nil
+}
+
+func (d *DryRunFileSystem) ReadDir(path string) ([]fs.DirEntry, error) {
+	clean := filepath.Clean(path)
+
+	entries, err := d.FileSystem.ReadDir(path)
+	if err != nil && !(errors.Is(err, fs.ErrNotExist) && d.dirs[clean]) {
+		return nil, err
+	}
+
+	byName := make(map[string]fs.DirEntry, len(entries))
+	for _, entry := range entries {
+		byName[entry.Name()] = entry
+	}
+	for pendingPath, file := range d.pending {
+		if filepath.Dir(pendingPath) != clean {
+			continue
+		}
+		name := filepath.Base(pendingPath)
+		if file.removed {
+			delete(byName, name)
+			continue
+		}
+		info, _ := d.Stat(pendingPath)
+		byName[name] = fs.FileInfoToDirEntry(info)
+	}
+
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	result := make([]fs.DirEntry, 0, len(names))
+	for _, name := range names {
+		result = append(result, byName[name])
+	}
+	return result, nil
+}
+
+// WalkDir walks the wrapped FileSystem, skipping removed files. Files that
+// would be created are not visited.
+func (d *DryRunFileSystem) WalkDir(root string, fn fs.WalkDirFunc) error {
+	return d.FileSystem.WalkDir(root, func(path string, entry fs.DirEntry, err error) error {
+		if file, ok := d.pending[filepath.Clean(path)]; ok && file.removed {
+			return nil
+		}
+		return fn(path, entry, err)
+	})
+}
+
+func (d *DryRunFileSystem) Glob(pattern string) ([]string, error) {
+	matches, err := d.FileSystem.Glob(pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]bool, len(matches))
+	var result []string
+	for _, match := range matches {
+		if file, ok := d.pending[filepath.Clean(match)]; ok && file.removed {
+			continue
+		}
+		seen[filepath.Clean(match)] = true
+		result = append(result, match)
+	}
+	for path, file := range d.pending {
+		if file.removed || seen[path] {
+			continue
+		}
+		if ok, _ := filepath.Match(pattern, path); ok {
+			result = append(result, path)
+		}
+	}
+	sort.Strings(result)
+	return result, nil
+}
+
+func (d *DryRunFileSystem) set(path string, file *pendingFile) {
+	clean := filepath.Clean(path)
+	if _, ok := d.pending[clean]; !ok {
+		d.order = append(d.order, clean)
+	}
+	d.pending[clean] = file
+}
+
+type pendingFileInfo struct {
+	name string
+	size int64
+	mode fs.FileMode
+}
+
+func (i *pendingFileInfo) Name() string       { return i.name }
+func (i *pendingFileInfo) Size() int64        { return i.size }
+func (i *pendingFileInfo) Mode() fs.FileMode  { return i.mode }
+func (i *pendingFileInfo) ModTime() time.Time { return time.Time{} }
+func (i *pendingFileInfo) IsDir() bool        { return i.mode.IsDir() }
+func (i *pendingFileInfo) Sys() interface{}   { return nil }
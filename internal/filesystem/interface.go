@@ -26,3 +26,15 @@ type FileSystem interface {
 	// Glob patterns
 	Glob(pattern string) ([]string, error)
 }
+
+// Unwrap returns the FileSystem wrapped by fsys, following wrappers such as
+// Transaction and DryRunFileSystem, or fsys itself if it wraps nothing.
+func Unwrap(fsys FileSystem) FileSystem {
+	for {
+		wrapper, ok := fsys.(interface{ Unwrap() FileSystem })
+		if !ok {
+			return fsys
+		}
+		fsys = wrapper.Unwrap()
+	}
+}
@@ -242,7 +242,12 @@ func (mfs *MockFileSystem) WalkDir(root string, fn fs.WalkDirFunc) error {
 	// Sort paths for consistent ordering
 	sort.Strings(paths)
 
+	var skipped []string
 	for _, p := range paths {
+		if isUnderAny(p, skipped) {
+			continue
+		}
+
 		file := mfs.files[p]
 		info := &mockFileInfo{
 			name:    filepath.Base(p),
@@ -256,6 +261,7 @@ func (mfs *MockFileSystem) WalkDir(root string, fn fs.WalkDirFunc) error {
 
 		if err := fn(p, entry, nil); err != nil {
 			if err == filepath.SkipDir && file.IsDir {
+				skipped = append(skipped, p+string(filepath.Separator))
 				continue
 			}
 			return err
@@ -265,6 +271,16 @@ func (mfs *MockFileSystem) WalkDir(root string, fn fs.WalkDirFunc) error {
 	return nil
 }
 
+// isUnderAny reports whether p has one of the given directory prefixes
+func isUnderAny(p string, prefixes []string) bool {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(p, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
 func (mfs *MockFileSystem) Glob(pattern string) ([]string, error) {
 	var matches []string
 
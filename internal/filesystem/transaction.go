@@ -0,0 +1,107 @@
+package filesystem
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+)
+
+// Transaction is a FileSystem that remembers the original content of every
+// file it writes or removes, so that all changes can be rolled back.
+// Directories created with MkdirAll are not removed on rollback.
+type Transaction struct {
+	FileSystem
+
+	originals map[string]*originalFile
+	order     []string
+}
+
+type originalFile struct {
+	data    []byte
+	perm    fs.FileMode
+	existed bool
+}
+
+// NewTransaction wraps fsys in a Transaction
+func NewTransaction(fsys FileSystem) *Transaction {
+	return &Transaction{
+		FileSystem: fsys,
+		originals:  make(map[string]*originalFile),
+	}
+}
+
+// WriteFile records the original content of path and writes data
+func (t *Transaction) WriteFile(path string, data []byte, perm fs.FileMode) error {
+	if err := t.record(path); err != nil {
+		return err
+	}
+	return t.FileSystem.WriteFile(path, data, perm)
+}
+
+// Remove records the original content of path and removes it
+func (t *Transaction) Remove(path string) error {
+	if err := t.record(path); err != nil {
+		return err
+	}
+	return t.FileSystem.Remove(path)
+}
+
+// Unwrap returns the wrapped FileSystem
+func (t *Transaction) Unwrap() FileSystem {
+	return t.FileSystem
+}
+
+// Changed returns the paths written or removed so far, in order
+func (t *Transaction) Changed() []string {
+	return append([]string(nil), t.order...)
+}
+
+// Rollback restores every written or removed file to its original content
+// and removes files that did not exist before.
+func (t *Transaction) Rollback() error {
+	var errs []error
+	for i := len(t.order) - 1; i >= 0; i-- {
+		path := t.order[i]
+		original := t.originals[path]
+
+		if !original.existed {
+			if t.FileSystem.Exists(path) {
+				if err := t.FileSystem.Remove(path); err != nil {
+					errs = append(errs, fmt.Errorf("failed to remove %s: %w", path, err))
+				}
+			}
+			continue
+		}
+
+		if err := t.FileSystem.WriteFile(path, original.data, original.perm); err != nil {
+			errs = append(errs, fmt.Errorf("failed to restore %s: %w", path, err))
+		}
+	}
+
+	t.originals = make(map[string]*originalFile)
+	t.order = nil
+	return errors.Join(errs...)
+}
+
+func (t *Transaction) record(path string) error {
+	if _, ok := t.originals[path]; ok {
+		return nil
+	}
+
+	original := &originalFile{perm: 0644}
+	if info, err := t.FileSystem.Stat(path); err == nil {
+		data, err := t.FileSystem.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("failed to read %s: %w", path, err)
+		}
+		original.data = data
+		original.perm = info.Mode().Perm()
+		original.existed = true
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to stat %s: %w", path, err)
+	}
+
+	t.originals[path] = original
+	t.order = append(t.order, path)
+	return nil
+}
@@ -0,0 +1,89 @@
+package git
+
+import (
+	"context"
+	"fmt"
+)
+
+// DryRunGitClient passes all operations to the wrapped GitClient until it is
+// enabled. Once enabled, tags are recorded instead of created or pushed.
+type DryRunGitClient struct {
+	GitClient
+
+	state *dryRunState
+}
+
+type dryRunState struct {
+	enabled    bool
+	operations []string
+	tags       map[string]string
+}
+
+// NewDryRunGitClient wraps client in a DryRunGitClient
+func NewDryRunGitClient(client GitClient) *DryRunGitClient {
+	return &DryRunGitClient{
+		GitClient: client,
+		state:     &dryRunState{tags: make(map[string]string)},
+	}
+}
+
+// Enable records all further tag creations and pushes instead of running them
+func (g *DryRunGitClient) Enable() {
+	g.state.enabled = true
+}
+
+// Operations returns the recorded operations, e.g. "create tag auth@v1.2.0"
+func (g *DryRunGitClient) Operations() []string {
+	return append([]string(nil), g.state.operations...)
+}
+
+// WithContext returns a new client with the given context that shares the recorded operations
+func (g *DryRunGitClient) WithContext(ctx context.Context) GitClient {
+	return &DryRunGitClient{GitClient: g.GitClient.WithContext(ctx), state: g.state}
+}
+
+func (g *DryRunGitClient) CreateTag(tagName, message string) error {
+	if !g.state.enabled {
+		return g.GitClient.CreateTag(tagName, message)
+	}
+	if exists, err := g.TagExists(tagName); err == nil && exists {
+		return fmt.Errorf("tag %s already exists", tagName)
+	}
+	g.state.tags[tagName] = message
+	g.state.operations = append(g.state.operations, fmt.Sprintf("create tag %s", tagName))
+	return nil
+}
+
+func (g *DryRunGitClient) CreateTagAt(tagName, ref, message string) error {
+	if !g.state.enabled {
+		return g.GitClient.CreateTagAt(tagName, ref, message)
+	}
+	if exists, err := g.TagExists(tagName); err == nil && exists {
+		return fmt.Errorf("tag %s already exists", tagName)
+	}
+	g.state.tags[tagName] = message
+	g.state.operations = append(g.state.operations, fmt.Sprintf("create tag %s at %s", tagName, ref))
+	return nil
+}
+
+func (g *DryRunGitClient) PushTag(tagName string) error {
+	if !g.state.enabled {
+		return g.GitClient.PushTag(tagName)
+	}
+	g.state.operations = append(g.state.operations, fmt.Sprintf("push tag %s", tagName))
+	return nil
+}
+
+func (g *DryRunGitClient) TagExists(tagName string) (bool, error) {
+	if _, ok := g.state.tags[tagName]; ok {
+		return true, nil
+	}
+	return g.GitClient.TagExists(tagName)
+}
+
+func (g *DryRunGitClient) GetTagAnnotation(tagName string) (string, error) {
+	if message, ok := g.state.tags[tagName]; ok {
+		return message, nil
+	}
+	return g.GitClient.GetTagAnnotation(tagName)
+}
@@ -4,6 +4,18 @@ import (
 	"context"
 )
 
+// Commit describes a single commit in the log
+type Commit struct {
+	// Hash is the full commit SHA
+	Hash string
+
+	// Message is the full commit message (subject, body and trailers)
+	Message string
+
+	// Files are the absolute paths of files touched by the commit
+	Files []string
+}
+
 // GitClient provides an abstraction over git operations for testability
 //
 // IMPORTANT: All tag operations are branch-aware and only return tags
@@ -18,9 +30,10 @@ import (
 // different branches may have different sets of published versions.
 type GitClient interface {
 	// Tag operations (all branch-aware via --merged HEAD)
-	GetLatestTag(projectName string) (string, error)
+	GetLatestTag(pattern string) (string, error)
 	GetTagsWithPrefix(prefix string) ([]string, error)
 	CreateTag(tagName, message string) error
+	CreateTagAt(tagName, ref, message string) error
 	PushTag(tagName string) error
 	TagExists(tagName string) (bool, error)
 	GetTagAnnotation(tagName string) (string, error)
@@ -28,6 +41,10 @@ type GitClient interface {
 	// Repository operations
 	IsGitRepo() (bool, error)
 	GetCurrentBranch() (string, error)
+	GetHeadCommit() (string, error)
+	// GetRepositoryRoot returns the absolute path of the top-level directory
+	// of the working tree
+	GetRepositoryRoot() (string, error)
 
 	// RC tag operations
 	ExtractRCNumber(tag string) (int, error)
@@ -36,6 +53,21 @@ type GitClient interface {
 	GetFileCreationCommit(filePath string) (string, error)
 	GetCommitMessage(commitSHA string) (string, error)
 
+	// Diff operations
+	// GetChangedFiles returns the absolute paths of files that differ between
+	// the merge base of baseRef and HEAD and the working tree (including
+	// untracked files).
+	GetChangedFiles(baseRef string) ([]string, error)
+	// GetAddedFiles returns the absolute paths of files added between the
+	// merge base of baseRef and HEAD and the working tree (including
+	// untracked files).
+	GetAddedFiles(baseRef string) ([]string, error)
+
+	// Log operations
+	// GetCommitsSince returns the non-merge commits reachable from HEAD but not
+	// from baseRef, oldest first.
+	GetCommitsSince(baseRef string) ([]Commit, error)
+
 	// Context support for network operations
 	WithContext(ctx context.Context) GitClient
 }
@@ -6,8 +6,6 @@ import (
 	"sort"
 	"strings"
 	"sync"
-
-	"github.com/jakoblorz/go-changesets/internal/models"
 )
 
 // MockGitClient implements GitClient for testing with full commit graph simulation
@@ -19,10 +17,14 @@ type MockGitClient struct {
 	head     string                 // current HEAD commit hash
 	branch   string                 // current branch name
 	isRepo   bool
+	repoRoot string // top-level directory, empty if not set
 	ctx      context.Context
 
 	// File tracking for git history simulation
-	fileCreationCommits map[string]string // filePath -> commit SHA
+	fileCreationCommits map[string]string   // filePath -> commit SHA
+	commitFiles         map[string][]string // commit SHA -> files touched by the commit
+	workingTreeChanges  []string            // uncommitted (or untracked) files
+	baseFiles           map[string]bool     // files that exist before any commit
 
 	// Hooks for testing error scenarios
 	GetLatestTagError     error
@@ -30,6 +32,8 @@ type MockGitClient struct {
 	PushTagError          error
 	TagExistsError        error
 	GetTagAnnotationError error
+	GetChangedFilesError  error
+	GetCommitsSinceError  error
 }
 
 // MockTag represents a git tag
@@ -64,6 +68,7 @@ func NewMockGitClient() *MockGitClient {
 		branch:              "main",
 		ctx:                 context.Background(),
 		fileCreationCommits: make(map[string]string),
+		commitFiles:         make(map[string][]string),
 	}
 
 	// Create initial commit (like real git init)
@@ -116,14 +121,20 @@ func (m *MockGitClient) WithContext(ctx context.Context) GitClient {
 		head:                m.head,
 		branch:              m.branch,
 		isRepo:              m.isRepo,
+		repoRoot:            m.repoRoot,
 		ctx:                 ctx,
 		fileCreationCommits: m.fileCreationCommits,
+		commitFiles:         m.commitFiles,
+		workingTreeChanges:  m.workingTreeChanges,
+		baseFiles:           m.baseFiles,
 
 		GetLatestTagError:     m.GetLatestTagError,
 		CreateTagError:        m.CreateTagError,
 		PushTagError:          m.PushTagError,
 		TagExistsError:        m.TagExistsError,
 		GetTagAnnotationError: m.GetTagAnnotationError,
+		GetChangedFilesError:  m.GetChangedFilesError,
+		GetCommitsSinceError:  m.GetCommitsSinceError,
 	}
 }
 
@@ -310,41 +321,47 @@ func (m *MockGitClient) isReachableFromHEAD(commitHash string) bool {
 	return m.isAncestor(commitHash, m.head)
 }
 
-func (m *MockGitClient) GetLatestTag(projectName string) (string, error) {
+func (m *MockGitClient) GetLatestTag(pattern string) (string, error) {
 	if m.GetLatestTagError != nil {
 		return "", m.GetLatestTagError
 	}
 
-	m.mu.RLock()
-	defer m.mu.RUnlock()
+	tags, err := m.GetTagsWithPrefix(pattern)
+	if err != nil {
+		return "", err
+	}
+	if len(tags) == 0 {
+		return "", fmt.Errorf("no tags found matching %s", pattern)
+	}
 
-	// Find all tags for this project
-	pattern := fmt.Sprintf("%s@v", projectName)
-	var matchingTags []string
+	return tags[0], nil
+}
 
-	for tagName, tag := range m.tags {
-		if !strings.HasPrefix(tagName, pattern) {
-			continue
-		}
-		if !m.isReachableFromHEAD(tag.CommitHash) {
-			continue
-		}
-		matchingTags = append(matchingTags, tagName)
+func (m *MockGitClient) CreateTag(tagName, message string) error {
+	if m.CreateTagError != nil {
+		return m.CreateTagError
 	}
 
-	if len(matchingTags) == 0 {
-		return "", fmt.Errorf("no tags found for project %s", projectName)
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	// Check if tag already exists
+	if _, exists := m.tags[tagName]; exists {
+		return fmt.Errorf("tag %s already exists", tagName)
 	}
 
-	// Sort by version (reverse order for latest first) using semver comparison
-	sort.Slice(matchingTags, func(i, j int) bool {
-		return compareTagVersions(matchingTags[i], matchingTags[j])
-	})
+	m.tags[tagName] = &MockTag{
+		Name:       tagName,
+		Message:    message,
+		IsPushed:   false,
+		ProjectTag: tagName,
+		CommitHash: m.head, // Tag points to current HEAD
+	}
 
-	return matchingTags[0], nil
+	return nil
 }
 
-func (m *MockGitClient) CreateTag(tagName, message string) error {
+func (m *MockGitClient) CreateTagAt(tagName, ref, message string) error {
 	if m.CreateTagError != nil {
 		return m.CreateTagError
 	}
@@ -352,17 +369,21 @@ func (m *MockGitClient) CreateTag(tagName, message string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
-	// Check if tag already exists
 	if _, exists := m.tags[tagName]; exists {
 		return fmt.Errorf("tag %s already exists", tagName)
 	}
 
+	commitHash, err := m.resolveRef(ref)
+	if err != nil {
+		return err
+	}
+
 	m.tags[tagName] = &MockTag{
 		Name:       tagName,
 		Message:    message,
 		IsPushed:   false,
 		ProjectTag: tagName,
-		CommitHash: m.head, // Tag points to current HEAD
+		CommitHash: commitHash,
 	}
 
 	return nil
@@ -425,6 +446,30 @@ func (m *MockGitClient) GetCurrentBranch() (string, error) {
 	return m.branch, nil
 }
 
+// SetRepositoryRoot sets the top-level directory of the simulated repository
+func (m *MockGitClient) SetRepositoryRoot(root string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.repoRoot = root
+}
+
+// GetRepositoryRoot returns the root set with SetRepositoryRoot, or "" if none
+// was set
+func (m *MockGitClient) GetRepositoryRoot() (string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if !m.isRepo {
+		return "", fmt.Errorf("not a git repository")
+	}
+	return m.repoRoot, nil
+}
+
+func (m *MockGitClient) GetHeadCommit() (string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.head, nil
+}
+
 // GetTagsWithPrefix returns all tags matching the prefix pattern
 // that are reachable from current HEAD (uses git ancestry simulation)
 // Supports wildcard patterns like "backend@v*"
@@ -448,9 +493,7 @@ func (m *MockGitClient) GetTagsWithPrefix(prefix string) ([]string, error) {
 	}
 
 	// Sort by version (reverse order for latest first) using semver comparison
-	sort.Slice(matchingTags, func(i, j int) bool {
-		return compareTagVersions(matchingTags[i], matchingTags[j])
-	})
+	sortTagsDescending(matchingTags)
 
 	return matchingTags, nil
 }
@@ -466,64 +509,9 @@ func matchesPattern(name, pattern string) bool {
 	return name == pattern
 }
 
-// compareTagVersions compares two tag names by their semantic version
-// Returns true if tag i should come before tag j (descending order)
-// Mimics git's --sort=-version:refname behavior
-func compareTagVersions(tagI, tagJ string) bool {
-	// Extract version from tags: "backend@v1.2.3" -> "1.2.3"
-	versionI := extractVersionFromTag(tagI)
-	versionJ := extractVersionFromTag(tagJ)
-
-	// If we can't parse, fall back to string comparison
-	if versionI == "" || versionJ == "" {
-		return tagI > tagJ
-	}
-
-	// Parse as semantic versions
-	verI, errI := models.ParseVersion(versionI)
-	verJ, errJ := models.ParseVersion(versionJ)
-
-	if errI != nil || errJ != nil {
-		// Fall back to string comparison
-		return tagI > tagJ
-	}
-
-	// Use Version.Compare: returns 1 if verI > verJ
-	return verI.Compare(verJ) > 0
-}
-
-// extractVersionFromTag extracts version string from tag name
-// "backend@v1.2.3-rc0" -> "1.2.3-rc0"
-func extractVersionFromTag(tag string) string {
-	parts := strings.Split(tag, "@")
-	if len(parts) != 2 {
-		return ""
-	}
-	return parts[1]
-}
-
 // ExtractRCNumber extracts the RC number from a tag
-// Delegates to the real implementation in os.go
 func (m *MockGitClient) ExtractRCNumber(tag string) (int, error) {
-	// Use the same logic as OSGitClient
-	rcIdx := strings.Index(tag, "-rc")
-	if rcIdx == -1 {
-		return -1, nil // Not an RC tag
-	}
-
-	rcSuffix := tag[rcIdx+3:]
-	if rcSuffix == "" {
-		return -1, fmt.Errorf("invalid RC tag format: %s (expected -rc{number})", tag)
-	}
-
-	// Need to import strconv
-	var num int
-	_, err := fmt.Sscanf(rcSuffix, "%d", &num)
-	if err != nil {
-		return -1, fmt.Errorf("invalid RC number in tag %s: %w", tag, err)
-	}
-
-	return num, nil
+	return extractRCNumber(tag)
 }
 
 // GetAllTags returns all tags (helper for testing)
@@ -548,7 +536,11 @@ func (m *MockGitClient) Reset() {
 	m.commits = make(map[string]*MockCommit)
 	m.branches = make(map[string]*MockBranch)
 	m.fileCreationCommits = make(map[string]string)
+	m.commitFiles = make(map[string][]string)
+	m.workingTreeChanges = nil
+	m.baseFiles = nil
 	m.isRepo = true
+	m.repoRoot = ""
 	m.branch = "main"
 	m.ctx = context.Background()
 
@@ -566,6 +558,8 @@ func (m *MockGitClient) Reset() {
 	m.PushTagError = nil
 	m.TagExistsError = nil
 	m.GetTagAnnotationError = nil
+	m.GetChangedFilesError = nil
+	m.GetCommitsSinceError = nil
 }
 
 // createInitialCommitUnsafe creates initial commit without locking (used by Reset)
@@ -614,3 +608,158 @@ func (m *MockGitClient) SetFileCreationCommit(filePath, commitSHA string) {
 
 	m.fileCreationCommits[filePath] = commitSHA
 }
+
+// AddCommitFiles records the files touched by a commit (for testing)
+func (m *MockGitClient) AddCommitFiles(commitSHA string, paths ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.commitFiles[commitSHA] = append(m.commitFiles[commitSHA], paths...)
+}
+
+// SetWorkingTreeChanges sets the uncommitted files reported by GetChangedFiles (for testing)
+func (m *MockGitClient) SetWorkingTreeChanges(paths ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.workingTreeChanges = append([]string{}, paths...)
+}
+
+// SetBaseFiles records files that exist before any commit, so GetAddedFiles
+// reports changes to them as modifications (for testing)
+func (m *MockGitClient) SetBaseFiles(paths ...string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.baseFiles = make(map[string]bool, len(paths))
+	for _, path := range paths {
+		m.baseFiles[path] = true
+	}
+}
+
+// GetAddedFiles returns the files of GetChangedFiles that were not recorded
+// via SetBaseFiles.
+func (m *MockGitClient) GetAddedFiles(baseRef string) ([]string, error) {
+	files, err := m.GetChangedFiles(baseRef)
+	if err != nil {
+		return nil, err
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	added := files[:0]
+	for _, path := range files {
+		if !m.baseFiles[path] {
+			added = append(added, path)
+		}
+	}
+	return added, nil
+}
+
+// GetChangedFiles returns files touched by commits reachable from HEAD but not
+// from baseRef, plus working tree changes. baseRef may be a branch, tag or commit.
+func (m *MockGitClient) GetChangedFiles(baseRef string) ([]string, error) {
+	if m.GetChangedFilesError != nil {
+		return nil, m.GetChangedFilesError
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if baseRef == "" {
+		return nil, fmt.Errorf("base ref cannot be empty")
+	}
+
+	base, err := m.resolveRef(baseRef)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := make(map[string]struct{})
+	var files []string
+	add := func(path string) {
+		if _, ok := seen[path]; ok {
+			return
+		}
+		seen[path] = struct{}{}
+		files = append(files, path)
+	}
+
+	for hash := range m.commits {
+		if !m.isReachableFromHEAD(hash) || m.isAncestor(hash, base) {
+			continue
+		}
+		for _, path := range m.commitFiles[hash] {
+			add(path)
+		}
+	}
+	for _, path := range m.workingTreeChanges {
+		add(path)
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// GetCommitsSince returns the non-merge commits reachable from HEAD but not
+// from baseRef, oldest first, including files recorded via AddCommitFiles.
+func (m *MockGitClient) GetCommitsSince(baseRef string) ([]Commit, error) {
+	if m.GetCommitsSinceError != nil {
+		return nil, m.GetCommitsSinceError
+	}
+
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if baseRef == "" {
+		return nil, fmt.Errorf("base ref cannot be empty")
+	}
+
+	base, err := m.resolveRef(baseRef)
+	if err != nil {
+		return nil, err
+	}
+
+	// Post-order DFS from HEAD visits parents before children
+	var commits []Commit
+	visited := make(map[string]bool)
+	var visit func(hash string)
+	visit = func(hash string) {
+		commit, exists := m.commits[hash]
+		if !exists || visited[hash] || m.isAncestor(hash, base) {
+			return
+		}
+		visited[hash] = true
+
+		for _, parent := range commit.Parents {
+			visit(parent)
+		}
+		if len(commit.Parents) > 1 {
+			return
+		}
+
+		commits = append(commits, Commit{
+			Hash:    commit.Hash,
+			Message: commit.Message,
+			Files:   append([]string{}, m.commitFiles[hash]...),
+		})
+	}
+	visit(m.head)
+
+	return commits, nil
+}
+
+// resolveRef resolves a branch, tag or commit hash to a commit hash
+func (m *MockGitClient) resolveRef(ref string) (string, error) {
+	if branch, ok := m.branches[ref]; ok {
+		return branch.Head, nil
+	}
+	if tag, ok := m.tags[ref]; ok {
+		return tag.CommitHash, nil
+	}
+	if _, ok := m.commits[ref]; ok {
+		return ref, nil
+	}
+	return "", fmt.Errorf("unknown revision %s", ref)
+}
@@ -47,8 +47,8 @@ func TestComparison_BasicTagOperations(t *testing.T) {
 	mockClient.CreateTag("backend@v1.1.0", "Release 1.1.0")
 
 	// Test 4: GetLatestTag
-	osTag, osErr3 := osClient.GetLatestTag("backend")
-	mockTag, mockErr3 := mockClient.GetLatestTag("backend")
+	osTag, osErr3 := osClient.GetLatestTag("backend@v*")
+	mockTag, mockErr3 := mockClient.GetLatestTag("backend@v*")
 
 	require.Equal(t, osTag, mockTag, "GetLatestTag mismatch: OS=%s, Mock=%s", osTag, mockTag)
 	require.Equal(t, osErr3 == nil, mockErr3 == nil, "GetLatestTag error mismatch: OS=%v, Mock=%v", osErr3, mockErr3)
@@ -16,15 +16,15 @@ func TestMockGitClient_TagOperations(t *testing.T) {
 	mock.AddTag("api", "0.1.0", "First release")
 
 	// Test GetLatestTag
-	tag, err := mock.GetLatestTag("auth")
+	tag, err := mock.GetLatestTag("auth@v*")
 	require.NoError(t, err)
 	require.Equal(t, "auth@v1.1.0", tag)
 
-	tag, err = mock.GetLatestTag("api")
+	tag, err = mock.GetLatestTag("api@v*")
 	require.NoError(t, err)
 	require.Equal(t, "api@v0.1.0", tag)
 
-	_, err = mock.GetLatestTag("nonexistent")
+	_, err = mock.GetLatestTag("nonexistent@v*")
 	require.Error(t, err)
 }
 
@@ -55,7 +55,7 @@ func TestMockGitClient_PushedTags(t *testing.T) {
 	mock.AddPushedTag("auth", "1.1.0", "Pushed")
 	mock.AddPushedTag("auth", "1.2.0", "Pushed latest")
 
-	tag, err := mock.GetLatestTag("auth")
+	tag, err := mock.GetLatestTag("auth@v*")
 	require.NoError(t, err)
 	require.Equal(t, "auth@v1.2.0", tag)
 
@@ -111,7 +111,7 @@ func TestMockGitClient_Reset(t *testing.T) {
 
 	mock.Reset()
 
-	_, err := mock.GetLatestTag("auth")
+	_, err := mock.GetLatestTag("auth@v*")
 	require.Error(t, err)
 
 	isRepo, _ := mock.IsGitRepo()
@@ -127,7 +127,7 @@ func TestMockGitClient_ErrorScenarios(t *testing.T) {
 	// Set error hooks
 	mock.GetLatestTagError = fmt.Errorf("simulated error")
 
-	_, err := mock.GetLatestTag("auth")
+	_, err := mock.GetLatestTag("auth@v*")
 	require.Error(t, err)
 	require.EqualError(t, err, "simulated error")
 
@@ -154,7 +154,7 @@ func TestMockGit_BasicTagOperationsWithGraph(t *testing.T) {
 	err = mock.CreateTag("backend@v1.1.0", "Release 1.1.0")
 	require.NoError(t, err)
 
-	tag, err := mock.GetLatestTag("backend")
+	tag, err := mock.GetLatestTag("backend@v*")
 	require.NoError(t, err)
 	require.Equal(t, "backend@v1.1.0", tag)
 
@@ -415,3 +415,65 @@ func TestMockGit_TagAnnotationsWithGraph(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, message, annotation)
 }
+
+func TestMockGit_GetChangedFiles(t *testing.T) {
+	mock := NewMockGitClient()
+
+	base := mock.CreateCommit("base")
+	mock.AddCommitFiles(base, "/repo/README.md")
+
+	require.NoError(t, mock.CreateBranch("feature"))
+	require.NoError(t, mock.CheckoutBranch("feature"))
+	first := mock.CreateCommit("first")
+	mock.AddCommitFiles(first, "/repo/backend/main.go", "/repo/shared/util.go")
+	second := mock.CreateCommit("second")
+	mock.AddCommitFiles(second, "/repo/backend/main.go")
+	mock.SetWorkingTreeChanges("/repo/.changeset/abc.md")
+
+	files, err := mock.GetChangedFiles("main")
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		"/repo/.changeset/abc.md",
+		"/repo/backend/main.go",
+		"/repo/shared/util.go",
+	}, files)
+
+	_, err = mock.GetChangedFiles("does-not-exist")
+	require.Error(t, err)
+}
+
+func TestMockGit_GetCommitsSince(t *testing.T) {
+	mock := NewMockGitClient()
+	mock.CreateTag("backend@v1.0.0", "Release 1.0.0")
+
+	require.NoError(t, mock.CreateBranch("feature"))
+	require.NoError(t, mock.CheckoutBranch("feature"))
+	first := mock.CreateCommit("feat: first")
+	mock.AddCommitFiles(first, "/repo/backend/main.go")
+
+	require.NoError(t, mock.CheckoutBranch("main"))
+	second := mock.CreateCommit("fix: second")
+	mock.CreateCommit("Merge feature", second, first)
+
+	commits, err := mock.GetCommitsSince("backend@v1.0.0")
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+	require.Equal(t, Commit{Hash: second, Message: "fix: second", Files: []string{}}, commits[0])
+	require.Equal(t, Commit{Hash: first, Message: "feat: first", Files: []string{"/repo/backend/main.go"}}, commits[1])
+}
+
+func TestMockGit_TagOrderingPastTenRCs(t *testing.T) {
+	mock := NewMockGitClient()
+
+	for _, tag := range []string{"backend@v1.2.0-rc2", "backend@v1.2.0-rc10", "backend@v1.2.0", "backend@v1.2.0-rc9"} {
+		require.NoError(t, mock.CreateTag(tag, tag))
+	}
+
+	tags, err := mock.GetTagsWithPrefix("backend@v*")
+	require.NoError(t, err)
+	require.Equal(t, []string{"backend@v1.2.0", "backend@v1.2.0-rc10", "backend@v1.2.0-rc9", "backend@v1.2.0-rc2"}, tags)
+
+	rc, err := mock.ExtractRCNumber("backend@v1.2.0-rc11+sha.abc")
+	require.NoError(t, err)
+	require.Equal(t, 11, rc)
+}
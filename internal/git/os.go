@@ -5,7 +5,8 @@ import (
 	"context"
 	"fmt"
 	"os/exec"
-	"strconv"
+	"path/filepath"
+	"sort"
 	"strings"
 )
 
@@ -28,28 +29,18 @@ func (g *OSGitClient) WithContext(ctx context.Context) GitClient {
 	}
 }
 
-// GetLatestTag returns the latest tag for a project on the current branch
-// Tags are expected to be in the format: {projectName}@v{version}
-func (g *OSGitClient) GetLatestTag(projectName string) (string, error) {
-	// Get all tags matching the project pattern, sorted by version, merged into current branch
-	pattern := fmt.Sprintf("%s@v*", projectName)
-	cmd := exec.CommandContext(g.ctx, "git", "tag", "-l", pattern, "--sort=-version:refname", "--merged", "HEAD")
-
-	var out bytes.Buffer
-	cmd.Stdout = &out
-
-	if err := cmd.Run(); err != nil {
-		return "", fmt.Errorf("failed to list tags: %w", err)
+// GetLatestTag returns the latest tag matching pattern on the current branch,
+// e.g. "auth@v*" or "services/auth/v*"
+func (g *OSGitClient) GetLatestTag(pattern string) (string, error) {
+	tags, err := g.GetTagsWithPrefix(pattern)
+	if err != nil {
+		return "", err
 	}
-
-	// Get the first line (latest version)
-	output := strings.TrimSpace(out.String())
-	if output == "" {
-		return "", fmt.Errorf("no tags found for project %s", projectName)
+	if len(tags) == 0 {
+		return "", fmt.Errorf("no tags found matching %s", pattern)
 	}
 
-	lines := strings.Split(output, "\n")
-	return strings.TrimSpace(lines[0]), nil
+	return tags[0], nil
 }
 
 // CreateTag creates an annotated tag
@@ -66,6 +57,21 @@ func (g *OSGitClient) CreateTag(tagName, message string) error {
 	return nil
 }
 
+// CreateTagAt creates an annotated tag on the commit ref points to. An
+// annotated tag ref is resolved to its commit instead of being tagged itself.
+func (g *OSGitClient) CreateTagAt(tagName, ref, message string) error {
+	cmd := exec.CommandContext(g.ctx, "git", "tag", "-a", tagName, ref+"^{commit}", "-m", message)
+
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return fmt.Errorf("failed to create tag %s at %s: %w: %s", tagName, ref, err, stderr.String())
+	}
+
+	return nil
+}
+
 // PushTag pushes a tag to the remote
 func (g *OSGitClient) PushTag(tagName string) error {
 	cmd := exec.CommandContext(g.ctx, "git", "push", "origin", tagName)
@@ -134,34 +140,21 @@ func (g *OSGitClient) GetTagsWithPrefix(prefix string) ([]string, error) {
 		}
 	}
 
+	// git's version:refname sorts "v1.2.0-rc1" above "v1.2.0" unless
+	// versionsort.suffix is configured, so re-sort by semver precedence
+	sortTagsDescending(result)
+
 	return result, nil
 }
 
 // ExtractRCNumber extracts the RC number from a tag
 // Examples:
 //   - "backend@v1.3.0-rc5" -> 5, nil
+//   - "backend@v1.3.0-rc5+sha.abc" -> 5, nil
 //   - "backend@v1.3.0" -> -1, nil (not an RC)
 //   - "backend@v1.3.0-rc" -> -1, error
 func (g *OSGitClient) ExtractRCNumber(tag string) (int, error) {
-	// Find the -rc prefix
-	rcIdx := strings.Index(tag, "-rc")
-	if rcIdx == -1 {
-		return -1, nil // Not an RC tag
-	}
-
-	// Extract everything after "-rc"
-	rcSuffix := tag[rcIdx+3:]
-	if rcSuffix == "" {
-		return -1, fmt.Errorf("invalid RC tag format: %s (expected -rc{number})", tag)
-	}
-
-	// Parse the number
-	num, err := strconv.Atoi(rcSuffix)
-	if err != nil {
-		return -1, fmt.Errorf("invalid RC number in tag %s: %w", tag, err)
-	}
-
-	return num, nil
+	return extractRCNumber(tag)
 }
 
 // IsGitRepo checks if the current directory is a git repository
@@ -190,15 +183,39 @@ func (g *OSGitClient) GetCurrentBranch() (string, error) {
 	return strings.TrimSpace(out.String()), nil
 }
 
-// GetFileCreationCommit returns the commit SHA that added a file
-// Returns empty string if file doesn't exist in git history
+// GetHeadCommit returns the full SHA of the HEAD commit
+func (g *OSGitClient) GetHeadCommit() (string, error) {
+	cmd := exec.CommandContext(g.ctx, "git", "rev-parse", "HEAD")
+
+	var out bytes.Buffer
+	cmd.Stdout = &out
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("failed to get HEAD commit: %w", err)
+	}
+
+	return strings.TrimSpace(out.String()), nil
+}
+
+// GetRepositoryRoot returns the top-level directory of the working tree
+func (g *OSGitClient) GetRepositoryRoot() (string, error) {
+	root, err := g.output("rev-parse", "--show-toplevel")
+	if err != nil {
+		return "", fmt.Errorf("failed to find repository root: %w", err)
+	}
+	return root, nil
+}
+
+// GetFileCreationCommit returns the commit SHA that added a file, including
+// files deleted since. Returns empty string if file doesn't exist in git history
 func (g *OSGitClient) GetFileCreationCommit(filePath string) (string, error) {
-	// git log --follow --diff-filter=A --pretty=format:"%H" -1 {file}
+	// git log --follow --diff-filter=A --pretty=format:"%H" -1 -- {file}
 	// --follow: track file renames
 	// --diff-filter=A: only show when file was Added
 	// -1: only first (creation) commit
+	// --: read {file} as a path even if it no longer exists
 	cmd := exec.CommandContext(g.ctx, "git", "log", "--follow", "--diff-filter=A",
-		"--pretty=format:%H", "-1", filePath)
+		"--pretty=format:%H", "-1", "--", filePath)
 
 	var out bytes.Buffer
 	cmd.Stdout = &out
@@ -230,3 +247,123 @@ func (g *OSGitClient) GetCommitMessage(commitSHA string) (string, error) {
 
 	return strings.TrimSpace(out.String()), nil
 }
+
+// GetChangedFiles returns the absolute paths of all files changed since the
+// merge base of baseRef and HEAD, including uncommitted and untracked files.
+func (g *OSGitClient) GetChangedFiles(baseRef string) ([]string, error) {
+	return g.filesSince(baseRef)
+}
+
+// GetAddedFiles returns the absolute paths of all files added since the
+// merge base of baseRef and HEAD, including untracked files.
+func (g *OSGitClient) GetAddedFiles(baseRef string) ([]string, error) {
+	return g.filesSince(baseRef, "--diff-filter=A")
+}
+
+// filesSince lists the files of the diff between the merge base of baseRef
+// and the working tree, narrowed by diffArgs, plus untracked files.
+func (g *OSGitClient) filesSince(baseRef string, diffArgs ...string) ([]string, error) {
+	if baseRef == "" {
+		return nil, fmt.Errorf("base ref cannot be empty")
+	}
+
+	root, err := g.output("rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository root: %w", err)
+	}
+
+	mergeBase, err := g.output("merge-base", baseRef, "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("failed to find merge base of %s and HEAD: %w", baseRef, err)
+	}
+
+	// --no-renames lists both sides of a rename so the old location counts as changed too
+	args := append([]string{"diff", "--name-only", "--no-renames"}, diffArgs...)
+	diffOutput, err := g.output(append(args, mergeBase)...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to diff against %s: %w", baseRef, err)
+	}
+
+	// ls-files only lists the current directory, so it runs from the root
+	untrackedOutput, err := g.output("-C", root, "ls-files", "--others", "--exclude-standard", "--full-name")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list untracked files: %w", err)
+	}
+
+	seen := make(map[string]struct{})
+	var files []string
+	for _, line := range strings.Split(diffOutput+"\n"+untrackedOutput, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+
+		absPath := filepath.Join(root, filepath.FromSlash(line))
+		if _, ok := seen[absPath]; ok {
+			continue
+		}
+		seen[absPath] = struct{}{}
+		files = append(files, absPath)
+	}
+
+	sort.Strings(files)
+	return files, nil
+}
+
+// GetCommitsSince returns the non-merge commits in baseRef..HEAD, oldest first
+func (g *OSGitClient) GetCommitsSince(baseRef string) ([]Commit, error) {
+	if baseRef == "" {
+		return nil, fmt.Errorf("base ref cannot be empty")
+	}
+
+	root, err := g.output("rev-parse", "--show-toplevel")
+	if err != nil {
+		return nil, fmt.Errorf("failed to find repository root: %w", err)
+	}
+
+	// Each record starts with \x1e and separates hash, message and file list with \x1f
+	logOutput, err := g.output("log", "--reverse", "--no-merges", "--no-renames", "--name-only",
+		"--format=%x1e%H%x1f%B%x1f", baseRef+"..HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read log since %s: %w", baseRef, err)
+	}
+
+	var commits []Commit
+	for _, record := range strings.Split(logOutput, "\x1e") {
+		fields := strings.SplitN(record, "\x1f", 3)
+		if len(fields) != 3 {
+			continue
+		}
+
+		commit := Commit{
+			Hash:    strings.TrimSpace(fields[0]),
+			Message: strings.TrimSpace(fields[1]),
+		}
+		for _, line := range strings.Split(fields[2], "\n") {
+			line = strings.TrimSpace(line)
+			if line == "" {
+				continue
+			}
+			commit.Files = append(commit.Files, filepath.Join(root, filepath.FromSlash(line)))
+		}
+
+		commits = append(commits, commit)
+	}
+
+	return commits, nil
+}
+
+// output runs a git command and returns its trimmed stdout
+func (g *OSGitClient) output(args ...string) (string, error) {
+	cmd := exec.CommandContext(g.ctx, "git", args...)
+
+	var out, stderr bytes.Buffer
+	cmd.Stdout = &out
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("%w: %s", err, strings.TrimSpace(stderr.String()))
+	}
+
+	return strings.TrimSpace(out.String()), nil
+}
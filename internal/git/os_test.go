@@ -112,7 +112,7 @@ func TestOSGit_BasicTagOperations(t *testing.T) {
 	err = client.CreateTag("backend@v1.1.0", "Release 1.1.0")
 	require.NoError(t, err)
 
-	tag, err := client.GetLatestTag("backend")
+	tag, err := client.GetLatestTag("backend@v*")
 	require.NoError(t, err)
 	require.Equal(t, "backend@v1.1.0", tag)
 
@@ -465,3 +465,160 @@ func TestOSGit_TagAnnotations(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, message, annotation)
 }
+
+// TestOSGit_GetChangedFiles tests committed, uncommitted and untracked changes since a base branch
+func TestOSGit_GetChangedFiles(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	client, repoPath, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	t.Chdir(repoPath)
+
+	createBranch(t, repoPath, "feature")
+	require.NoError(t, os.MkdirAll(filepath.Join(repoPath, "backend"), 0755))
+	writeFile(t, repoPath, "backend/main.go", "package main")
+	runGitCmd(t, repoPath, "add", ".")
+	runGitCmd(t, repoPath, "commit", "-m", "Add backend")
+
+	writeFile(t, repoPath, "README.md", "# Changed")
+	writeFile(t, repoPath, "notes.txt", "untracked")
+
+	files, err := client.GetChangedFiles("main")
+	require.NoError(t, err)
+
+	root, err := filepath.EvalSymlinks(repoPath)
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		filepath.Join(root, "README.md"),
+		filepath.Join(root, "backend", "main.go"),
+		filepath.Join(root, "notes.txt"),
+	}, files)
+
+	// README.md exists on main, so it is modified rather than added
+	files, err = client.GetAddedFiles("main")
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		filepath.Join(root, "backend", "main.go"),
+		filepath.Join(root, "notes.txt"),
+	}, files)
+
+	// Untracked files outside the current directory are listed too
+	t.Chdir(filepath.Join(repoPath, "backend"))
+	files, err = client.GetChangedFiles("main")
+	require.NoError(t, err)
+	require.Equal(t, []string{
+		filepath.Join(root, "README.md"),
+		filepath.Join(root, "backend", "main.go"),
+		filepath.Join(root, "notes.txt"),
+	}, files)
+}
+
+// TestOSGit_GetRepositoryRoot tests finding the top level from a subdirectory
+func TestOSGit_GetRepositoryRoot(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	client, repoPath, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	sub := filepath.Join(repoPath, "go", "apps")
+	require.NoError(t, os.MkdirAll(sub, 0755))
+	t.Chdir(sub)
+
+	root, err := client.GetRepositoryRoot()
+	require.NoError(t, err)
+
+	want, err := filepath.EvalSymlinks(repoPath)
+	require.NoError(t, err)
+	require.Equal(t, want, root)
+}
+
+// TestOSGit_GetCommitsSince tests reading messages and touched files since a tag
+func TestOSGit_GetCommitsSince(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	client, repoPath, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	t.Chdir(repoPath)
+	runGitCmd(t, repoPath, "tag", "-a", "backend@v1.0.0", "-m", "Release 1.0.0")
+
+	writeFile(t, repoPath, "a.txt", "a")
+	runGitCmd(t, repoPath, "add", ".")
+	runGitCmd(t, repoPath, "commit", "-m", "feat(backend): add a\n\nLonger description.")
+
+	writeFile(t, repoPath, "b.txt", "b")
+	writeFile(t, repoPath, "README.md", "# Changed")
+	runGitCmd(t, repoPath, "add", ".")
+	runGitCmd(t, repoPath, "commit", "-m", "fix: add b")
+
+	commits, err := client.GetCommitsSince("backend@v1.0.0")
+	require.NoError(t, err)
+	require.Len(t, commits, 2)
+
+	root, err := filepath.EvalSymlinks(repoPath)
+	require.NoError(t, err)
+
+	require.Equal(t, "feat(backend): add a\n\nLonger description.", commits[0].Message)
+	require.Equal(t, []string{filepath.Join(root, "a.txt")}, commits[0].Files)
+	require.Len(t, commits[0].Hash, 40)
+
+	require.Equal(t, "fix: add b", commits[1].Message)
+	require.Equal(t, []string{filepath.Join(root, "README.md"), filepath.Join(root, "b.txt")}, commits[1].Files)
+}
+
+// TestOSGit_GetFileCreationCommitOfDeletedFile tests finding the commit that
+// added a file that was deleted since
+func TestOSGit_GetFileCreationCommitOfDeletedFile(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	client, repoPath, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	t.Chdir(repoPath)
+	require.NoError(t, os.MkdirAll(filepath.Join(repoPath, ".changeset"), 0755))
+	writeFile(t, repoPath, ".changeset/commit-abc1234.md", "---\nbackend: minor\n---\n\nAdd a")
+	runGitCmd(t, repoPath, "add", ".")
+	runGitCmd(t, repoPath, "commit", "-m", "chore: add changeset")
+	added, err := client.GetHeadCommit()
+	require.NoError(t, err)
+
+	runGitCmd(t, repoPath, "rm", "-q", ".changeset/commit-abc1234.md")
+	runGitCmd(t, repoPath, "commit", "-m", "chore: release")
+
+	commit, err := client.GetFileCreationCommit(filepath.Join(repoPath, ".changeset", "commit-abc1234.md"))
+	require.NoError(t, err)
+	require.Equal(t, added, commit)
+
+	commit, err = client.GetFileCreationCommit(filepath.Join(repoPath, ".changeset", "commit-def5678.md"))
+	require.NoError(t, err)
+	require.Empty(t, commit)
+}
+
+// TestOSGit_TagOrderingPastTenRCs tests semver ordering of release and RC tags
+func TestOSGit_TagOrderingPastTenRCs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test in short mode")
+	}
+
+	client, repoPath, cleanup := setupTestRepo(t)
+	defer cleanup()
+
+	t.Chdir(repoPath)
+
+	for _, tag := range []string{"backend@v1.2.0-rc2", "backend@v1.2.0-rc10", "backend@v1.2.0", "backend@v1.1.0"} {
+		require.NoError(t, client.CreateTag(tag, tag))
+	}
+
+	tags, err := client.GetTagsWithPrefix("backend@v*")
+	require.NoError(t, err)
+	require.Equal(t, []string{"backend@v1.2.0", "backend@v1.2.0-rc10", "backend@v1.2.0-rc2", "backend@v1.1.0"}, tags)
+}
@@ -0,0 +1,86 @@
+package git
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jakoblorz/go-changesets/internal/models"
+)
+
+// sortTagsDescending sorts project tags by semantic version, newest first.
+// Tags that cannot be parsed fall back to string comparison.
+func sortTagsDescending(tags []string) {
+	sort.SliceStable(tags, func(i, j int) bool {
+		return compareTagVersions(tags[i], tags[j])
+	})
+}
+
+// compareTagVersions compares two tag names by their semantic version
+// Returns true if tag i should come before tag j (descending order)
+func compareTagVersions(tagI, tagJ string) bool {
+	// Extract version from tags: "backend@v1.2.3" -> "v1.2.3"
+	versionI := extractVersionFromTag(tagI)
+	versionJ := extractVersionFromTag(tagJ)
+
+	// If we can't parse, fall back to string comparison
+	if versionI == "" || versionJ == "" {
+		return tagI > tagJ
+	}
+
+	// Parse as semantic versions
+	verI, errI := models.ParseVersion(versionI)
+	verJ, errJ := models.ParseVersion(versionJ)
+
+	if errI != nil || errJ != nil {
+		// Fall back to string comparison
+		return tagI > tagJ
+	}
+
+	// Use Version.Compare: returns 1 if verI > verJ
+	return verI.Compare(verJ) > 0
+}
+
+// extractVersionFromTag extracts version string from tag name
+// "backend@v1.2.3-rc0" -> "v1.2.3-rc0", "apps/backend/v1.2.3" -> "v1.2.3"
+func extractVersionFromTag(tag string) string {
+	if idx := strings.LastIndex(tag, "@"); idx != -1 {
+		return tag[idx+1:]
+	}
+
+	// Module path prefix tags; build metadata may not contain "/"
+	if idx := strings.LastIndex(tag, "/"); idx != -1 {
+		tag = tag[idx+1:]
+	}
+	if !strings.HasPrefix(tag, "v") {
+		return ""
+	}
+	return tag
+}
+
+// extractRCNumber extracts the RC number from a tag, ignoring build metadata
+func extractRCNumber(tag string) (int, error) {
+	// Find the -rc prefix
+	rcIdx := strings.Index(tag, "-rc")
+	if rcIdx == -1 {
+		return -1, nil // Not an RC tag
+	}
+
+	// Extract everything after "-rc", up to any build metadata
+	rcSuffix := tag[rcIdx+3:]
+	if idx := strings.Index(rcSuffix, "+"); idx != -1 {
+		rcSuffix = rcSuffix[:idx]
+	}
+	if rcSuffix == "" {
+		return -1, fmt.Errorf("invalid RC tag format: %s (expected -rc{number})", tag)
+	}
+
+	// Parse the number
+	num, err := strconv.Atoi(rcSuffix)
+	if err != nil {
+		return -1, fmt.Errorf("invalid RC number in tag %s: %w", tag, err)
+	}
+
+	return num, nil
+}
@@ -82,6 +82,21 @@ func (c *Client) CreateRelease(ctx context.Context, owner, repo string, req *Cre
 	return convertRelease(release), nil
 }
 
+func (c *Client) UpdateRelease(ctx context.Context, owner, repo string, id int64, req *UpdateReleaseRequest) (*Release, error) {
+	ghRelease := &github.RepositoryRelease{
+		TagName:    &req.TagName,
+		Name:       &req.Name,
+		Body:       &req.Body,
+		Prerelease: &req.Prerelease,
+	}
+
+	release, _, err := c.client.Repositories.EditRelease(ctx, owner, repo, id, ghRelease)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update release %d: %w", id, err)
+	}
+	return convertRelease(release), nil
+}
+
 func (c *Client) GetRepository(ctx context.Context, owner, repo string) (*Repository, error) {
 	repository, _, err := c.client.Repositories.Get(ctx, owner, repo)
 	if err != nil {
@@ -0,0 +1,106 @@
+package github
+
+import (
+	"context"
+	"fmt"
+)
+
+// DryRunClient passes all operations to the wrapped GitHubClient until it is
+// enabled. Once enabled, releases, pull request changes and branch deletions
+// are recorded instead of sent; reads still reach GitHub.
+type DryRunClient struct {
+	GitHubClient
+
+	enabled    bool
+	operations []string
+}
+
+// NewDryRunClient wraps client in a DryRunClient
+func NewDryRunClient(client GitHubClient) *DryRunClient {
+	return &DryRunClient{GitHubClient: client}
+}
+
+// Enable records all further write operations instead of sending them
+func (c *DryRunClient) Enable() {
+	c.enabled = true
+}
+
+// Operations returns the recorded operations, e.g. "create release auth@v1.2.0 in org/repo"
+func (c *DryRunClient) Operations() []string {
+	return append([]string(nil), c.operations...)
+}
+
+func (c *DryRunClient) record(format string, args ...any) {
+	c.operations = append(c.operations, fmt.Sprintf(format, args...))
+}
+
+func (c *DryRunClient) CreateRelease(ctx context.Context, owner, repo string, release *CreateReleaseRequest) (*Release, error) {
+	if !c.enabled {
+		return c.GitHubClient.CreateRelease(ctx, owner, repo, release)
+	}
+	c.record("create release %s in %s/%s", release.TagName, owner, repo)
+	return &Release{
+		TagName:    release.TagName,
+		Name:       release.Name,
+		Body:       release.Body,
+		Draft:      release.Draft,
+		Prerelease: release.Prerelease,
+	}, nil
+}
+
+func (c *DryRunClient) UpdateRelease(ctx context.Context, owner, repo string, id int64, release *UpdateReleaseRequest) (*Release, error) {
+	if !c.enabled {
+		return c.GitHubClient.UpdateRelease(ctx, owner, repo, id, release)
+	}
+	c.record("update release %d to %s in %s/%s", id, release.TagName, owner, repo)
+	return &Release{
+		ID:         id,
+		TagName:    release.TagName,
+		Name:       release.Name,
+		Body:       release.Body,
+		Prerelease: release.Prerelease,
+	}, nil
+}
+
+func (c *DryRunClient) CreatePullRequest(ctx context.Context, owner, repo string, req *CreatePullRequestRequest) (*PullRequest, error) {
+	if !c.enabled {
+		return c.GitHubClient.CreatePullRequest(ctx, owner, repo, req)
+	}
+	c.record("create pull request %q (%s -> %s) in %s/%s", req.Title, req.Head, req.Base, owner, repo)
+	return &PullRequest{
+		Title: req.Title,
+		Body:  req.Body,
+		Head:  req.Head,
+		Base:  req.Base,
+		State: "open",
+	}, nil
+}
+
+func (c *DryRunClient) UpdatePullRequest(ctx context.Context, owner, repo string, number int, req *UpdatePullRequestRequest) (*PullRequest, error) {
+	if !c.enabled {
+		return c.GitHubClient.UpdatePullRequest(ctx, owner, repo, number, req)
+	}
+	c.record("update pull request #%d %q in %s/%s", number, req.Title, owner, repo)
+	return &PullRequest{
+		Number: number,
+		Title:  req.Title,
+		Body:   req.Body,
+		State:  "open",
+	}, nil
+}
+
+func (c *DryRunClient) ClosePullRequest(ctx context.Context, owner, repo string, number int) error {
+	if !c.enabled {
+		return c.GitHubClient.ClosePullRequest(ctx, owner, repo, number)
+	}
+	c.record("close pull request #%d in %s/%s", number, owner, repo)
+	return nil
+}
+
+func (c *DryRunClient) DeleteBranch(ctx context.Context, owner, repo, branch string) error {
+	if !c.enabled {
+		return c.GitHubClient.DeleteBranch(ctx, owner, repo, branch)
+	}
+	c.record("delete branch %s in %s/%s", branch, owner, repo)
+	return nil
+}
@@ -11,6 +11,7 @@ type GitHubClient interface {
 	GetLatestRelease(ctx context.Context, owner, repo string) (*Release, error)
 	GetReleaseByTag(ctx context.Context, owner, repo, tag string) (*Release, error)
 	CreateRelease(ctx context.Context, owner, repo string, release *CreateReleaseRequest) (*Release, error)
+	UpdateRelease(ctx context.Context, owner, repo string, id int64, release *UpdateReleaseRequest) (*Release, error)
 
 	// Repository operations
 	GetRepository(ctx context.Context, owner, repo string) (*Repository, error)
@@ -62,6 +63,14 @@ type CreateReleaseRequest struct {
 	TargetCommitish string
 }
 
+// UpdateReleaseRequest represents a request to update a release
+type UpdateReleaseRequest struct {
+	TagName    string
+	Name       string
+	Body       string
+	Prerelease bool
+}
+
 // Repository represents a GitHub repository
 type Repository struct {
 	Owner         string
@@ -21,6 +21,7 @@ type MockClient struct {
 	GetLatestReleaseError         error
 	GetReleaseByTagError          error
 	CreateReleaseError            error
+	UpdateReleaseError            error
 	GetRepositoryError            error
 	GetPullRequestError           error
 	GetPullRequestByHeadError     error
@@ -142,6 +143,28 @@ func (m *MockClient) CreateRelease(ctx context.Context, owner, repo string, req
 	return release, nil
 }
 
+func (m *MockClient) UpdateRelease(ctx context.Context, owner, repo string, id int64, req *UpdateReleaseRequest) (*Release, error) {
+	if m.UpdateReleaseError != nil {
+		return nil, m.UpdateReleaseError
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := fmt.Sprintf("%s/%s", owner, repo)
+	for _, r := range m.releases[key] {
+		if r.ID == id {
+			r.TagName = req.TagName
+			r.Name = req.Name
+			r.Body = req.Body
+			r.Prerelease = req.Prerelease
+			return r, nil
+		}
+	}
+
+	return nil, fmt.Errorf("release %d not found", id)
+}
+
 func (m *MockClient) GetRepository(ctx context.Context, owner, repo string) (*Repository, error) {
 	if m.GetRepositoryError != nil {
 		return nil, m.GetRepositoryError
@@ -2,8 +2,11 @@ package github
 
 import (
 	"encoding/json"
-	"os"
+	"errors"
+	"io/fs"
 	"time"
+
+	"github.com/jakoblorz/go-changesets/internal/filesystem"
 )
 
 type PRMapping struct {
@@ -26,11 +29,10 @@ func NewPRMapping() *PRMapping {
 	}
 }
 
-func ReadPRMapping(path string) (*PRMapping, error) {
-	// TODO: make filesystem.FileSystem compatible
-	data, err := os.ReadFile(path)
+func ReadPRMapping(fsys filesystem.FileSystem, path string) (*PRMapping, error) {
+	data, err := fsys.ReadFile(path)
 	if err != nil {
-		if os.IsNotExist(err) {
+		if errors.Is(err, fs.ErrNotExist) {
 			return NewPRMapping(), nil
 		}
 		return nil, err
@@ -52,13 +54,13 @@ func ReadPRMapping(path string) (*PRMapping, error) {
 	return &mapping, nil
 }
 
-func (m *PRMapping) Write(path string) error {
+func (m *PRMapping) Write(fsys filesystem.FileSystem, path string) error {
 	m.UpdatedAt = time.Now().Format(time.RFC3339)
 	data, err := json.MarshalIndent(m, "", "  ")
 	if err != nil {
 		return err
 	}
-	return os.WriteFile(path, data, 0644)
+	return fsys.WriteFile(path, data, 0644)
 }
 
 func (m *PRMapping) Set(project string, entry PullRequestInfo) {
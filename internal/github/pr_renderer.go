@@ -53,8 +53,11 @@ var (
 	templateCacheLock sync.Mutex
 )
 
-func getBodyTemplate(fs filesystem.FileSystem, projectRoot string) (*template.Template, error) {
-	path := findCustomBodyTemplate(fs, projectRoot)
+func getBodyTemplate(fs filesystem.FileSystem, projectRoot, templatePath string) (*template.Template, error) {
+	path := templatePath
+	if path == "" {
+		path = findCustomBodyTemplate(fs, projectRoot)
+	}
 	cacheKey := path
 	if cacheKey == "" {
 		cacheKey = "__default_pr-description__"
@@ -107,8 +110,11 @@ func findCustomBodyTemplate(fs filesystem.FileSystem, start string) string {
 
 const DefaultTitleTemplate = "🚀 Release {{.Project}} v{{.Version}}"
 
-func getTitleTemplate(fs filesystem.FileSystem, projectRoot string) (*template.Template, error) {
-	path := findCustomTitleTemplate(fs, projectRoot)
+func getTitleTemplate(fs filesystem.FileSystem, projectRoot, templatePath string) (*template.Template, error) {
+	path := templatePath
+	if path == "" {
+		path = findCustomTitleTemplate(fs, projectRoot)
+	}
 	cacheKey := path
 	if cacheKey == "" {
 		cacheKey = "__default_pr-title__"
@@ -159,13 +165,24 @@ func findCustomTitleTemplate(fs filesystem.FileSystem, start string) string {
 }
 
 type PRRenderer struct {
-	fs filesystem.FileSystem
+	fs                filesystem.FileSystem
+	titleTemplatePath string
+	bodyTemplatePath  string
 }
 
 func NewPRRenderer(fs filesystem.FileSystem) *PRRenderer {
 	return &PRRenderer{fs: fs}
 }
 
+// WithTemplates uses the given title and body templates instead of searching
+// for .changeset/pr-title.tmpl and .changeset/pr-description.tmpl. Empty
+// paths keep the search.
+func (p *PRRenderer) WithTemplates(titlePath, bodyPath string) *PRRenderer {
+	p.titleTemplatePath = titlePath
+	p.bodyTemplatePath = bodyPath
+	return p
+}
+
 func (p *PRRenderer) RenderTitle(data TemplateData, projectRoot string) (string, error) {
 	root := projectRoot
 	if root == "" {
@@ -175,7 +192,7 @@ func (p *PRRenderer) RenderTitle(data TemplateData, projectRoot string) (string,
 		}
 	}
 
-	tmpl, err := getTitleTemplate(p.fs, root)
+	tmpl, err := getTitleTemplate(p.fs, root, p.titleTemplatePath)
 	if err != nil {
 		return "", err
 	}
@@ -197,7 +214,7 @@ func (p *PRRenderer) RenderBody(data TemplateData, projectRoot string) (string,
 		}
 	}
 
-	tmpl, err := getBodyTemplate(p.fs, root)
+	tmpl, err := getBodyTemplate(p.fs, root, p.bodyTemplatePath)
 	if err != nil {
 		return "", err
 	}
@@ -0,0 +1,274 @@
+package gomod
+
+import (
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/jakoblorz/go-changesets/internal/filesystem"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+)
+
+// MajorVersionPath returns modulePath with the major version suffix required
+// for major, e.g. ("github.com/org/auth", 2) -> "github.com/org/auth/v2" and
+// ("github.com/org/auth/v2", 3) -> "github.com/org/auth/v3". Majors 0 and 1
+// have no suffix.
+func MajorVersionPath(modulePath string, major int) (string, error) {
+	prefix, pathMajor, ok := module.SplitPathVersion(modulePath)
+	if !ok {
+		return "", fmt.Errorf("invalid module path: %s", modulePath)
+	}
+	if strings.HasPrefix(pathMajor, ".") {
+		return "", fmt.Errorf("gopkg.in module paths are not supported: %s", modulePath)
+	}
+
+	if major < 2 {
+		return prefix, nil
+	}
+	return fmt.Sprintf("%s/v%d", prefix, major), nil
+}
+
+// Migration describes a rewritten module path.
+type Migration struct {
+	OldPath string
+	NewPath string
+
+	// ChangedFiles are the go.mod and .go files that were rewritten, sorted
+	ChangedFiles []string
+}
+
+// Migrator rewrites module paths in go.mod files and import paths in Go sources.
+type Migrator struct {
+	fs filesystem.FileSystem
+}
+
+// NewMigrator creates a new module path migrator
+func NewMigrator(fs filesystem.FileSystem) *Migrator {
+	return &Migrator{fs: fs}
+}
+
+// Migrate changes the module path of the module in moduleDir to newPath. The
+// module's own imports are rewritten, and so are the require/replace
+// directives and imports of every other module in workspaceModuleDirs that
+// depends on it. version is the version required by dependents, e.g. "v2.0.0".
+func (m *Migrator) Migrate(moduleDir, newPath, version string, workspaceModuleDirs []string) (*Migration, error) {
+	goModPath := filepath.Join(moduleDir, "go.mod")
+	modFile, err := m.readModFile(goModPath)
+	if err != nil {
+		return nil, err
+	}
+	if modFile.Module == nil {
+		return nil, fmt.Errorf("%s has no module directive", goModPath)
+	}
+
+	migration := &Migration{
+		OldPath: modFile.Module.Mod.Path,
+		NewPath: newPath,
+	}
+	if migration.OldPath == newPath {
+		return migration, nil
+	}
+
+	if err := modFile.AddModuleStmt(newPath); err != nil {
+		return nil, fmt.Errorf("failed to set module path in %s: %w", goModPath, err)
+	}
+	if err := m.writeModFile(goModPath, modFile); err != nil {
+		return nil, err
+	}
+	migration.ChangedFiles = append(migration.ChangedFiles, goModPath)
+
+	changed, err := m.rewriteImports(moduleDir, migration.OldPath, newPath)
+	if err != nil {
+		return nil, err
+	}
+	migration.ChangedFiles = append(migration.ChangedFiles, changed...)
+
+	for _, dir := range workspaceModuleDirs {
+		if filepath.Clean(dir) == filepath.Clean(moduleDir) {
+			continue
+		}
+
+		changed, err := m.migrateDependent(dir, migration.OldPath, newPath, version)
+		if err != nil {
+			return nil, err
+		}
+		migration.ChangedFiles = append(migration.ChangedFiles, changed...)
+	}
+
+	sort.Strings(migration.ChangedFiles)
+	return migration, nil
+}
+
+// migrateDependent updates a module that requires or replaces oldPath.
+// Other modules are left untouched.
+func (m *Migrator) migrateDependent(moduleDir, oldPath, newPath, version string) ([]string, error) {
+	goModPath := filepath.Join(moduleDir, "go.mod")
+	modFile, err := m.readModFile(goModPath)
+	if err != nil {
+		return nil, err
+	}
+
+	requires := false
+	for _, req := range modFile.Require {
+		if req.Mod.Path != oldPath {
+			continue
+		}
+		requires = true
+		indirect := req.Indirect
+		if err := modFile.DropRequire(oldPath); err != nil {
+			return nil, fmt.Errorf("failed to update %s: %w", goModPath, err)
+		}
+		modFile.AddNewRequire(newPath, version, indirect)
+		break
+	}
+
+	for _, rep := range modFile.Replace {
+		if rep.Old.Path != oldPath {
+			continue
+		}
+		requires = true
+		oldVersion, newModPath, newModVersion := rep.Old.Version, rep.New.Path, rep.New.Version
+		if err := modFile.DropReplace(oldPath, oldVersion); err != nil {
+			return nil, fmt.Errorf("failed to update %s: %w", goModPath, err)
+		}
+		if err := modFile.AddReplace(newPath, oldVersion, newModPath, newModVersion); err != nil {
+			return nil, fmt.Errorf("failed to update %s: %w", goModPath, err)
+		}
+	}
+
+	if !requires {
+		return nil, nil
+	}
+
+	if err := m.writeModFile(goModPath, modFile); err != nil {
+		return nil, err
+	}
+
+	changed, err := m.rewriteImports(moduleDir, oldPath, newPath)
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]string{goModPath}, changed...), nil
+}
+
+// rewriteImports rewrites imports of oldPath (and its packages) to newPath in
+// all .go files of the module, skipping nested modules, vendor and testdata.
+func (m *Migrator) rewriteImports(moduleDir, oldPath, newPath string) ([]string, error) {
+	var changed []string
+
+	err := m.fs.WalkDir(moduleDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() {
+			if path == moduleDir {
+				return nil
+			}
+			name := d.Name()
+			if name == "vendor" || name == "testdata" || strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") {
+				return filepath.SkipDir
+			}
+			if m.fs.Exists(filepath.Join(path, "go.mod")) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !strings.HasSuffix(path, ".go") {
+			return nil
+		}
+
+		rewritten, err := m.rewriteFileImports(path, oldPath, newPath)
+		if err != nil {
+			return err
+		}
+		if rewritten {
+			changed = append(changed, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to rewrite imports in %s: %w", moduleDir, err)
+	}
+
+	return changed, nil
+}
+
+// rewriteFileImports replaces matching import path literals in place, keeping
+// the rest of the file byte-for-byte unchanged.
+func (m *Migrator) rewriteFileImports(path, oldPath, newPath string) (bool, error) {
+	src, err := m.fs.ReadFile(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, src, parser.ImportsOnly|parser.ParseComments)
+	if err != nil {
+		return false, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	var out []byte
+	last := 0
+	for _, spec := range file.Imports {
+		importPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			continue
+		}
+		if importPath != oldPath && !strings.HasPrefix(importPath, oldPath+"/") {
+			continue
+		}
+
+		start := fset.Position(spec.Path.Pos()).Offset
+		end := fset.Position(spec.Path.End()).Offset
+
+		out = append(out, src[last:start]...)
+		out = append(out, strconv.Quote(newPath+importPath[len(oldPath):])...)
+		last = end
+	}
+
+	if out == nil {
+		return false, nil
+	}
+	out = append(out, src[last:]...)
+
+	if err := m.fs.WriteFile(path, out, 0644); err != nil {
+		return false, fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return true, nil
+}
+
+func (m *Migrator) readModFile(path string) (*modfile.File, error) {
+	data, err := m.fs.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	modFile, err := modfile.Parse(path, data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return modFile, nil
+}
+
+func (m *Migrator) writeModFile(path string, modFile *modfile.File) error {
+	modFile.Cleanup()
+
+	data, err := modFile.Format()
+	if err != nil {
+		return fmt.Errorf("failed to format %s: %w", path, err)
+	}
+
+	if err := m.fs.WriteFile(path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package gomod
+
+import (
+	"testing"
+
+	"github.com/jakoblorz/go-changesets/internal/filesystem"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMajorVersionPath(t *testing.T) {
+	tests := []struct {
+		path     string
+		major    int
+		expected string
+	}{
+		{"github.com/org/auth", 1, "github.com/org/auth"},
+		{"github.com/org/auth", 2, "github.com/org/auth/v2"},
+		{"github.com/org/auth/v2", 3, "github.com/org/auth/v3"},
+		{"github.com/org/auth/v2", 2, "github.com/org/auth/v2"},
+		{"example.com/tools", 10, "example.com/tools/v10"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			got, err := MajorVersionPath(tt.path, tt.major)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, got)
+		})
+	}
+
+	_, err := MajorVersionPath("gopkg.in/yaml.v2", 3)
+	require.Error(t, err)
+}
+
+func TestMigrator_Migrate(t *testing.T) {
+	fs := filesystem.NewMockFileSystem()
+	fs.AddFile("/ws/auth/go.mod", []byte("module github.com/org/auth\n\ngo 1.24\n"))
+	fs.AddFile("/ws/auth/auth.go", []byte(`package auth
+
+import "github.com/org/auth/internal/token"
+
+func New() string { return token.New() }
+`))
+	fs.AddFile("/ws/auth/internal/token/token.go", []byte("package token\n\nfunc New() string { return \"t\" }\n"))
+	fs.AddFile("/ws/auth/testdata/fixture.go", []byte("package fixture\n\nimport _ \"github.com/org/auth\"\n"))
+
+	fs.AddFile("/ws/api/go.mod", []byte(`module github.com/org/api
+
+go 1.24
+
+require (
+	github.com/org/auth v1.4.0
+	github.com/org/authz v0.1.0 // indirect
+)
+
+replace github.com/org/auth => ../auth
+`))
+	fs.AddFile("/ws/api/main.go", []byte(`package main
+
+import (
+	"fmt"
+
+	// Keep this comment
+	"github.com/org/auth"
+	tok "github.com/org/auth/internal/token"
+	"github.com/org/authz"
+)
+
+func main() { fmt.Println(auth.New(), tok.New(), authz.X) }
+`))
+
+	fs.AddFile("/ws/web/go.mod", []byte("module github.com/org/web\n\ngo 1.24\n"))
+	fs.AddFile("/ws/web/main.go", []byte("package main\n"))
+
+	migration, err := NewMigrator(fs).Migrate("/ws/auth", "github.com/org/auth/v2", "v2.0.0", []string{"/ws/auth", "/ws/api", "/ws/web"})
+	require.NoError(t, err)
+	require.Equal(t, "github.com/org/auth", migration.OldPath)
+	require.Equal(t, []string{
+		"/ws/api/go.mod",
+		"/ws/api/main.go",
+		"/ws/auth/auth.go",
+		"/ws/auth/go.mod",
+	}, migration.ChangedFiles)
+
+	requireFile(t, fs, "/ws/auth/go.mod", "module github.com/org/auth/v2\n\ngo 1.24\n")
+	requireFile(t, fs, "/ws/auth/auth.go", `package auth
+
+import "github.com/org/auth/v2/internal/token"
+
+func New() string { return token.New() }
+`)
+	requireFile(t, fs, "/ws/auth/testdata/fixture.go", "package fixture\n\nimport _ \"github.com/org/auth\"\n")
+
+	requireFile(t, fs, "/ws/api/go.mod", `module github.com/org/api
+
+go 1.24
+
+require (
+	github.com/org/authz v0.1.0 // indirect
+	github.com/org/auth/v2 v2.0.0
+)
+
+replace github.com/org/auth/v2 => ../auth
+`)
+	requireFile(t, fs, "/ws/api/main.go", `package main
+
+import (
+	"fmt"
+
+	// Keep this comment
+	"github.com/org/auth/v2"
+	tok "github.com/org/auth/v2/internal/token"
+	"github.com/org/authz"
+)
+
+func main() { fmt.Println(auth.New(), tok.New(), authz.X) }
+`)
+
+	requireFile(t, fs, "/ws/web/go.mod", "module github.com/org/web\n\ngo 1.24\n")
+}
+
+func requireFile(t *testing.T, fs *filesystem.MockFileSystem, path, expected string) {
+	t.Helper()
+	data, err := fs.ReadFile(path)
+	require.NoError(t, err)
+	require.Equal(t, expected, string(data))
+}
@@ -0,0 +1,43 @@
+package models
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Category is a kind of change a changeset can declare with "type", e.g.
+// "security". Changelog templates can group changes by category.
+type Category struct {
+	// Type is the value used in changeset frontmatter
+	Type string `json:"type"`
+
+	// Title is the changelog section title, e.g. "Security"
+	Title string `json:"title"`
+}
+
+// DefaultCategories are the categories used unless configured otherwise
+var DefaultCategories = []Category{
+	{Type: "feat", Title: "Features"},
+	{Type: "fix", Title: "Bug Fixes"},
+	{Type: "security", Title: "Security"},
+	{Type: "deprecation", Title: "Deprecations"},
+}
+
+// ValidateCategory checks that changeType is one of categories. An empty
+// type is always valid.
+func ValidateCategory(changeType string, categories []Category) error {
+	if changeType == "" {
+		return nil
+	}
+	for _, category := range categories {
+		if category.Type == changeType {
+			return nil
+		}
+	}
+
+	types := make([]string, 0, len(categories))
+	for _, category := range categories {
+		types = append(types, category.Type)
+	}
+	return fmt.Errorf("unknown change type %q (available: %s)", changeType, strings.Join(types, ", "))
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"fmt"
+	"sort"
 )
 
 // BumpType represents the type of version bump
@@ -71,6 +72,12 @@ type Changeset struct {
 
 	// PR contains optional pull request metadata (populated via GitHub API)
 	PR *PullRequest
+
+	// Type is the optional category of the change, e.g. "feat" or "security"
+	Type string
+
+	// ProjectTypes overrides Type for single projects
+	ProjectTypes map[string]string
 }
 
 // NewChangeset creates a new Changeset instance
@@ -88,6 +95,34 @@ func (c *Changeset) GetBumpForProject(projectName string) (BumpType, bool) {
 	return bump, exists
 }
 
+// GetTypeForProject returns the category of the change for a specific project
+func (c *Changeset) GetTypeForProject(projectName string) string {
+	if changeType, ok := c.ProjectTypes[projectName]; ok {
+		return changeType
+	}
+	return c.Type
+}
+
+// ValidateCategories checks that the type and per-project types of the
+// changeset are among categories
+func (c *Changeset) ValidateCategories(categories []Category) error {
+	if err := ValidateCategory(c.Type, categories); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(c.ProjectTypes))
+	for name := range c.ProjectTypes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if err := ValidateCategory(c.ProjectTypes[name], categories); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
 // AffectsProject checks if this changeset affects a specific project
 func (c *Changeset) AffectsProject(projectName string) bool {
 	_, exists := c.Projects[projectName]
@@ -23,12 +23,15 @@ const (
 
 	// FilterUnchanged selects projects with no changesets
 	FilterUnchanged FilterType = "unchanged"
+
+	// FilterGrouped selects projects in a fixed or linked version group
+	FilterGrouped FilterType = "grouped"
 )
 
 // IsValid checks if the filter type is valid
 func (f FilterType) IsValid() bool {
 	switch f {
-	case FilterAll, FilterOpenChangesets, FilterOutdatedVersions, FilterHasVersion, FilterNoVersion, FilterUnchanged:
+	case FilterAll, FilterOpenChangesets, FilterOutdatedVersions, FilterHasVersion, FilterNoVersion, FilterUnchanged, FilterGrouped:
 		return true
 	default:
 		return false
@@ -44,7 +47,7 @@ func (f FilterType) String() string {
 func ParseFilterType(s string) (FilterType, error) {
 	ft := FilterType(s)
 	if !ft.IsValid() {
-		return "", fmt.Errorf("invalid filter type: %s (must be all, open-changesets, outdated-versions, has-version, no-version, or unchanged)", s)
+		return "", fmt.Errorf("invalid filter type: %s (must be all, open-changesets, outdated-versions, has-version, no-version, unchanged, or grouped)", s)
 	}
 	return ft, nil
 }
@@ -65,6 +68,8 @@ func (f FilterType) MatchesContext(ctx *ProjectContext) bool {
 	case FilterUnchanged:
 		// No changesets (no pending changes)
 		return !ctx.HasChangesets
+	case FilterGrouped:
+		return ctx.Group != nil
 	default:
 		return false
 	}
@@ -0,0 +1,585 @@
+package models
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Filter selects projects by their ProjectContext. FilterType and parsed
+// filter expressions are filters.
+type Filter interface {
+	MatchesContext(ctx *ProjectContext) bool
+}
+
+// ParseFilter parses a filter expression, e.g.
+//
+//	type==go && bump>=minor && path=apps/** && !name=legacy-*
+//
+// An expression combines conditions with && (and), || (or), ! (not) and
+// parentheses. A condition is a filter name such as open-changesets, or a
+// comparison of a field with a value:
+//
+//	name, path, type, group, category  = or == (glob match), !=
+//	                                   (group is the kind: fixed or linked)
+//	bump                               ==, !=, <, <=, >, >= (none < patch < minor < major)
+//	version, latest                    ==, !=, <, <=, >, >= (semantic versions)
+//	changesets                         ==, !=, <, <=, >, >= (number of changesets)
+//	changed-since                      = or == (a git ref, see ChangedSinceRefs)
+//
+// Values may be quoted with " or '. A comma-separated list of filter names,
+// e.g. open-changesets,outdated-versions, matches projects matching all of
+// them.
+func ParseFilter(expr string) (Filter, error) {
+	if filter, ok := parseFilterNames(expr); ok {
+		return filter, nil
+	}
+
+	p := &filterParser{input: expr}
+	if err := p.tokenize(); err != nil {
+		return nil, commaHint(expr, err)
+	}
+
+	if len(p.tokens) == 1 {
+		return nil, p.errorAt(p.tokens[0], "empty filter")
+	}
+
+	filter, err := p.parseOr()
+	if err != nil {
+		return nil, commaHint(expr, err)
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return nil, commaHint(expr, p.errorAt(tok, fmt.Sprintf("unexpected %s", tok)))
+	}
+	return filter, nil
+}
+
+// parseFilterNames parses a comma-separated list of filter names, the
+// filter syntax before expressions
+func parseFilterNames(expr string) (Filter, bool) {
+	if !strings.Contains(expr, ",") {
+		return nil, false
+	}
+
+	filters := andFilter{}
+	for _, name := range strings.Split(expr, ",") {
+		filter := FilterType(strings.TrimSpace(name))
+		if !filter.IsValid() {
+			return nil, false
+		}
+		filters = append(filters, filter)
+	}
+	return filters, true
+}
+
+// commaHint points to && if an invalid filter contains a comma
+func commaHint(expr string, err error) error {
+	if !strings.Contains(expr, ",") {
+		return err
+	}
+	return fmt.Errorf("%w (combine filters with && instead of commas)", err)
+}
+
+// AllFilters returns a filter matching projects that match all filters
+func AllFilters(filters ...Filter) Filter {
+	return andFilter(filters)
+}
+
+type andFilter []Filter
+
+func (f andFilter) MatchesContext(ctx *ProjectContext) bool {
+	for _, filter := range f {
+		if !filter.MatchesContext(ctx) {
+			return false
+		}
+	}
+	return true
+}
+
+type orFilter []Filter
+
+func (f orFilter) MatchesContext(ctx *ProjectContext) bool {
+	for _, filter := range f {
+		if filter.MatchesContext(ctx) {
+			return true
+		}
+	}
+	return false
+}
+
+type notFilter struct {
+	filter Filter
+}
+
+func (f notFilter) MatchesContext(ctx *ProjectContext) bool {
+	return !f.filter.MatchesContext(ctx)
+}
+
+// comparisonFilter compares a field of the context with a value
+type comparisonFilter struct {
+	field string
+	value string
+	match func(ctx *ProjectContext) bool
+}
+
+func (f comparisonFilter) MatchesContext(ctx *ProjectContext) bool {
+	return f.match(ctx)
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenWord
+	tokenString
+	tokenOperator
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenLParen
+	tokenRParen
+)
+
+type filterToken struct {
+	kind  tokenKind
+	text  string
+	value string
+	pos   int
+}
+
+func (t filterToken) String() string {
+	if t.kind == tokenEOF {
+		return "end of filter"
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+type filterParser struct {
+	input  string
+	tokens []filterToken
+	next   int
+}
+
+// wordBreaks are the characters that end a word
+const wordBreaks = " \t\n()!&|=<>\"'"
+
+func (p *filterParser) tokenize() error {
+	input := p.input
+	for i := 0; i < len(input); {
+		c := input[i]
+		switch {
+		case c == ' ' || c == '\t' || c == '\n':
+			i++
+		case c == '(':
+			p.tokens = append(p.tokens, filterToken{kind: tokenLParen, text: "(", pos: i})
+			i++
+		case c == ')':
+			p.tokens = append(p.tokens, filterToken{kind: tokenRParen, text: ")", pos: i})
+			i++
+		case strings.HasPrefix(input[i:], "&&"):
+			p.tokens = append(p.tokens, filterToken{kind: tokenAnd, text: "&&", pos: i})
+			i += 2
+		case strings.HasPrefix(input[i:], "||"):
+			p.tokens = append(p.tokens, filterToken{kind: tokenOr, text: "||", pos: i})
+			i += 2
+		case c == '&' || c == '|':
+			return p.errorAt(filterToken{pos: i}, fmt.Sprintf("unexpected %q (use %c%c)", c, c, c))
+		case c == '!' && !strings.HasPrefix(input[i:], "!="):
+			p.tokens = append(p.tokens, filterToken{kind: tokenNot, text: "!", pos: i})
+			i++
+		case strings.ContainsRune("!=<>", rune(c)):
+			op := input[i : i+1]
+			if i+1 < len(input) && input[i+1] == '=' {
+				op = input[i : i+2]
+			}
+			p.tokens = append(p.tokens, filterToken{kind: tokenOperator, text: op, value: op, pos: i})
+			i += len(op)
+		case c == '"' || c == '\'':
+			end := strings.IndexByte(input[i+1:], c)
+			if end == -1 {
+				return p.errorAt(filterToken{pos: i}, "unterminated quoted value")
+			}
+			text := input[i : i+end+2]
+			p.tokens = append(p.tokens, filterToken{kind: tokenString, text: text, value: text[1 : len(text)-1], pos: i})
+			i += len(text)
+		default:
+			end := strings.IndexAny(input[i:], wordBreaks)
+			if end == -1 {
+				end = len(input) - i
+			}
+			text := input[i : i+end]
+			p.tokens = append(p.tokens, filterToken{kind: tokenWord, text: text, value: text, pos: i})
+			i += end
+		}
+	}
+	p.tokens = append(p.tokens, filterToken{kind: tokenEOF, pos: len(input)})
+	return nil
+}
+
+func (p *filterParser) peek() filterToken {
+	return p.tokens[p.next]
+}
+
+func (p *filterParser) advance() filterToken {
+	tok := p.tokens[p.next]
+	if tok.kind != tokenEOF {
+		p.next++
+	}
+	return tok
+}
+
+func (p *filterParser) errorAt(tok filterToken, message string) error {
+	return fmt.Errorf("invalid filter %q: %s at column %d", p.input, message, tok.pos+1)
+}
+
+func (p *filterParser) parseOr() (Filter, error) {
+	filters := orFilter{}
+	for {
+		filter, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+
+		if p.peek().kind != tokenOr {
+			break
+		}
+		p.advance()
+	}
+
+	if len(filters) == 1 {
+		return filters[0], nil
+	}
+	return filters, nil
+}
+
+func (p *filterParser) parseAnd() (Filter, error) {
+	filters := andFilter{}
+	for {
+		filter, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		filters = append(filters, filter)
+
+		if p.peek().kind != tokenAnd {
+			break
+		}
+		p.advance()
+	}
+
+	if len(filters) == 1 {
+		return filters[0], nil
+	}
+	return filters, nil
+}
+
+func (p *filterParser) parseUnary() (Filter, error) {
+	if p.peek().kind == tokenNot {
+		p.advance()
+		filter, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notFilter{filter: filter}, nil
+	}
+	return p.parsePrimary()
+}
+
+func (p *filterParser) parsePrimary() (Filter, error) {
+	tok := p.advance()
+	switch tok.kind {
+	case tokenLParen:
+		filter, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.peek(); closing.kind != tokenRParen {
+			return nil, p.errorAt(closing, fmt.Sprintf("expected \")\" but found %s", closing))
+		}
+		p.advance()
+		return filter, nil
+	case tokenWord:
+		if p.peek().kind == tokenOperator {
+			return p.parseComparison(tok)
+		}
+		filterType := FilterType(tok.value)
+		if !filterType.IsValid() {
+			if _, ok := filterFields[tok.value]; ok {
+				return nil, p.errorAt(p.peek(), fmt.Sprintf("expected an operator after %s", tok))
+			}
+			return nil, p.errorAt(tok, fmt.Sprintf("unknown filter %s (filters: %s; fields: %s)",
+				tok, strings.Join(filterTypeNames(), ", "), strings.Join(filterFieldNames(), ", ")))
+		}
+		return filterType, nil
+	default:
+		return nil, p.errorAt(tok, fmt.Sprintf("expected a condition but found %s", tok))
+	}
+}
+
+func (p *filterParser) parseComparison(field filterToken) (Filter, error) {
+	compile, ok := filterFields[field.value]
+	if !ok {
+		return nil, p.errorAt(field, fmt.Sprintf("unknown field %s (fields: %s)", field, strings.Join(filterFieldNames(), ", ")))
+	}
+
+	op := p.advance()
+	value := p.advance()
+	if value.kind != tokenWord && value.kind != tokenString {
+		return nil, p.errorAt(value, fmt.Sprintf("expected a value after %s but found %s", op, value))
+	}
+
+	match, err := compile(op.value, value.value)
+	if err != nil {
+		return nil, p.errorAt(op, fmt.Sprintf("%s%s%s: %v", field.value, op.value, value.value, err))
+	}
+	return comparisonFilter{field: field.value, value: value.value, match: match}, nil
+}
+
+// filterFields compile a comparison of a field with a value
+var filterFields = map[string]func(op, value string) (func(*ProjectContext) bool, error){
+	"name": globField(func(ctx *ProjectContext) []string { return []string{ctx.Project} }),
+	"type": globField(func(ctx *ProjectContext) []string { return []string{string(ctx.Type)} }),
+	"group": globField(func(ctx *ProjectContext) []string {
+		if ctx.Group == nil {
+			return []string{""}
+		}
+		return []string{string(ctx.Group.Kind)}
+	}),
+	"category": globField(func(ctx *ProjectContext) []string {
+		types := make([]string, 0, len(ctx.Changesets))
+		for _, cs := range ctx.Changesets {
+			types = append(types, cs.Type)
+		}
+		return types
+	}),
+	"path":       compilePathField,
+	"bump":       compileBumpField,
+	"version":    versionField(func(ctx *ProjectContext) string { return ctx.CurrentVersion }),
+	"latest":     versionField(func(ctx *ProjectContext) string { return ctx.LatestTag }),
+	"changesets": compileChangesetsField,
+
+	// changed-since matches projects with files changed since the ref, or
+	// with changed dependencies. The changes have to be set on the context
+	// for the refs returned by ChangedSinceRefs.
+	changedSinceField: compileChangedSinceField,
+}
+
+const changedSinceField = "changed-since"
+
+// ChangedSinceRefs returns the git refs of the changed-since conditions of
+// filter, sorted and without duplicates. The contexts matched against filter
+// need ChangedSince, ChangedFiles and ChangedDependencies set for these refs.
+func ChangedSinceRefs(filter Filter) []string {
+	seen := make(map[string]bool)
+	var visit func(filter Filter)
+	visit = func(filter Filter) {
+		switch f := filter.(type) {
+		case andFilter:
+			for _, child := range f {
+				visit(child)
+			}
+		case orFilter:
+			for _, child := range f {
+				visit(child)
+			}
+		case notFilter:
+			visit(f.filter)
+		case comparisonFilter:
+			if f.field == changedSinceField {
+				seen[f.value] = true
+			}
+		}
+	}
+	visit(filter)
+
+	refs := make([]string, 0, len(seen))
+	for ref := range seen {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+	return refs
+}
+
+func filterFieldNames() []string {
+	names := make([]string, 0, len(filterFields))
+	for name := range filterFields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func filterTypeNames() []string {
+	return []string{
+		string(FilterAll), string(FilterOpenChangesets), string(FilterOutdatedVersions), string(FilterHasVersion),
+		string(FilterNoVersion), string(FilterUnchanged), string(FilterGrouped),
+	}
+}
+
+// globField compiles a glob comparison that matches if any of the values of
+// the field matches the pattern, or with != if none does
+func globField(values func(*ProjectContext) []string) func(op, value string) (func(*ProjectContext) bool, error) {
+	return func(op, pattern string) (func(*ProjectContext) bool, error) {
+		if op != "=" && op != "==" && op != "!=" {
+			return nil, fmt.Errorf("operator %s is not supported (use =, == or !=)", op)
+		}
+
+		glob, err := compileGlob(pattern)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(ctx *ProjectContext) bool {
+			for _, value := range values(ctx) {
+				if glob.MatchString(value) {
+					return op != "!="
+				}
+			}
+			return op == "!="
+		}, nil
+	}
+}
+
+// compilePathField compiles a glob comparison of the project path. A relative
+// pattern matches the path relative to the workspace root, e.g. apps/**
+// matches every project below the apps directory of the workspace and
+// **/apps/** every project below any directory named apps. An absolute
+// pattern matches the absolute path.
+func compilePathField(op, pattern string) (func(*ProjectContext) bool, error) {
+	absolute := strings.HasPrefix(pattern, "/")
+	return globField(func(ctx *ProjectContext) []string {
+		if absolute {
+			return []string{path.Clean(strings.ReplaceAll(ctx.ProjectPath, "\\", "/"))}
+		}
+
+		// Relative patterns never see the directories above the workspace
+		// root, so where the repository is checked out does not matter
+		if ctx.RelativePath == "" {
+			return nil
+		}
+		return []string{path.Clean(ctx.RelativePath)}
+	})(op, strings.TrimSuffix(pattern, "/"))
+}
+
+// compileGlob converts a glob to a regular expression. * and ? match within a
+// path segment, ** matches across segments.
+func compileGlob(pattern string) (*regexp.Regexp, error) {
+	var expr strings.Builder
+	expr.WriteString("^")
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			expr.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case c == '*':
+			expr.WriteString("[^/]*")
+		case c == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+	expr.WriteString("$")
+
+	glob, err := regexp.Compile(expr.String())
+	if err != nil {
+		return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+	}
+	return glob, nil
+}
+
+// compareOp returns whether cmp, the result of comparing a field with a
+// value, satisfies op
+func compareOp(op string) (func(cmp int) bool, error) {
+	switch op {
+	case "=", "==":
+		return func(cmp int) bool { return cmp == 0 }, nil
+	case "!=":
+		return func(cmp int) bool { return cmp != 0 }, nil
+	case "<":
+		return func(cmp int) bool { return cmp < 0 }, nil
+	case "<=":
+		return func(cmp int) bool { return cmp <= 0 }, nil
+	case ">":
+		return func(cmp int) bool { return cmp > 0 }, nil
+	case ">=":
+		return func(cmp int) bool { return cmp >= 0 }, nil
+	default:
+		return nil, fmt.Errorf("unknown operator %s", op)
+	}
+}
+
+// filterBumpRanks orders the highest bump of a project; "none" means no changesets
+var filterBumpRanks = map[string]int{"none": 0, "patch": 1, "minor": 2, "major": 3}
+
+func compileBumpField(op, value string) (func(*ProjectContext) bool, error) {
+	want, ok := filterBumpRanks[value]
+	if !ok {
+		return nil, fmt.Errorf("invalid bump %q (must be none, patch, minor or major)", value)
+	}
+	satisfies, err := compareOp(op)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx *ProjectContext) bool {
+		highest := 0
+		for _, cs := range ctx.Changesets {
+			highest = max(highest, filterBumpRanks[string(cs.BumpType)])
+		}
+		return satisfies(highest - want)
+	}, nil
+}
+
+func versionField(version func(*ProjectContext) string) func(op, value string) (func(*ProjectContext) bool, error) {
+	return func(op, value string) (func(*ProjectContext) bool, error) {
+		want, err := ParseVersion(value)
+		if err != nil {
+			return nil, err
+		}
+		satisfies, err := compareOp(op)
+		if err != nil {
+			return nil, err
+		}
+
+		return func(ctx *ProjectContext) bool {
+			have, err := ParseVersion(version(ctx))
+			if err != nil {
+				return false
+			}
+			return satisfies(have.Compare(want))
+		}, nil
+	}
+}
+
+func compileChangesetsField(op, value string) (func(*ProjectContext) bool, error) {
+	want, err := strconv.Atoi(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid number %q", value)
+	}
+	satisfies, err := compareOp(op)
+	if err != nil {
+		return nil, err
+	}
+
+	return func(ctx *ProjectContext) bool {
+		return satisfies(len(ctx.Changesets) - want)
+	}, nil
+}
+
+func compileChangedSinceField(op, ref string) (func(*ProjectContext) bool, error) {
+	if op != "=" && op != "==" {
+		return nil, fmt.Errorf("operator %s is not supported (use = or ==)", op)
+	}
+
+	return func(ctx *ProjectContext) bool {
+		return ctx.ChangedSince == ref && (len(ctx.ChangedFiles) > 0 || len(ctx.ChangedDependencies) > 0)
+	}, nil
+}
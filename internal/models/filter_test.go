@@ -0,0 +1,167 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFilter(t *testing.T) {
+	backend := &ProjectContext{
+		Project:        "backend",
+		ProjectPath:    "/repo/apps/backend",
+		RelativePath:   "apps/backend",
+		Type:           ProjectTypeGo,
+		CurrentVersion: "1.4.0",
+		LatestTag:      "1.3.0",
+		HasChangesets:  true,
+		IsOutdated:     true,
+		Changesets: []ChangesetSummary{
+			{ID: "a", BumpType: BumpPatch, Type: "fix"},
+			{ID: "b", BumpType: BumpMinor, Type: "security"},
+		},
+	}
+	legacy := &ProjectContext{
+		Project:        "legacy-api",
+		ProjectPath:    "/repo/apps/legacy/api",
+		RelativePath:   "apps/legacy/api",
+		Type:           ProjectTypeGo,
+		CurrentVersion: "0.9.0",
+		LatestTag:      "0.9.0",
+		Group:          &VersionGroup{Kind: GroupKindFixed},
+	}
+	web := &ProjectContext{
+		Project:        "web",
+		ProjectPath:    "/repo/packages/web",
+		RelativePath:   "packages/web",
+		Type:           ProjectTypeNode,
+		CurrentVersion: "2.0.0-beta.1",
+		LatestTag:      "1.9.0",
+		HasChangesets:  true,
+		Changesets:     []ChangesetSummary{{ID: "c", BumpType: BumpMajor}},
+	}
+
+	tests := []struct {
+		expr string
+		want []string
+	}{
+		{"all", []string{"backend", "legacy-api", "web"}},
+		{"open-changesets", []string{"backend", "web"}},
+		{"!unchanged && outdated-versions", []string{"backend"}},
+		{"type==go", []string{"backend", "legacy-api"}},
+		{"type != go", []string{"web"}},
+		{"name=legacy-*", []string{"legacy-api"}},
+		{"!name=legacy-*", []string{"backend", "web"}},
+		{"path=apps/**", []string{"backend", "legacy-api"}},
+		{"path=apps/*", []string{"backend"}},
+		{"path=/repo/packages/*", []string{"web"}},
+		{"path=legacy/api", nil},
+		{"path=**/legacy/api", []string{"legacy-api"}},
+		{"path=apps/legacy/api", []string{"legacy-api"}},
+		{"bump>=minor", []string{"backend", "web"}},
+		{"bump==none", []string{"legacy-api"}},
+		{"bump<major && open-changesets", []string{"backend"}},
+		{"version>=1.0.0", []string{"backend", "web"}},
+		{"version<2.0.0", []string{"backend", "legacy-api", "web"}},
+		{"latest=='0.9.0'", []string{"legacy-api"}},
+		{"changesets>1", []string{"backend"}},
+		{"category=security", []string{"backend"}},
+		{"category!=security", []string{"legacy-api", "web"}},
+		{"group==fixed || grouped && name=web", []string{"legacy-api"}},
+		{"(type==node || name=back*) && open-changesets", []string{"backend", "web"}},
+		{"type==go && bump>=minor && path=apps/** && !name=legacy-*", []string{"backend"}},
+		{"open-changesets,outdated-versions", []string{"backend"}},
+		{"open-changesets, grouped", nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			filter, err := ParseFilter(tt.expr)
+			require.NoError(t, err)
+
+			var got []string
+			for _, ctx := range []*ProjectContext{backend, legacy, web} {
+				if filter.MatchesContext(ctx) {
+					got = append(got, ctx.Project)
+				}
+			}
+			require.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseFilter_PathIgnoresCheckoutDirectory(t *testing.T) {
+	// The repository is checked out below a directory named apps
+	auth := &ProjectContext{Project: "auth", ProjectPath: "/home/ci/apps/repo/services/auth", RelativePath: "services/auth"}
+	web := &ProjectContext{Project: "web", ProjectPath: "/home/ci/apps/repo/apps/web", RelativePath: "apps/web"}
+
+	filter, err := ParseFilter("path=apps/**")
+	require.NoError(t, err)
+	require.False(t, filter.MatchesContext(auth))
+	require.True(t, filter.MatchesContext(web))
+
+	filter, err = ParseFilter("path=/home/ci/apps/**")
+	require.NoError(t, err)
+	require.True(t, filter.MatchesContext(auth))
+}
+
+func TestParseFilter_PathAnchoredAtWorkspaceRoot(t *testing.T) {
+	web := &ProjectContext{Project: "web", RelativePath: "apps/web"}
+	nested := &ProjectContext{Project: "nested", RelativePath: "tools/apps/x"}
+
+	filter, err := ParseFilter("path=apps/**")
+	require.NoError(t, err)
+	require.True(t, filter.MatchesContext(web))
+	require.False(t, filter.MatchesContext(nested))
+
+	filter, err = ParseFilter("path=**/apps/**")
+	require.NoError(t, err)
+	require.True(t, filter.MatchesContext(web))
+	require.True(t, filter.MatchesContext(nested))
+}
+
+func TestChangedSinceRefs(t *testing.T) {
+	filter, err := ParseFilter("changed-since=origin/main && !(name=legacy-* || changed-since=HEAD~3) && changed-since=origin/main")
+	require.NoError(t, err)
+	require.Equal(t, []string{"HEAD~3", "origin/main"}, ChangedSinceRefs(filter))
+
+	filter, err = ParseFilter("open-changesets")
+	require.NoError(t, err)
+	require.Empty(t, ChangedSinceRefs(filter))
+
+	filter, err = ParseFilter("changed-since=origin/main")
+	require.NoError(t, err)
+	require.False(t, filter.MatchesContext(&ProjectContext{ChangedSince: "origin/main"}))
+	require.True(t, filter.MatchesContext(&ProjectContext{ChangedSince: "origin/main", ChangedFiles: []string{"apps/api/main.go"}}))
+	require.True(t, filter.MatchesContext(&ProjectContext{ChangedSince: "origin/main", ChangedDependencies: []string{"shared"}}))
+}
+
+func TestParseFilter_Errors(t *testing.T) {
+	tests := []struct {
+		expr string
+		want string
+	}{
+		{"", `invalid filter "": empty filter at column 1`},
+		{"open-changeset", `invalid filter "open-changeset": unknown filter "open-changeset" (filters: all, open-changesets, outdated-versions, has-version, no-version, unchanged, grouped; fields: bump, category, changed-since, changesets, group, latest, name, path, type, version) at column 1`},
+		{"bmp>=minor", `unknown field "bmp" (fields: bump, category, changed-since, changesets, group, latest, name, path, type, version) at column 1`},
+		{"type==go &&", `expected a condition but found end of filter at column 12`},
+		{"type==go & bump>=minor", `unexpected '&' (use &&) at column 10`},
+		{"(type==go", `expected ")" but found end of filter at column 10`},
+		{"type==go)", `unexpected ")" at column 9`},
+		{"type==", `expected a value after "==" but found end of filter at column 7`},
+		{"bump", `expected an operator after "bump" at column 5`},
+		{"bump>=huge", `bump>=huge: invalid bump "huge" (must be none, patch, minor or major) at column 5`},
+		{"name>=a", `name>=a: operator >= is not supported (use =, == or !=) at column 5`},
+		{"changesets>many", `changesets>many: invalid number "many" at column 11`},
+		{"name=\"web", `unterminated quoted value at column 6`},
+		{"type==go,bump>=minor", `(combine filters with && instead of commas)`},
+		{"open-changesets,outdated", `(combine filters with && instead of commas)`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expr, func(t *testing.T) {
+			_, err := ParseFilter(tt.expr)
+			require.ErrorContains(t, err, tt.want)
+		})
+	}
+}
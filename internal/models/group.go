@@ -0,0 +1,21 @@
+package models
+
+// GroupKind is the kind of a version group
+type GroupKind string
+
+const (
+	// GroupKindFixed keeps all members on the same version and releases them together
+	GroupKindFixed GroupKind = "fixed"
+
+	// GroupKindLinked applies the same bump to the members released together,
+	// but releases members without changes only when a dependency requires it
+	GroupKindLinked GroupKind = "linked"
+)
+
+// VersionGroup is a set of projects that are versioned together.
+type VersionGroup struct {
+	Kind GroupKind `json:"kind"`
+
+	// Projects are the member project names, in workspace order
+	Projects []string `json:"projects"`
+}
@@ -0,0 +1,159 @@
+package models
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// PreMode is the state of prerelease mode.
+type PreMode string
+
+const (
+	// PreModeActive means 'changeset version' produces prerelease versions.
+	PreModeActive PreMode = "pre"
+
+	// PreModeExit means the next 'changeset version' cuts the final version.
+	PreModeExit PreMode = "exit"
+)
+
+// PreState is the prerelease mode state stored in .changeset/pre.json.
+type PreState struct {
+	// Mode is either "pre" or "exit"
+	Mode PreMode `json:"mode"`
+
+	// Tag is the prerelease identifier, e.g. "beta" for 1.3.0-beta.0
+	Tag string `json:"tag"`
+
+	// InitialVersions are the project versions when pre mode was entered
+	InitialVersions map[string]string `json:"initialVersions"`
+
+	// Changesets are the IDs already released as prereleases, per project
+	Changesets map[string][]string `json:"changesets"`
+}
+
+// NewPreState creates the state for entering pre mode with the given tag.
+func NewPreState(tag string, initialVersions map[string]string) (*PreState, error) {
+	if err := ValidatePreTag(tag); err != nil {
+		return nil, err
+	}
+
+	return &PreState{
+		Mode:            PreModeActive,
+		Tag:             tag,
+		InitialVersions: initialVersions,
+		Changesets:      make(map[string][]string),
+	}, nil
+}
+
+// ValidatePreTag checks that tag can be used as a prerelease identifier.
+func ValidatePreTag(tag string) error {
+	if tag == "" {
+		return fmt.Errorf("prerelease tag cannot be empty")
+	}
+	if strings.Contains(tag, ".") {
+		return fmt.Errorf("invalid prerelease tag %q: must be a single identifier", tag)
+	}
+	if _, err := strconv.Atoi(tag); err == nil {
+		return fmt.Errorf("invalid prerelease tag %q: must not be numeric", tag)
+	}
+	if _, err := ParseVersion("0.0.0-" + tag); err != nil {
+		return fmt.Errorf("invalid prerelease tag %q: %w", tag, err)
+	}
+	return nil
+}
+
+// IsActive returns true while 'changeset version' should produce prereleases.
+func (s *PreState) IsActive() bool {
+	return s.Mode == PreModeActive
+}
+
+// IsConsumed returns true if the changeset was already released as a prerelease of the project.
+func (s *PreState) IsConsumed(projectName, changesetID string) bool {
+	for _, id := range s.Changesets[projectName] {
+		if id == changesetID {
+			return true
+		}
+	}
+	return false
+}
+
+// IsConsumedByOtherProject returns true if another project released the
+// changeset as a prerelease and has not cut its final version yet.
+func (s *PreState) IsConsumedByOtherProject(projectName, changesetID string) bool {
+	for name := range s.Changesets {
+		if name != projectName && s.IsConsumed(name, changesetID) {
+			return true
+		}
+	}
+	return false
+}
+
+// MarkConsumed records changesets released as a prerelease of the project.
+func (s *PreState) MarkConsumed(projectName string, changesetIDs ...string) {
+	if s.Changesets == nil {
+		s.Changesets = make(map[string][]string)
+	}
+	for _, id := range changesetIDs {
+		if !s.IsConsumed(projectName, id) {
+			s.Changesets[projectName] = append(s.Changesets[projectName], id)
+		}
+	}
+}
+
+// InitialVersion returns the version of the project when pre mode was entered.
+func (s *PreState) InitialVersion(projectName string) (*Version, bool, error) {
+	raw, ok := s.InitialVersions[projectName]
+	if !ok {
+		return nil, false, nil
+	}
+
+	version, err := ParseVersion(raw)
+	if err != nil {
+		return nil, false, fmt.Errorf("invalid initial version for %s: %w", projectName, err)
+	}
+	return version, true, nil
+}
+
+// SetInitialVersion records the version of a project that was not known when pre mode was entered.
+func (s *PreState) SetInitialVersion(projectName string, version *Version) {
+	if s.InitialVersions == nil {
+		s.InitialVersions = make(map[string]string)
+	}
+	s.InitialVersions[projectName] = version.String()
+}
+
+// Finish removes a project after its final version was cut.
+func (s *PreState) Finish(projectName string) {
+	delete(s.Changesets, projectName)
+	delete(s.InitialVersions, projectName)
+}
+
+// PendingProjects returns the projects with prereleases that have not been finalized, sorted.
+func (s *PreState) PendingProjects() []string {
+	names := make([]string, 0, len(s.Changesets))
+	for name, ids := range s.Changesets {
+		if len(ids) > 0 {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
+
+// PrereleaseVersion returns the next prerelease of target, continuing the
+// numbering of current when it is a prerelease of the same target and tag.
+// e.g. target 1.3.0, current 1.3.0-beta.1 and tag beta -> 1.3.0-beta.2.
+func (s *PreState) PrereleaseVersion(target, current *Version) *Version {
+	next := 0
+	if current.StripPrerelease().Compare(target) == 0 {
+		if n, ok := strings.CutPrefix(current.Prerelease, s.Tag+"."); ok {
+			if num, err := strconv.Atoi(n); err == nil {
+				next = num + 1
+			}
+		}
+	}
+
+	return target.WithPrerelease(fmt.Sprintf("%s.%d", s.Tag, next))
+}
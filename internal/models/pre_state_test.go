@@ -0,0 +1,64 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPreState_PrereleaseVersion(t *testing.T) {
+	state, err := NewPreState("beta", nil)
+	require.NoError(t, err)
+
+	tests := []struct {
+		target   string
+		current  string
+		expected string
+	}{
+		{"1.3.0", "1.2.0", "1.3.0-beta.0"},
+		{"1.3.0", "1.3.0-beta.0", "1.3.0-beta.1"},
+		{"1.3.0", "1.3.0-beta.9", "1.3.0-beta.10"},
+		{"2.0.0", "1.3.0-beta.4", "2.0.0-beta.0"},
+		{"1.3.0", "1.3.0-alpha.3", "1.3.0-beta.0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.current+"->"+tt.expected, func(t *testing.T) {
+			target, err := ParseVersion(tt.target)
+			require.NoError(t, err)
+			current, err := ParseVersion(tt.current)
+			require.NoError(t, err)
+
+			require.Equal(t, tt.expected, state.PrereleaseVersion(target, current).String())
+		})
+	}
+}
+
+func TestPreState_ConsumedChangesets(t *testing.T) {
+	state, err := NewPreState("rc", map[string]string{"auth": "1.0.0", "api": "0.3.0"})
+	require.NoError(t, err)
+
+	state.MarkConsumed("auth", "a", "b")
+	state.MarkConsumed("api", "b")
+	state.MarkConsumed("auth", "a")
+
+	require.Equal(t, []string{"a", "b"}, state.Changesets["auth"])
+	require.True(t, state.IsConsumed("api", "b"))
+	require.False(t, state.IsConsumed("api", "a"))
+	require.True(t, state.IsConsumedByOtherProject("auth", "b"))
+	require.False(t, state.IsConsumedByOtherProject("auth", "a"))
+	require.Equal(t, []string{"api", "auth"}, state.PendingProjects())
+
+	state.Finish("auth")
+	require.Equal(t, []string{"api"}, state.PendingProjects())
+	require.NotContains(t, state.InitialVersions, "auth")
+}
+
+func TestValidatePreTag(t *testing.T) {
+	for _, tag := range []string{"beta", "rc", "next-1"} {
+		require.NoError(t, ValidatePreTag(tag), tag)
+	}
+	for _, tag := range []string{"", "1", "beta.1", "be_ta"} {
+		require.Error(t, ValidatePreTag(tag), tag)
+	}
+}
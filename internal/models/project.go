@@ -1,5 +1,32 @@
 package models
 
+import (
+	"fmt"
+	"strings"
+)
+
+// TagScheme selects how release tags of a project are named.
+type TagScheme string
+
+const (
+	// TagSchemeProject names tags "<project>@v1.2.3" (Node: "<project>@1.2.3").
+	TagSchemeProject TagScheme = "project"
+
+	// TagSchemeModule names tags "<module-dir>/v1.2.3", which is what the Go
+	// module proxy resolves for modules in a subdirectory of the repository.
+	TagSchemeModule TagScheme = "module"
+)
+
+// ParseTagScheme parses a string into a TagScheme
+func ParseTagScheme(s string) (TagScheme, error) {
+	switch TagScheme(s) {
+	case TagSchemeProject, TagSchemeModule:
+		return TagScheme(s), nil
+	default:
+		return "", fmt.Errorf("invalid tag scheme: %s (must be project or module)", s)
+	}
+}
+
 // ProjectType represents the kind of project.
 type ProjectType string
 
@@ -24,6 +51,21 @@ type Project struct {
 
 	// Type indicates whether this is a Go or Node project.
 	Type ProjectType
+
+	// TagScheme selects the release tag format; empty means TagSchemeProject.
+	TagScheme TagScheme
+
+	// ModuleDir is the slash-separated project directory relative to the git
+	// repository root, used by TagSchemeModule ("." for the root).
+	ModuleDir string
+
+	// Group is the fixed or linked version group of the project, if any.
+	Group *VersionGroup
+
+	// VersionStores are the configured stores of the project's version. The
+	// first one is read from, all of them are written. Empty means the
+	// default store of the project type (version.txt or package.json).
+	VersionStores []VersionStoreSpec
 }
 
 // NewProject creates a new Project instance
@@ -36,3 +78,34 @@ func NewProject(name, rootPath, modulePath, manifestPath string, projectType Pro
 		Type:         projectType,
 	}
 }
+
+// TagPrefix returns the part of the project's release tags that precedes the
+// version, e.g. "auth@v", "web@" or "services/auth/v".
+func (p *Project) TagPrefix() string {
+	if p.TagScheme == TagSchemeModule {
+		dir := strings.Trim(p.ModuleDir, "/")
+		if dir == "" || dir == "." {
+			return "v"
+		}
+		return dir + "/v"
+	}
+
+	if p.Type == ProjectTypeNode {
+		return p.Name + "@"
+	}
+	return p.Name + "@v"
+}
+
+// TagName returns the release tag of the project for version.
+func (p *Project) TagName(version *Version) string {
+	return p.TagPrefix() + version.String()
+}
+
+// TagVersion parses the version from a release tag of the project.
+func (p *Project) TagVersion(tag string) (*Version, error) {
+	prefix := p.TagPrefix()
+	if !strings.HasPrefix(tag, prefix) {
+		return nil, fmt.Errorf("tag %s does not belong to project %s", tag, p.Name)
+	}
+	return ParseVersion(strings.TrimPrefix(tag, prefix))
+}
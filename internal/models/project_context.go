@@ -9,9 +9,16 @@ type ProjectContext struct {
 	// ProjectPath is the absolute path to the project root
 	ProjectPath string `json:"projectPath"`
 
+	// RelativePath is the slash-separated project root relative to the
+	// workspace root ("." for the root)
+	RelativePath string `json:"relativePath,omitempty"`
+
 	// ModulePath is the full module path from go.mod
 	ModulePath string `json:"modulePath"`
 
+	// Type is the project type, e.g. "go" or "node"
+	Type ProjectType `json:"type,omitempty"`
+
 	// Changesets contains summaries of all changesets affecting this project
 	Changesets []ChangesetSummary `json:"changesets"`
 
@@ -31,6 +38,20 @@ type ProjectContext struct {
 	// IsOutdated indicates if CurrentVersion > LatestTag
 	IsOutdated bool `json:"isOutdated"`
 
+	// Group is the fixed or linked version group of the project, if any
+	Group *VersionGroup `json:"group,omitempty"`
+
+	// ChangedSince is the git ref of a changed-since filter, if any
+	ChangedSince string `json:"changedSince,omitempty"`
+
+	// ChangedFiles are the files of the project changed since ChangedSince,
+	// relative to the workspace root
+	ChangedFiles []string `json:"changedFiles,omitempty"`
+
+	// ChangedDependencies are the changed workspace projects the project
+	// depends on, with --include-dependents
+	ChangedDependencies []string `json:"changedDependencies,omitempty"`
+
 	// ChangelogPreview contains the markdown that will be added to CHANGELOG.md
 	// Empty string if no changesets
 	ChangelogPreview string `json:"changelogPreview"`
@@ -44,6 +65,9 @@ type ChangesetSummary struct {
 	// BumpType is the semantic version bump type for this project
 	BumpType BumpType `json:"bumpType"`
 
+	// Type is the change category for this project, e.g. "security"
+	Type string `json:"type,omitempty"`
+
 	// Message is the changeset description
 	Message string `json:"message"`
 }
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestProject_TagName(t *testing.T) {
+	version := &Version{Major: 1, Minor: 2, Patch: 3}
+
+	tests := []struct {
+		name    string
+		project *Project
+		want    string
+	}{
+		{
+			name:    "go project scheme",
+			project: &Project{Name: "backend", Type: ProjectTypeGo},
+			want:    "backend@v1.2.3",
+		},
+		{
+			name:    "node project scheme",
+			project: &Project{Name: "web", Type: ProjectTypeNode},
+			want:    "web@1.2.3",
+		},
+		{
+			name:    "go module scheme",
+			project: &Project{Name: "backend", Type: ProjectTypeGo, TagScheme: TagSchemeModule, ModuleDir: "apps/backend"},
+			want:    "apps/backend/v1.2.3",
+		},
+		{
+			name:    "go module scheme at repository root",
+			project: &Project{Name: "lib", Type: ProjectTypeGo, TagScheme: TagSchemeModule, ModuleDir: "."},
+			want:    "v1.2.3",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tag := tt.project.TagName(version)
+			require.Equal(t, tt.want, tag)
+
+			parsed, err := tt.project.TagVersion(tag)
+			require.NoError(t, err)
+			require.Equal(t, version, parsed)
+		})
+	}
+}
+
+func TestProject_TagVersion_RejectsOtherProjects(t *testing.T) {
+	project := &Project{Name: "backend", Type: ProjectTypeGo, TagScheme: TagSchemeModule, ModuleDir: "apps/backend"}
+
+	_, err := project.TagVersion("backend@v1.0.0")
+	require.Error(t, err)
+
+	_, err = project.TagVersion("apps/backend/vault/v1.0.0")
+	require.Error(t, err)
+}
+
+func TestParseTagScheme(t *testing.T) {
+	scheme, err := ParseTagScheme("module")
+	require.NoError(t, err)
+	require.Equal(t, TagSchemeModule, scheme)
+
+	_, err = ParseTagScheme("semver")
+	require.Error(t, err)
+}
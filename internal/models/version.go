@@ -6,15 +6,16 @@ import (
 	"strings"
 )
 
-// Version represents a semantic version
+// Version represents a semantic version (https://semver.org/spec/v2.0.0.html)
 type Version struct {
 	Major      int
 	Minor      int
 	Patch      int
-	Prerelease string // e.g., "rc0", "rc1", etc.
+	Prerelease string // e.g., "rc0", "beta.1", etc.
+	Build      string // e.g., "sha.abc123"; kept but ignored when comparing
 }
 
-// ParseVersion parses a version string (e.g., "1.2.3", "v1.2.3", "1.2.3-rc0")
+// ParseVersion parses a version string (e.g., "1.2.3", "v1.2.3", "1.2.3-rc0", "1.2.3-beta.1+sha.abc")
 func ParseVersion(s string) (*Version, error) {
 	// Remove leading 'v' if present
 	s = strings.TrimPrefix(s, "v")
@@ -24,11 +25,24 @@ func ParseVersion(s string) (*Version, error) {
 		return &Version{Major: 0, Minor: 0, Patch: 0}, nil
 	}
 
+	// Build metadata comes last (e.g., "1.2.3-rc0+sha.abc")
+	var build string
+	if idx := strings.Index(s, "+"); idx != -1 {
+		build = s[idx+1:]
+		s = s[:idx]
+		if err := validateIdentifiers(build, false); err != nil {
+			return nil, fmt.Errorf("invalid build metadata %q: %w", build, err)
+		}
+	}
+
 	// Check for prerelease suffix (e.g., "1.2.3-rc0")
 	var prerelease string
 	if idx := strings.Index(s, "-"); idx != -1 {
 		prerelease = s[idx+1:]
 		s = s[:idx]
+		if err := validateIdentifiers(prerelease, true); err != nil {
+			return nil, fmt.Errorf("invalid prerelease %q: %w", prerelease, err)
+		}
 	}
 
 	parts := strings.Split(s, ".")
@@ -36,17 +50,17 @@ func ParseVersion(s string) (*Version, error) {
 		return nil, fmt.Errorf("invalid version format: %s (expected major.minor.patch)", s)
 	}
 
-	major, err := strconv.Atoi(parts[0])
+	major, err := parseNumericIdentifier(parts[0])
 	if err != nil {
 		return nil, fmt.Errorf("invalid major version: %s", parts[0])
 	}
 
-	minor, err := strconv.Atoi(parts[1])
+	minor, err := parseNumericIdentifier(parts[1])
 	if err != nil {
 		return nil, fmt.Errorf("invalid minor version: %s", parts[1])
 	}
 
-	patch, err := strconv.Atoi(parts[2])
+	patch, err := parseNumericIdentifier(parts[2])
 	if err != nil {
 		return nil, fmt.Errorf("invalid patch version: %s", parts[2])
 	}
@@ -56,23 +70,67 @@ func ParseVersion(s string) (*Version, error) {
 		Minor:      minor,
 		Patch:      patch,
 		Prerelease: prerelease,
+		Build:      build,
 	}, nil
 }
 
+// parseNumericIdentifier parses a non-negative integer without leading zeros
+func parseNumericIdentifier(s string) (int, error) {
+	if !isNumeric(s) {
+		return 0, fmt.Errorf("not a number: %s", s)
+	}
+	if len(s) > 1 && s[0] == '0' {
+		return 0, fmt.Errorf("leading zero in %s", s)
+	}
+	return strconv.Atoi(s)
+}
+
+// validateIdentifiers checks dot-separated prerelease or build identifiers.
+// Numeric prerelease identifiers must not have leading zeros.
+func validateIdentifiers(s string, prerelease bool) error {
+	for _, ident := range strings.Split(s, ".") {
+		if ident == "" {
+			return fmt.Errorf("empty identifier")
+		}
+		for _, r := range ident {
+			if !(r >= '0' && r <= '9' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r == '-') {
+				return fmt.Errorf("invalid character %q in %s", r, ident)
+			}
+		}
+		if prerelease && isNumeric(ident) && len(ident) > 1 && ident[0] == '0' {
+			return fmt.Errorf("leading zero in %s", ident)
+		}
+	}
+	return nil
+}
+
+func isNumeric(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
 // String returns the version as a string without 'v' prefix
 func (v *Version) String() string {
+	s := fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
 	if v.Prerelease != "" {
-		return fmt.Sprintf("%d.%d.%d-%s", v.Major, v.Minor, v.Patch, v.Prerelease)
+		s += "-" + v.Prerelease
 	}
-	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+	if v.Build != "" {
+		s += "+" + v.Build
+	}
+	return s
 }
 
 // Tag returns the version as a tag string with 'v' prefix
 func (v *Version) Tag() string {
-	if v.Prerelease != "" {
-		return fmt.Sprintf("v%d.%d.%d-%s", v.Major, v.Minor, v.Patch, v.Prerelease)
-	}
-	return fmt.Sprintf("v%d.%d.%d", v.Major, v.Minor, v.Patch)
+	return "v" + v.String()
 }
 
 // Bump creates a new version by applying a bump type
@@ -98,54 +156,111 @@ func (v *Version) Bump(bumpType BumpType) *Version {
 	return newVersion
 }
 
-// Compare compares two versions
+// Compare compares two versions by semver precedence
 // Returns -1 if v < other, 0 if v == other, 1 if v > other
-// Prerelease versions are ordered before release versions (e.g., 1.2.3-rc0 < 1.2.3)
+// Prerelease versions are ordered before release versions (e.g., 1.2.3-rc0 < 1.2.3),
+// numeric prerelease identifiers compare numerically (rc2 < rc10, beta.2 < beta.10)
+// and build metadata is ignored.
 func (v *Version) Compare(other *Version) int {
-	if v.Major != other.Major {
-		if v.Major < other.Major {
-			return -1
-		}
-		return 1
+	if c := compareInts(v.Major, other.Major); c != 0 {
+		return c
+	}
+	if c := compareInts(v.Minor, other.Minor); c != 0 {
+		return c
+	}
+	if c := compareInts(v.Patch, other.Patch); c != 0 {
+		return c
 	}
 
-	if v.Minor != other.Minor {
-		if v.Minor < other.Minor {
-			return -1
-		}
-		return 1
+	// Same base version (major.minor.patch), compare prerelease
+	// No prerelease (release) > prerelease
+	switch {
+	case v.Prerelease == "" && other.Prerelease == "":
+		return 0
+	case v.Prerelease == "":
+		return 1 // Release > prerelease
+	case other.Prerelease == "":
+		return -1 // Prerelease < release
 	}
 
-	if v.Patch != other.Patch {
-		if v.Patch < other.Patch {
-			return -1
+	return comparePrerelease(v.Prerelease, other.Prerelease)
+}
+
+// comparePrerelease compares dot-separated prerelease identifiers per semver 2.0 §11
+func comparePrerelease(a, b string) int {
+	aIdents := strings.Split(a, ".")
+	bIdents := strings.Split(b, ".")
+
+	for i := 0; i < len(aIdents) && i < len(bIdents); i++ {
+		if c := compareIdentifier(aIdents[i], bIdents[i]); c != 0 {
+			return c
 		}
+	}
+
+	// A larger set of identifiers has higher precedence when all preceding are equal
+	return compareInts(len(aIdents), len(bIdents))
+}
+
+// compareIdentifier compares a single prerelease identifier. Identifiers of
+// the form "rc10" (letters followed by digits) compare their numeric suffix
+// numerically, so existing rcN tags sort as expected. This is an extension of
+// semver 2.0, which compares such identifiers as plain strings (rc10 < rc2).
+// Identifiers with equal numbers such as rc1 and rc01 fall back to comparing
+// the full strings, so that only equal identifiers compare equal.
+func compareIdentifier(a, b string) int {
+	aNum, bNum := isNumeric(a), isNumeric(b)
+	switch {
+	case aNum && bNum:
+		return compareNumericStrings(a, b)
+	case aNum:
+		return -1 // Numeric identifiers have lower precedence
+	case bNum:
 		return 1
 	}
 
-	// Same base version (major.minor.patch), compare prerelease
-	// No prerelease (release) > prerelease
-	if v.Prerelease == "" && other.Prerelease == "" {
-		return 0
+	aPrefix, aSuffix := splitNumericSuffix(a)
+	bPrefix, bSuffix := splitNumericSuffix(b)
+	if aPrefix == bPrefix && aSuffix != "" && bSuffix != "" {
+		if c := compareNumericStrings(aSuffix, bSuffix); c != 0 {
+			return c
+		}
 	}
-	if v.Prerelease == "" && other.Prerelease != "" {
-		return 1 // Release > prerelease
+
+	return strings.Compare(a, b)
+}
+
+// splitNumericSuffix splits "rc10" into "rc" and "10"
+func splitNumericSuffix(s string) (string, string) {
+	i := len(s)
+	for i > 0 && s[i-1] >= '0' && s[i-1] <= '9' {
+		i--
 	}
-	if v.Prerelease != "" && other.Prerelease == "" {
-		return -1 // Prerelease < release
+	return s[:i], s[i:]
+}
+
+// compareNumericStrings compares digit strings of arbitrary length numerically
+func compareNumericStrings(a, b string) int {
+	a = strings.TrimLeft(a, "0")
+	b = strings.TrimLeft(b, "0")
+	if c := compareInts(len(a), len(b)); c != 0 {
+		return c
 	}
+	return strings.Compare(a, b)
+}
 
-	// Both have prerelease, compare lexicographically
-	if v.Prerelease < other.Prerelease {
+func compareInts(a, b int) int {
+	switch {
+	case a < b:
 		return -1
-	}
-	if v.Prerelease > other.Prerelease {
+	case a > b:
 		return 1
+	default:
+		return 0
 	}
-	return 0
 }
 
-// WithPrerelease returns a new version with the specified prerelease suffix
+// WithPrerelease returns a new version with the specified prerelease suffix.
+// Build metadata is not carried over.
 func (v *Version) WithPrerelease(prerelease string) *Version {
 	return &Version{
 		Major:      v.Major,
@@ -160,7 +275,7 @@ func (v *Version) IsPrerelease() bool {
 	return v.Prerelease != ""
 }
 
-// StripPrerelease returns a new version without the prerelease suffix
+// StripPrerelease returns a new version without the prerelease suffix or build metadata
 func (v *Version) StripPrerelease() *Version {
 	return &Version{
 		Major: v.Major,
@@ -0,0 +1,14 @@
+package models
+
+// VersionStoreSpec configures a file that holds a project's version.
+type VersionStoreSpec struct {
+	// Type selects the store implementation, e.g. "version-file", "go-const" or "yaml"
+	Type string `json:"type"`
+
+	// File is the file holding the version, relative to the project root
+	File string `json:"file,omitempty"`
+
+	// Key is the dot-separated key path of the version (e.g. "info.version"),
+	// or the constant name for Go source files
+	Key string `json:"key,omitempty"`
+}
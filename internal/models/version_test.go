@@ -1,6 +1,7 @@
 package models
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -11,10 +12,10 @@ func TestParseVersion_WithPrerelease(t *testing.T) {
 		input    string
 		expected *Version
 	}{
-		{"1.2.3-rc0", &Version{1, 2, 3, "rc0"}},
-		{"v1.2.3-rc0", &Version{1, 2, 3, "rc0"}},
-		{"0.1.0-rc5", &Version{0, 1, 0, "rc5"}},
-		{"2.0.0-beta.1", &Version{2, 0, 0, "beta.1"}},
+		{"1.2.3-rc0", &Version{1, 2, 3, "rc0", ""}},
+		{"v1.2.3-rc0", &Version{1, 2, 3, "rc0", ""}},
+		{"0.1.0-rc5", &Version{0, 1, 0, "rc5", ""}},
+		{"2.0.0-beta.1", &Version{2, 0, 0, "beta.1", ""}},
 	}
 
 	for _, tt := range tests {
@@ -31,9 +32,9 @@ func TestParseVersion_WithoutPrerelease(t *testing.T) {
 		input    string
 		expected *Version
 	}{
-		{"1.2.3", &Version{1, 2, 3, ""}},
-		{"v1.2.3", &Version{1, 2, 3, ""}},
-		{"0.0.0", &Version{0, 0, 0, ""}},
+		{"1.2.3", &Version{1, 2, 3, "", ""}},
+		{"v1.2.3", &Version{1, 2, 3, "", ""}},
+		{"0.0.0", &Version{0, 0, 0, "", ""}},
 	}
 
 	for _, tt := range tests {
@@ -50,10 +51,10 @@ func TestVersion_String_WithPrerelease(t *testing.T) {
 		version  *Version
 		expected string
 	}{
-		{&Version{1, 2, 3, "rc0"}, "1.2.3-rc0"},
-		{&Version{0, 1, 0, "rc5"}, "0.1.0-rc5"},
-		{&Version{2, 0, 0, "beta.1"}, "2.0.0-beta.1"},
-		{&Version{1, 2, 3, ""}, "1.2.3"},
+		{&Version{1, 2, 3, "rc0", ""}, "1.2.3-rc0"},
+		{&Version{0, 1, 0, "rc5", ""}, "0.1.0-rc5"},
+		{&Version{2, 0, 0, "beta.1", ""}, "2.0.0-beta.1"},
+		{&Version{1, 2, 3, "", ""}, "1.2.3"},
 	}
 
 	for _, tt := range tests {
@@ -68,10 +69,10 @@ func TestVersion_Tag_WithPrerelease(t *testing.T) {
 		version  *Version
 		expected string
 	}{
-		{&Version{1, 2, 3, "rc0"}, "v1.2.3-rc0"},
-		{&Version{0, 1, 0, "rc5"}, "v0.1.0-rc5"},
-		{&Version{2, 0, 0, "beta.1"}, "v2.0.0-beta.1"},
-		{&Version{1, 2, 3, ""}, "v1.2.3"},
+		{&Version{1, 2, 3, "rc0", ""}, "v1.2.3-rc0"},
+		{&Version{0, 1, 0, "rc5", ""}, "v0.1.0-rc5"},
+		{&Version{2, 0, 0, "beta.1", ""}, "v2.0.0-beta.1"},
+		{&Version{1, 2, 3, "", ""}, "v1.2.3"},
 	}
 
 	for _, tt := range tests {
@@ -89,21 +90,24 @@ func TestVersion_Compare_PrereleaseOrdering(t *testing.T) {
 		expected int
 	}{
 		// Prerelease < release
-		{"rc0 < release", &Version{1, 2, 3, "rc0"}, &Version{1, 2, 3, ""}, -1},
-		{"release > rc0", &Version{1, 2, 3, ""}, &Version{1, 2, 3, "rc0"}, 1},
+		{"rc0 < release", &Version{1, 2, 3, "rc0", ""}, &Version{1, 2, 3, "", ""}, -1},
+		{"release > rc0", &Version{1, 2, 3, "", ""}, &Version{1, 2, 3, "rc0", ""}, 1},
 
 		// RC number ordering
-		{"rc0 < rc1", &Version{1, 2, 3, "rc0"}, &Version{1, 2, 3, "rc1"}, -1},
-		{"rc1 > rc0", &Version{1, 2, 3, "rc1"}, &Version{1, 2, 3, "rc0"}, 1},
-		{"rc0 == rc0", &Version{1, 2, 3, "rc0"}, &Version{1, 2, 3, "rc0"}, 0},
+		{"rc0 < rc1", &Version{1, 2, 3, "rc0", ""}, &Version{1, 2, 3, "rc1", ""}, -1},
+		{"rc1 > rc0", &Version{1, 2, 3, "rc1", ""}, &Version{1, 2, 3, "rc0", ""}, 1},
+		{"rc0 == rc0", &Version{1, 2, 3, "rc0", ""}, &Version{1, 2, 3, "rc0", ""}, 0},
+		{"rc2 < rc10", &Version{1, 2, 3, "rc2", ""}, &Version{1, 2, 3, "rc10", ""}, -1},
+		{"rc01 < rc1", &Version{1, 2, 3, "rc01", ""}, &Version{1, 2, 3, "rc1", ""}, -1},
+		{"rc1 > rc01", &Version{1, 2, 3, "rc1", ""}, &Version{1, 2, 3, "rc01", ""}, 1},
 
 		// Base version comparison takes precedence
-		{"1.2.3-rc0 < 1.2.4", &Version{1, 2, 3, "rc0"}, &Version{1, 2, 4, ""}, -1},
-		{"1.2.4 > 1.2.3-rc0", &Version{1, 2, 4, ""}, &Version{1, 2, 3, "rc0"}, 1},
-		{"1.2.3 < 1.2.4-rc0", &Version{1, 2, 3, ""}, &Version{1, 2, 4, "rc0"}, -1},
+		{"1.2.3-rc0 < 1.2.4", &Version{1, 2, 3, "rc0", ""}, &Version{1, 2, 4, "", ""}, -1},
+		{"1.2.4 > 1.2.3-rc0", &Version{1, 2, 4, "", ""}, &Version{1, 2, 3, "rc0", ""}, 1},
+		{"1.2.3 < 1.2.4-rc0", &Version{1, 2, 3, "", ""}, &Version{1, 2, 4, "rc0", ""}, -1},
 
 		// Same base version, same prerelease
-		{"same version", &Version{1, 2, 3, ""}, &Version{1, 2, 3, ""}, 0},
+		{"same version", &Version{1, 2, 3, "", ""}, &Version{1, 2, 3, "", ""}, 0},
 	}
 
 	for _, tt := range tests {
@@ -114,22 +118,22 @@ func TestVersion_Compare_PrereleaseOrdering(t *testing.T) {
 }
 
 func TestVersion_WithPrerelease(t *testing.T) {
-	original := &Version{1, 2, 3, ""}
+	original := &Version{1, 2, 3, "", ""}
 	result := original.WithPrerelease("rc0")
 
 	require.Equal(t, "rc0", result.Prerelease)
 	require.Equal(t, "", original.Prerelease)
-	require.Equal(t, &Version{1, 2, 3, "rc0"}, result)
+	require.Equal(t, &Version{1, 2, 3, "rc0", ""}, result)
 }
 
 func TestVersion_StripPrerelease(t *testing.T) {
-	original := &Version{1, 2, 3, "rc0"}
+	original := &Version{1, 2, 3, "rc0", ""}
 	result := original.StripPrerelease()
 
 	// Check new version has no prerelease
 	require.Equal(t, "", result.Prerelease)
 	require.Equal(t, "rc0", original.Prerelease)
-	require.Equal(t, &Version{1, 2, 3, ""}, result)
+	require.Equal(t, &Version{1, 2, 3, "", ""}, result)
 }
 
 func TestVersion_IsPrerelease(t *testing.T) {
@@ -138,9 +142,9 @@ func TestVersion_IsPrerelease(t *testing.T) {
 		version  *Version
 		expected bool
 	}{
-		{"with rc", &Version{1, 2, 3, "rc0"}, true},
-		{"with beta", &Version{1, 2, 3, "beta.1"}, true},
-		{"without prerelease", &Version{1, 2, 3, ""}, false},
+		{"with rc", &Version{1, 2, 3, "rc0", ""}, true},
+		{"with beta", &Version{1, 2, 3, "beta.1", ""}, true},
+		{"without prerelease", &Version{1, 2, 3, "", ""}, false},
 	}
 
 	for _, tt := range tests {
@@ -152,15 +156,15 @@ func TestVersion_IsPrerelease(t *testing.T) {
 
 func TestVersion_Bump_WithPrerelease(t *testing.T) {
 	// Bump should strip prerelease and apply the bump
-	original := &Version{1, 2, 3, "rc0"}
+	original := &Version{1, 2, 3, "rc0", ""}
 
 	tests := []struct {
 		bump     BumpType
 		expected *Version
 	}{
-		{BumpPatch, &Version{1, 2, 4, ""}},
-		{BumpMinor, &Version{1, 3, 0, ""}},
-		{BumpMajor, &Version{2, 0, 0, ""}},
+		{BumpPatch, &Version{1, 2, 4, "", ""}},
+		{BumpMinor, &Version{1, 3, 0, "", ""}},
+		{BumpMajor, &Version{2, 0, 0, "", ""}},
 	}
 
 	for _, tt := range tests {
@@ -171,3 +175,81 @@ func TestVersion_Bump_WithPrerelease(t *testing.T) {
 		})
 	}
 }
+
+func TestParseVersion_BuildMetadata(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected *Version
+	}{
+		{"1.2.3+build.5", &Version{1, 2, 3, "", "build.5"}},
+		{"v1.2.3-beta.1+sha.abc", &Version{1, 2, 3, "beta.1", "sha.abc"}},
+		{"1.0.0-alpha-1+exp.sha.5114f85", &Version{1, 0, 0, "alpha-1", "exp.sha.5114f85"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result, err := ParseVersion(tt.input)
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, result)
+			require.Equal(t, strings.TrimPrefix(tt.input, "v"), result.String())
+			require.Equal(t, "v"+strings.TrimPrefix(tt.input, "v"), result.Tag())
+		})
+	}
+}
+
+func TestParseVersion_Invalid(t *testing.T) {
+	for _, input := range []string{
+		"1.2",
+		"1.2.3.4",
+		"01.2.3",
+		"1.2.3-",
+		"1.2.3-rc..1",
+		"1.2.3-rc.01",
+		"1.2.3+",
+		"1.2.3+sha_abc",
+		"1.2.x",
+	} {
+		t.Run(input, func(t *testing.T) {
+			_, err := ParseVersion(input)
+			require.Error(t, err)
+		})
+	}
+}
+
+func TestVersion_Compare_Semver(t *testing.T) {
+	// Ordered by precedence, taken from semver 2.0 §11 plus rcN tags
+	ordered := []string{
+		"1.0.0-alpha",
+		"1.0.0-alpha.1",
+		"1.0.0-alpha.beta",
+		"1.0.0-beta",
+		"1.0.0-beta.2",
+		"1.0.0-beta.11",
+		"1.0.0-rc.1",
+		"1.0.0-rc0",
+		"1.0.0-rc01",
+		"1.0.0-rc1",
+		"1.0.0-rc2",
+		"1.0.0-rc10",
+		"1.0.0",
+		"1.0.1",
+	}
+
+	for i := 0; i < len(ordered)-1; i++ {
+		lower, err := ParseVersion(ordered[i])
+		require.NoError(t, err)
+		higher, err := ParseVersion(ordered[i+1])
+		require.NoError(t, err)
+
+		require.Equal(t, -1, lower.Compare(higher), "%s < %s", lower, higher)
+		require.Equal(t, 1, higher.Compare(lower), "%s > %s", higher, lower)
+	}
+}
+
+func TestVersion_Compare_IgnoresBuildMetadata(t *testing.T) {
+	a := &Version{1, 2, 3, "rc1", "sha.aaa"}
+	b := &Version{1, 2, 3, "rc1", "sha.bbb"}
+
+	require.Equal(t, 0, a.Compare(b))
+	require.Equal(t, 0, a.Compare(&Version{1, 2, 3, "rc1", ""}))
+}
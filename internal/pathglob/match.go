@@ -0,0 +1,57 @@
+package pathglob
+
+import (
+	"path"
+	"strings"
+)
+
+// Match reports whether name matches the slash-separated glob pattern.
+//
+// Within a path segment the syntax of path.Match applies (*, ?, [...]).
+// A segment consisting only of "**" matches zero or more whole segments,
+// e.g. "docs/**" matches everything below docs/ and "**/*_test.go" matches
+// test files at any depth. Malformed patterns never match.
+func Match(pattern, name string) bool {
+	pattern = strings.Trim(pattern, "/")
+	name = strings.Trim(name, "/")
+
+	return matchSegments(splitSegments(pattern), splitSegments(name))
+}
+
+func matchSegments(pattern, name []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == "**" {
+			rest := pattern[1:]
+			if len(rest) == 0 {
+				return true
+			}
+			for i := 0; i <= len(name); i++ {
+				if matchSegments(rest, name[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+
+		if len(name) == 0 {
+			return false
+		}
+
+		ok, err := path.Match(pattern[0], name[0])
+		if err != nil || !ok {
+			return false
+		}
+
+		pattern = pattern[1:]
+		name = name[1:]
+	}
+
+	return len(name) == 0
+}
+
+func splitSegments(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "/")
+}
@@ -0,0 +1,38 @@
+package pathglob
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMatch(t *testing.T) {
+	tests := []struct {
+		pattern string
+		name    string
+		want    bool
+	}{
+		{"docs/**", "docs/intro.mdx", true},
+		{"docs/**", "docs/guides/intro.mdx", true},
+		{"docs/**", "apps/docs/intro.mdx", false},
+		{"**/*_test.go", "handler_test.go", true},
+		{"**/*_test.go", "apps/backend/handler_test.go", true},
+		{"**/*_test.go", "apps/backend/handler.go", false},
+		{"**/testdata/**", "packages/shared/testdata/golden.json", true},
+		{"apps/*", "apps/backend", true},
+		{"apps/*", "apps/backend/main.go", false},
+		{"apps/**", "apps", true},
+		{"apps/**/main.go", "apps/backend/main.go", true},
+		{"apps/**/main.go", "apps/backend/cmd/main.go", true},
+		{"legacy-*", "legacy-api", true},
+		{"legacy-*", "modern-api", false},
+		{"*.md", "README.md", true},
+		{"[", "[", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pattern+"~"+tt.name, func(t *testing.T) {
+			require.Equal(t, tt.want, Match(tt.pattern, tt.name))
+		})
+	}
+}
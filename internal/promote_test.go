@@ -0,0 +1,102 @@
+package internal_test
+
+import (
+	"testing"
+
+	"github.com/jakoblorz/go-changesets/internal/cli"
+	"github.com/jakoblorz/go-changesets/internal/git"
+	"github.com/jakoblorz/go-changesets/internal/github"
+	"github.com/jakoblorz/go-changesets/internal/workspace"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromoteWorkflow(t *testing.T) {
+	wb := workspace.NewWorkspaceBuilder("/test-workspace")
+	wb.AddProject("auth", "apps/auth", "github.com/test/auth")
+	wb.AddChangeset("abc123", "auth", "minor", "Add token refresh")
+
+	fs := wb.Build()
+	gitMock := git.NewMockGitClient()
+	ghMock := github.NewMockClient()
+	ghMock.SetupRepository("testorg", "testrepo")
+
+	snapshot := func() {
+		cmd := cli.NewSnapshotCommand(fs, gitMock, ghMock)
+		cmd.SetArgs([]string{"--project", "auth", "--owner", "testorg", "--repo", "testrepo"})
+		require.NoError(t, cmd.Execute())
+	}
+
+	snapshot()
+	gitMock.CreateCommit("Fix flaky test")
+	snapshot()
+	gitMock.CreateCommit("Start next feature")
+
+	promote := func(from string) error {
+		cmd := cli.NewPromoteCommand(fs, gitMock, ghMock)
+		cmd.SetArgs([]string{"--project", "auth", "--from", from, "--owner", "testorg", "--repo", "testrepo"})
+		return cmd.Execute()
+	}
+
+	t.Run("final tag points at the release candidate's commit", func(t *testing.T) {
+		require.NoError(t, promote("rc0"))
+
+		tags := gitMock.GetAllTags()
+		final, exists := tags["auth@v0.1.0"]
+		require.True(t, exists, "expected final tag, got tags: %v", tagNames(tags))
+		require.Equal(t, tags["auth@v0.1.0-rc0"].CommitHash, final.CommitHash)
+		require.NotEqual(t, tags["auth@v0.1.0-rc1"].CommitHash, final.CommitHash)
+		require.Equal(t, tags["auth@v0.1.0-rc0"].Message, final.Message)
+		require.True(t, final.IsPushed)
+	})
+
+	t.Run("pre-release becomes the final release", func(t *testing.T) {
+		releases := ghMock.GetAllReleases("testorg", "testrepo")
+		require.Len(t, releases, 2)
+
+		var final *github.Release
+		for _, r := range releases {
+			require.NotEqual(t, "auth@v0.1.0-rc0", r.TagName)
+			if r.TagName == "auth@v0.1.0" {
+				final = r
+			}
+		}
+		require.NotNil(t, final)
+		require.False(t, final.Prerelease)
+		require.Equal(t, "auth@v0.1.0", final.Name)
+		require.Contains(t, final.Body, "Add token refresh")
+	})
+
+	t.Run("promotion is refused if the final tag exists", func(t *testing.T) {
+		require.ErrorContains(t, promote("0.1.0-rc1"), "tag auth@v0.1.0 already exists")
+	})
+
+	t.Run("unknown release candidate", func(t *testing.T) {
+		require.ErrorContains(t, promote("rc7"), `no release candidate tag of auth matches "rc7"`)
+	})
+}
+
+func TestPromoteCreatesRelease(t *testing.T) {
+	wb := workspace.NewWorkspaceBuilder("/test-workspace")
+	wb.AddProject("auth", "apps/auth", "github.com/test/auth")
+	wb.AddChangeset("abc123", "auth", "patch", "Fix header parsing")
+
+	fs := wb.Build()
+	gitMock := git.NewMockGitClient()
+
+	snapshotCmd := cli.NewSnapshotCommand(fs, gitMock, nil)
+	snapshotCmd.SetArgs([]string{"--project", "auth"})
+	require.NoError(t, snapshotCmd.Execute())
+
+	ghMock := github.NewMockClient()
+	ghMock.SetupRepository("testorg", "testrepo")
+
+	cmd := cli.NewPromoteCommand(fs, gitMock, ghMock)
+	cmd.SetArgs([]string{"--project", "auth", "--from", "auth@v0.0.1-rc0", "--owner", "testorg", "--repo", "testrepo"})
+	require.NoError(t, cmd.Execute())
+
+	releases := ghMock.GetAllReleases("testorg", "testrepo")
+	require.Len(t, releases, 1)
+	require.Equal(t, "auth@v0.0.1", releases[0].TagName)
+	require.False(t, releases[0].Prerelease)
+	require.Contains(t, releases[0].Body, "Fix header parsing")
+}
@@ -1,6 +1,8 @@
 package internal_test
 
 import (
+	"context"
+	"regexp"
 	"testing"
 
 	"github.com/jakoblorz/go-changesets/internal/changeset"
@@ -212,6 +214,114 @@ func TestSnapshotViaEach(t *testing.T) {
 	})
 }
 
+func TestSnapshotChannels(t *testing.T) {
+	wb := workspace.NewWorkspaceBuilder("/test-workspace")
+	wb.AddProject("backend", "apps/backend", "github.com/test/backend")
+	wb.AddChangeset("abc123", "backend", "minor", "Add new API endpoints")
+
+	fs := wb.Build()
+	gitMock := git.NewMockGitClient()
+	gitMock.AddTag("backend", "1.2.0", "Release 1.2.0")
+	ghMock := github.NewMockClient()
+	ghMock.SetupRepository("testorg", "testrepo")
+
+	snapshot := func(args ...string) {
+		cmd := cli.NewSnapshotCommand(fs, gitMock, ghMock)
+		cmd.SetArgs(append([]string{"--project", "backend", "--owner", "testorg", "--repo", "testrepo"}, args...))
+		require.NoError(t, cmd.Execute())
+	}
+
+	snapshot("--channel", "beta")
+	snapshot("--channel", "beta")
+	snapshot("--channel", "canary")
+	snapshot()
+
+	tags := gitMock.GetAllTags()
+	for _, expectedTag := range []string{
+		"backend@v1.3.0-beta.0",
+		"backend@v1.3.0-beta.1",
+		"backend@v1.3.0-canary.0",
+		"backend@v1.3.0-rc0",
+	} {
+		_, exists := tags[expectedTag]
+		require.True(t, exists, "expected tag %s not found, got tags: %v", expectedTag, tagNames(tags))
+	}
+
+	release, err := ghMock.GetReleaseByTag(context.Background(), "testorg", "testrepo", "backend@v1.3.0-canary.0")
+	require.NoError(t, err)
+	require.Equal(t, "backend@v1.3.0-canary.0 (canary)", release.Name)
+	require.True(t, release.Prerelease)
+
+	cmd := cli.NewSnapshotCommand(fs, gitMock, ghMock)
+	cmd.SetArgs([]string{"--project", "backend", "--channel", "beta", "--template", "{{.Next}}-pre.{{.N}}"})
+	require.ErrorContains(t, cmd.Execute(), "{{.Channel}}")
+}
+
+func TestSnapshotTemplate(t *testing.T) {
+	wb := workspace.NewWorkspaceBuilder("/test-workspace")
+	wb.AddProject("backend", "apps/backend", "github.com/test/backend")
+	wb.AddChangeset("abc123", "backend", "patch", "Fix memory leak")
+
+	fs := wb.Build()
+	gitMock := git.NewMockGitClient()
+	head, err := gitMock.GetHeadCommit()
+	require.NoError(t, err)
+
+	// The mock SHA is all digits with leading zeros, which is not a valid
+	// numeric identifier without the "g" prefix
+	require.Regexp(t, `^0\d+$`, head)
+
+	cmd := cli.NewSnapshotCommand(fs, gitMock, nil)
+	cmd.SetArgs([]string{"--project", "backend", "--template", "{{.Next}}-canary.{{.Timestamp}}.{{.ShortSHA}}"})
+	require.NoError(t, cmd.Execute())
+
+	tags := tagNames(gitMock.GetAllTags())
+	require.Len(t, tags, 1)
+	require.Regexp(t, `^backend@v0\.0\.1-canary\.\d{14}\.g`+regexp.QuoteMeta(head)+`$`, tags[0])
+}
+
+func TestSnapshotWriteVersion(t *testing.T) {
+	wb := workspace.NewWorkspaceBuilder("/test-workspace")
+	wb.AddProject("backend", "apps/backend", "github.com/test/backend")
+	wb.SetVersion("backend", "1.2.0")
+	wb.AddChangeset("abc123", "backend", "minor", "Add new API endpoints")
+
+	fs := wb.Build()
+	gitMock := git.NewMockGitClient()
+	gitMock.AddTag("backend", "1.2.0", "Release 1.2.0")
+
+	cmd := cli.NewSnapshotCommand(fs, gitMock, nil)
+	cmd.SetArgs([]string{"--project", "backend", "--channel", "next", "--write-version"})
+	require.NoError(t, cmd.Execute())
+
+	ws := workspace.New(fs, workspace.WithGoEnv(workspace.NewMockGoEnvReader(fs)))
+	require.NoError(t, ws.Detect())
+	project, err := ws.GetProject("backend")
+	require.NoError(t, err)
+	version, err := versioning.NewVersionFile(fs).Read(project.RootPath)
+	require.NoError(t, err)
+	require.Equal(t, "1.3.0-next.0", version.String())
+
+	csManager := changeset.NewManager(fs, ws.ChangesetDir())
+	remainingChangesets, err := csManager.ReadAll()
+	require.NoError(t, err)
+	require.Len(t, remainingChangesets, 1)
+
+	// The snapshot is already tagged, so publish does not release it again
+	cmd = cli.NewPublishCommand(fs, gitMock, nil)
+	cmd.SetArgs([]string{"--project", "backend"})
+	require.NoError(t, cmd.Execute())
+	require.ElementsMatch(t, []string{"backend@v1.2.0", "backend@v1.3.0-next.0"}, tagNames(gitMock.GetAllTags()))
+
+	// The release of the snapshot is its base version, not the next minor
+	cmd = cli.NewVersionCommand(fs, gitMock, nil)
+	cmd.SetArgs([]string{"--project", "backend"})
+	require.NoError(t, cmd.Execute())
+	version, err = versioning.NewVersionFile(fs).Read(project.RootPath)
+	require.NoError(t, err)
+	require.Equal(t, "1.3.0", version.String())
+}
+
 // Helper function to get tag names from mock
 func tagNames(tags map[string]*git.MockTag) []string {
 	var names []string
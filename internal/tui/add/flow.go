@@ -26,11 +26,15 @@ type Flow struct {
 // Result captures the successful output of the flow.
 type Result struct {
 	SelectedProjects []string
-	BumpType         models.BumpType
+	Bumps            map[string]models.BumpType
+	Type             string
 	Message          string
-	CreatedFiles     []string
+	CreatedFile      string
 }
 
+// perProjectBump is the sentinel option value for choosing bumps individually.
+const perProjectBump = "per-project"
+
 // NewFlow constructs a Flow with the orange/blue huh theme.
 func NewFlow(fs filesystem.FileSystem, ws *workspace.Workspace) *Flow {
 	return &Flow{
@@ -51,7 +55,15 @@ func (f *Flow) Run() (*Result, error) {
 		return nil, err
 	}
 
-	bumpType, err := f.selectBump(projects)
+	bumps, err := f.selectBumps(projects)
+	if err != nil {
+		if errors.Is(err, huh.ErrUserAborted) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	changeType, err := f.selectType()
 	if err != nil {
 		if errors.Is(err, huh.ErrUserAborted) {
 			return nil, nil
@@ -67,16 +79,17 @@ func (f *Flow) Run() (*Result, error) {
 		return nil, err
 	}
 
-	createdFiles, err := f.createChangesets(projects, bumpType, message)
+	createdFile, err := f.createChangeset(bumps, changeType, message)
 	if err != nil {
 		return nil, err
 	}
 
 	return &Result{
 		SelectedProjects: projects,
-		BumpType:         bumpType,
+		Bumps:            bumps,
+		Type:             changeType,
 		Message:          message,
-		CreatedFiles:     createdFiles,
+		CreatedFile:      createdFile,
 	}, nil
 }
 
@@ -164,45 +177,137 @@ func (f *Flow) selectProjects() ([]string, error) {
 	return selected, nil
 }
 
-func (f *Flow) selectBump(projects []string) (models.BumpType, error) {
-	bump := ""
+// selectBumps asks for the bump type of every selected project. With more than
+// one project, a bulk "same for all" choice is offered before falling back to
+// one question per project.
+func (f *Flow) selectBumps(projects []string) (map[string]models.BumpType, error) {
+	bulk := ""
+
+	opts := bumpOptions()
+	description := fmt.Sprintf("Applies to %s", strings.Join(projects, ", "))
+	if len(projects) > 1 {
+		for i := range opts {
+			opts[i].Key = opts[i].Key + " — same for all"
+		}
+		opts = append(opts, huh.NewOption("choose per project", perProjectBump))
+		description = fmt.Sprintf("Applies to %s, or choose a bump for each project", strings.Join(projects, ", "))
+	}
+
+	form := huh.NewForm(
+		huh.NewGroup(
+			huh.NewSelect[string]().
+				Options(opts...).
+				Value(&bulk),
+		).
+			Title("Change Impact").
+			Description(description),
+	).
+		WithTheme(f.theme).
+		WithShowHelp(true).
+		WithProgramOptions(tea.WithAltScreen()).
+		WithKeyMap(bumpKeyMap())
+
+	if err := form.Run(); err != nil {
+		return nil, err
+	}
+
+	if bulk != perProjectBump {
+		parsed, err := models.ParseBumpType(bulk)
+		if err != nil {
+			return nil, err
+		}
+
+		bumps := make(map[string]models.BumpType, len(projects))
+		for _, projectName := range projects {
+			bumps[projectName] = parsed
+		}
+		return bumps, nil
+	}
+
+	return f.selectBumpPerProject(projects)
+}
+
+func (f *Flow) selectBumpPerProject(projects []string) (map[string]models.BumpType, error) {
+	values := make([]string, len(projects))
+	fields := make([]huh.Field, 0, len(projects))
+	for i, projectName := range projects {
+		fields = append(fields, huh.NewSelect[string]().
+			Title(projectName).
+			Options(bumpOptions()...).
+			Value(&values[i]))
+	}
 
-	opts := []huh.Option[string]{
+	form := huh.NewForm(
+		huh.NewGroup(fields...).
+			Title("Change Impact").
+			Description("Choose the bump type for each project."),
+	).
+		WithTheme(f.theme).
+		WithShowHelp(true).
+		WithProgramOptions(tea.WithAltScreen()).
+		WithKeyMap(bumpKeyMap())
+
+	if err := form.Run(); err != nil {
+		return nil, err
+	}
+
+	bumps := make(map[string]models.BumpType, len(projects))
+	for i, projectName := range projects {
+		parsed, err := models.ParseBumpType(values[i])
+		if err != nil {
+			return nil, fmt.Errorf("invalid bump for %s: %w", projectName, err)
+		}
+		bumps[projectName] = parsed
+	}
+
+	return bumps, nil
+}
+
+func bumpOptions() []huh.Option[string] {
+	return []huh.Option[string]{
 		huh.NewOption("patch (0.0.X) — Bug fixes, no breaking changes", string(models.BumpPatch)),
 		huh.NewOption("minor (0.X.0) — New features, backward compatible", string(models.BumpMinor)),
 		huh.NewOption("major (X.0.0) — Breaking changes", string(models.BumpMajor)),
 	}
+}
 
+func bumpKeyMap() *huh.KeyMap {
 	keyMap := huh.NewDefaultKeyMap()
 	keyMap.Select.Filter.SetEnabled(false)
 	keyMap.Select.Prev.SetEnabled(true)
 	keyMap.Select.Submit.SetKeys("enter", " ")
 	keyMap.Select.Submit.SetHelp("space/enter", "continue")
+	return keyMap
+}
+
+// selectType asks for the optional change category of the changeset.
+func (f *Flow) selectType() (string, error) {
+	changeType := ""
+
+	opts := []huh.Option[string]{huh.NewOption("none", "")}
+	for _, category := range f.workspace.Config.Categories() {
+		opts = append(opts, huh.NewOption(fmt.Sprintf("%s — %s", category.Type, category.Title), category.Type))
+	}
 
 	form := huh.NewForm(
 		huh.NewGroup(
 			huh.NewSelect[string]().
 				Options(opts...).
-				Value(&bump),
+				Value(&changeType),
 		).
-			Title("Change Impact").
-			Description(fmt.Sprintf("Applies to %s", strings.Join(projects, ", "))),
+			Title("Change Category").
+			Description("Optionally choose the changelog section of this change."),
 	).
 		WithTheme(f.theme).
 		WithShowHelp(true).
 		WithProgramOptions(tea.WithAltScreen()).
-		WithKeyMap(keyMap)
+		WithKeyMap(bumpKeyMap())
 
 	if err := form.Run(); err != nil {
-		return models.BumpType(""), err
-	}
-
-	parsed, err := models.ParseBumpType(bump)
-	if err != nil {
-		return models.BumpType(""), err
+		return "", err
 	}
 
-	return parsed, nil
+	return changeType, nil
 }
 
 func (f *Flow) inputMessage() (string, error) {
@@ -240,32 +345,27 @@ func (f *Flow) inputMessage() (string, error) {
 	return strings.TrimSpace(message), nil
 }
 
-func (f *Flow) createChangesets(projects []string, bump models.BumpType, message string) ([]string, error) {
-	created := make([]string, 0, len(projects))
-
-	for _, projectName := range projects {
-		id, err := f.csManager.GenerateID()
-		if err != nil {
-			return nil, fmt.Errorf("failed to generate changeset ID for %s: %w", projectName, err)
-		}
-
-		singleProjectBumps := map[string]models.BumpType{
-			projectName: bump,
-		}
-		cs := models.NewChangeset(id, singleProjectBumps, message)
-
-		if err := f.csManager.Write(cs); err != nil {
-			return nil, fmt.Errorf("failed to write changeset for %s: %w", projectName, err)
-		}
+// createChangeset writes a single changeset covering all selected projects.
+func (f *Flow) createChangeset(bumps map[string]models.BumpType, changeType, message string) (string, error) {
+	id, err := f.csManager.GenerateID()
+	if err != nil {
+		return "", fmt.Errorf("failed to generate changeset ID: %w", err)
+	}
 
-		created = append(created, fmt.Sprintf("%s.md", id))
+	cs := models.NewChangeset(id, bumps, message)
+	cs.Type = changeType
+	if err := f.csManager.Write(cs); err != nil {
+		return "", fmt.Errorf("failed to write changeset: %w", err)
 	}
 
-	return created, nil
+	return fmt.Sprintf("%s.md", id), nil
 }
 
 func (f *Flow) projectVersion(project *models.Project) (string, bool) {
-	versionStore := versioning.NewVersionStore(f.fs, project.Type)
+	versionStore, err := versioning.NewProjectVersionStore(versioning.StoreEnv{FS: f.fs, Project: project})
+	if err != nil {
+		return "version not resolved", false
+	}
 	version, err := versionStore.Read(project.RootPath)
 	if err != nil {
 		return "version not resolved", false
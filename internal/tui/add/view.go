@@ -13,11 +13,14 @@ func RenderSuccess(result *Result) string {
 
 	b.WriteString(tui.SuccessStyle.Render("✓ Changeset Created"))
 	b.WriteString("\n\n")
-	b.WriteString(fmt.Sprintf("Created %d changeset file(s):\n", len(result.CreatedFiles)))
-	for i, file := range result.CreatedFiles {
-		b.WriteString(fmt.Sprintf("  %d. %s (%s: %s)\n", i+1, file, result.SelectedProjects[i], result.BumpType))
+	b.WriteString(fmt.Sprintf("Created %s:\n", result.CreatedFile))
+	for _, projectName := range result.SelectedProjects {
+		b.WriteString(fmt.Sprintf("  - %s: %s\n", projectName, result.Bumps[projectName]))
 	}
 	b.WriteString("\n")
+	if result.Type != "" {
+		b.WriteString(fmt.Sprintf("Type: %s\n", result.Type))
+	}
 	b.WriteString(fmt.Sprintf("Message: %s\n", result.Message))
 
 	return b.String()
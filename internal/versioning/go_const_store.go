@@ -0,0 +1,122 @@
+package versioning
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+
+	"github.com/jakoblorz/go-changesets/internal/filesystem"
+	"github.com/jakoblorz/go-changesets/internal/models"
+)
+
+const defaultGoConstName = "Version"
+
+var _ VersionStore = (*GoConstVersionStore)(nil)
+
+// GoConstVersionStore reads/writes a string constant in a Go source file,
+// e.g. `const Version = "1.2.3"` in internal/buildinfo/version.go. Only the
+// constant's literal is rewritten; the rest of the file is kept byte for byte.
+type GoConstVersionStore struct {
+	fs   filesystem.FileSystem
+	file string
+	name string
+}
+
+// NewGoConstVersionStore creates a store for the constant name in file, which
+// is relative to the project root. An empty name defaults to Version.
+func NewGoConstVersionStore(fs filesystem.FileSystem, file, name string) *GoConstVersionStore {
+	if name == "" {
+		name = defaultGoConstName
+	}
+	return &GoConstVersionStore{fs: fs, file: file, name: name}
+}
+
+// Read reads the version from the constant
+func (s *GoConstVersionStore) Read(projectRoot string) (*models.Version, error) {
+	path, _, span, err := s.locate(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := models.ParseVersion(span.value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version in constant %s in %s: %w", s.name, path, err)
+	}
+	return version, nil
+}
+
+// Write replaces the constant's value with version
+func (s *GoConstVersionStore) Write(projectRoot string, version *models.Version) error {
+	path, data, span, err := s.locate(projectRoot)
+	if err != nil {
+		return err
+	}
+
+	if err := s.fs.WriteFile(path, replaceVersion(data, span, version), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// IsEnabled always returns true
+func (s *GoConstVersionStore) IsEnabled(string) bool {
+	return true
+}
+
+// Files returns the path of the Go source file
+func (s *GoConstVersionStore) Files(projectRoot string) []string {
+	return []string{filepath.Join(projectRoot, s.file)}
+}
+
+func (s *GoConstVersionStore) locate(projectRoot string) (string, []byte, valueSpan, error) {
+	path := filepath.Join(projectRoot, s.file)
+	data, err := s.fs.ReadFile(path)
+	if err != nil {
+		return "", nil, valueSpan{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, path, data, parser.SkipObjectResolution)
+	if err != nil {
+		return "", nil, valueSpan{}, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			valueSpec := spec.(*ast.ValueSpec)
+			for i, ident := range valueSpec.Names {
+				if ident.Name != s.name {
+					continue
+				}
+				if i >= len(valueSpec.Values) {
+					return "", nil, valueSpan{}, fmt.Errorf("constant %s in %s has no value", s.name, path)
+				}
+
+				lit, ok := valueSpec.Values[i].(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING {
+					return "", nil, valueSpan{}, fmt.Errorf("constant %s in %s is not a string literal", s.name, path)
+				}
+				value, err := strconv.Unquote(lit.Value)
+				if err != nil {
+					return "", nil, valueSpan{}, fmt.Errorf("invalid constant %s in %s: %w", s.name, path, err)
+				}
+
+				span := valueSpan{
+					start: fset.Position(lit.Pos()).Offset,
+					end:   fset.Position(lit.End()).Offset,
+					value: value,
+				}
+				return path, data, span, nil
+			}
+		}
+	}
+
+	return "", nil, valueSpan{}, fmt.Errorf("constant %s not found in %s", s.name, path)
+}
@@ -0,0 +1,67 @@
+package versioning
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jakoblorz/go-changesets/internal/filesystem"
+	"github.com/jakoblorz/go-changesets/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+const buildinfoSource = `// Package buildinfo describes the build.
+package buildinfo
+
+import "runtime"
+
+const (
+	Name    = "auth"
+	Version = "1.4.0" // set by changeset version
+)
+
+var GoVersion   = runtime.Version()
+`
+
+func TestGoConstVersionStore_ReadWrite(t *testing.T) {
+	fs := filesystem.NewMockFileSystem()
+	fs.AddFile("/workspace/internal/buildinfo/version.go", []byte(buildinfoSource))
+
+	store := NewGoConstVersionStore(fs, "internal/buildinfo/version.go", "")
+
+	version, err := store.Read("/workspace")
+	require.NoError(t, err)
+	require.Equal(t, "1.4.0", version.String())
+
+	require.NoError(t, store.Write("/workspace", version.Bump(models.BumpMinor)))
+
+	data, err := fs.ReadFile("/workspace/internal/buildinfo/version.go")
+	require.NoError(t, err)
+	// Only the literal changes, including the unformatted var declaration
+	require.Equal(t, strings.Replace(buildinfoSource, `"1.4.0"`, `"1.5.0"`, 1), string(data))
+}
+
+func TestGoConstVersionStore_KeepsPrefixAndQuotes(t *testing.T) {
+	fs := filesystem.NewMockFileSystem()
+	fs.AddFile("/workspace/version.go", []byte("package main\n\nconst AppVersion = `v0.9.1`\n"))
+
+	store := NewGoConstVersionStore(fs, "version.go", "AppVersion")
+	require.NoError(t, store.Write("/workspace", &models.Version{Major: 1}))
+
+	data, err := fs.ReadFile("/workspace/version.go")
+	require.NoError(t, err)
+	require.Equal(t, "package main\n\nconst AppVersion = `v1.0.0`\n", string(data))
+}
+
+func TestGoConstVersionStore_Errors(t *testing.T) {
+	fs := filesystem.NewMockFileSystem()
+	fs.AddFile("/workspace/version.go", []byte("package main\n\nconst Build = 42\n\nvar Version = \"1.0.0\"\n"))
+
+	_, err := NewGoConstVersionStore(fs, "version.go", "Version").Read("/workspace")
+	require.ErrorContains(t, err, "constant Version not found")
+
+	_, err = NewGoConstVersionStore(fs, "version.go", "Build").Read("/workspace")
+	require.ErrorContains(t, err, "constant Build in /workspace/version.go is not a string literal")
+
+	_, err = NewGoConstVersionStore(fs, "missing.go", "").Read("/workspace")
+	require.ErrorContains(t, err, "failed to read /workspace/missing.go")
+}
@@ -0,0 +1,319 @@
+package versioning
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/jakoblorz/go-changesets/internal/filesystem"
+	"github.com/jakoblorz/go-changesets/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// Format is the file format of a KeyPathVersionStore.
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+	FormatTOML Format = "toml"
+)
+
+var _ VersionStore = (*KeyPathVersionStore)(nil)
+
+// KeyPathVersionStore reads/writes a string value at a key path in a JSON,
+// YAML or TOML file, e.g. "version" in Chart.yaml or "info.version" in
+// openapi.yaml. Only the value is rewritten; formatting and comments are kept.
+type KeyPathVersionStore struct {
+	fs     filesystem.FileSystem
+	format Format
+	file   string
+	path   []string
+}
+
+// NewKeyPathVersionStore creates a store for the value at the dot-separated
+// key path in file, which is relative to the project root.
+func NewKeyPathVersionStore(fs filesystem.FileSystem, format Format, file, key string) *KeyPathVersionStore {
+	return &KeyPathVersionStore{fs: fs, format: format, file: file, path: strings.Split(key, ".")}
+}
+
+// Read reads the version at the key path
+func (s *KeyPathVersionStore) Read(projectRoot string) (*models.Version, error) {
+	path, _, span, err := s.locate(projectRoot)
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := models.ParseVersion(span.value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version at %s in %s: %w", s.key(), path, err)
+	}
+	return version, nil
+}
+
+// Write replaces the value at the key path with version
+func (s *KeyPathVersionStore) Write(projectRoot string, version *models.Version) error {
+	path, data, span, err := s.locate(projectRoot)
+	if err != nil {
+		return err
+	}
+
+	if err := s.fs.WriteFile(path, replaceVersion(data, span, version), 0644); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// IsEnabled always returns true
+func (s *KeyPathVersionStore) IsEnabled(string) bool {
+	return true
+}
+
+// Files returns the path of the file
+func (s *KeyPathVersionStore) Files(projectRoot string) []string {
+	return []string{filepath.Join(projectRoot, s.file)}
+}
+
+func (s *KeyPathVersionStore) key() string {
+	return strings.Join(s.path, ".")
+}
+
+func (s *KeyPathVersionStore) locate(projectRoot string) (string, []byte, valueSpan, error) {
+	path := filepath.Join(projectRoot, s.file)
+	data, err := s.fs.ReadFile(path)
+	if err != nil {
+		return "", nil, valueSpan{}, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	var span valueSpan
+	switch s.format {
+	case FormatJSON:
+		span, err = locateJSON(data, s.path)
+	case FormatYAML:
+		span, err = locateYAML(data, s.path)
+	case FormatTOML:
+		span, err = locateTOML(data, s.path)
+	default:
+		err = fmt.Errorf("unsupported format %q", s.format)
+	}
+	if err != nil {
+		return "", nil, valueSpan{}, fmt.Errorf("failed to find %s in %s: %w", s.key(), path, err)
+	}
+	return path, data, span, nil
+}
+
+// valueSpan is the location of a string value in a file, including its quotes.
+type valueSpan struct {
+	start, end int
+	value      string
+}
+
+// replaceVersion replaces the value at span with version, keeping its quote
+// style and a leading "v".
+func replaceVersion(data []byte, span valueSpan, version *models.Version) []byte {
+	raw := data[span.start:span.end]
+
+	quote := ""
+	if len(raw) > 0 && strings.ContainsRune("\"'`", rune(raw[0])) {
+		quote = string(raw[0])
+	}
+	prefix := ""
+	if strings.HasPrefix(span.value, "v") {
+		prefix = "v"
+	}
+
+	result := make([]byte, 0, len(data))
+	result = append(result, data[:span.start]...)
+	result = append(result, quote+prefix+version.String()+quote...)
+	return append(result, data[span.end:]...)
+}
+
+func locateJSON(data []byte, path []string) (valueSpan, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+
+	for _, key := range path {
+		if token, err := decoder.Token(); err != nil || token != json.Delim('{') {
+			return valueSpan{}, fmt.Errorf("parent of %q is not an object", key)
+		}
+
+		found := false
+		for decoder.More() {
+			token, err := decoder.Token()
+			if err != nil {
+				return valueSpan{}, err
+			}
+			if token == key {
+				found = true
+				break
+			}
+			if err := skipJSONValue(decoder); err != nil {
+				return valueSpan{}, err
+			}
+		}
+		if !found {
+			return valueSpan{}, fmt.Errorf("key %q not found", key)
+		}
+	}
+
+	// The offset after the key precedes the colon and the value
+	offset := int(decoder.InputOffset())
+	token, err := decoder.Token()
+	if err != nil {
+		return valueSpan{}, err
+	}
+	value, ok := token.(string)
+	if !ok {
+		return valueSpan{}, fmt.Errorf("value is not a string")
+	}
+
+	end := int(decoder.InputOffset())
+	start := offset + bytes.IndexByte(data[offset:end], '"')
+	return valueSpan{start: start, end: end, value: value}, nil
+}
+
+func skipJSONValue(decoder *json.Decoder) error {
+	depth := 0
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return err
+		}
+		switch token {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+func locateYAML(data []byte, path []string) (valueSpan, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return valueSpan{}, err
+	}
+	if len(doc.Content) == 0 {
+		return valueSpan{}, fmt.Errorf("document is empty")
+	}
+
+	node := doc.Content[0]
+	for _, key := range path {
+		if node.Kind != yaml.MappingNode {
+			return valueSpan{}, fmt.Errorf("parent of %q is not a mapping", key)
+		}
+
+		var value *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				value = node.Content[i+1]
+				break
+			}
+		}
+		if value == nil {
+			return valueSpan{}, fmt.Errorf("key %q not found", key)
+		}
+		node = value
+	}
+
+	if node.Kind != yaml.ScalarNode || node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0 {
+		return valueSpan{}, fmt.Errorf("value is not a single-line scalar")
+	}
+
+	start := lineOffset(data, node.Line) + node.Column - 1
+	end := start + len(node.Value)
+	if node.Style&(yaml.DoubleQuotedStyle|yaml.SingleQuotedStyle) != 0 {
+		end = start + 1 + bytes.IndexByte(data[start+1:], data[start]) + 1
+	}
+	return valueSpan{start: start, end: end, value: node.Value}, nil
+}
+
+// lineOffset returns the offset of the 1-based line in data.
+func lineOffset(data []byte, line int) int {
+	offset := 0
+	for i := 1; i < line; i++ {
+		offset += bytes.IndexByte(data[offset:], '\n') + 1
+	}
+	return offset
+}
+
+// locateTOML finds a single-line string value by its table and key. Array
+// tables and multi-line strings are skipped.
+func locateTOML(data []byte, path []string) (valueSpan, error) {
+	want := strings.Join(path, ".")
+
+	var table []string
+	inArrayTable := false
+	inMultiline := false
+	offset := 0
+
+	for _, rawLine := range strings.SplitAfter(string(data), "\n") {
+		line := strings.TrimRight(rawLine, "\r\n")
+		lineStart := offset
+		offset += len(rawLine)
+
+		trimmed := strings.TrimSpace(line)
+		if inMultiline {
+			if strings.Contains(trimmed, `"""`) || strings.Contains(trimmed, `'''`) {
+				inMultiline = false
+			}
+			continue
+		}
+
+		switch {
+		case trimmed == "" || strings.HasPrefix(trimmed, "#"):
+			continue
+		case strings.HasPrefix(trimmed, "[["):
+			inArrayTable = true
+			continue
+		case strings.HasPrefix(trimmed, "["):
+			end := strings.Index(trimmed, "]")
+			if end == -1 {
+				return valueSpan{}, fmt.Errorf("invalid table header %q", trimmed)
+			}
+			table = splitTOMLKey(trimmed[1:end])
+			inArrayTable = false
+			continue
+		}
+
+		keyPart, valuePart, ok := strings.Cut(line, "=")
+		if !ok || inArrayTable {
+			continue
+		}
+		value := strings.TrimLeft(valuePart, " \t")
+		if strings.HasPrefix(value, `"""`) || strings.HasPrefix(value, `'''`) {
+			inMultiline = strings.Count(value, value[:3]) < 2
+			continue
+		}
+
+		key := append(append([]string(nil), table...), splitTOMLKey(keyPart)...)
+		if strings.Join(key, ".") != want {
+			continue
+		}
+
+		if value == "" || (value[0] != '"' && value[0] != '\'') {
+			return valueSpan{}, fmt.Errorf("value is not a string")
+		}
+		closing := strings.IndexByte(value[1:], value[0])
+		if closing == -1 {
+			return valueSpan{}, fmt.Errorf("unterminated string")
+		}
+
+		start := lineStart + len(keyPart) + 1 + len(valuePart) - len(value)
+		return valueSpan{start: start, end: start + closing + 2, value: value[1 : closing+1]}, nil
+	}
+	return valueSpan{}, fmt.Errorf("key %q not found", want)
+}
+
+// splitTOMLKey splits a dotted TOML key into its unquoted parts.
+func splitTOMLKey(key string) []string {
+	parts := strings.Split(key, ".")
+	for i, part := range parts {
+		parts[i] = strings.Trim(strings.TrimSpace(part), `"'`)
+	}
+	return parts
+}
@@ -0,0 +1,130 @@
+package versioning
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/jakoblorz/go-changesets/internal/filesystem"
+	"github.com/jakoblorz/go-changesets/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeyPathVersionStore_ReadWrite(t *testing.T) {
+	tests := []struct {
+		name    string
+		format  Format
+		file    string
+		key     string
+		content string
+		old     string
+		new     string
+	}{
+		{
+			name:   "json nested",
+			format: FormatJSON,
+			file:   "manifest.json",
+			key:    "app.version",
+			content: `{
+  "name": "web",
+  "version": "9.9.9",
+  "tags": ["a", {"version": "x"}],
+  "app" :  { "id": 7, "version":   "1.2.3" }
+}
+`,
+			old: `"version":   "1.2.3"`,
+			new: `"version":   "1.3.0"`,
+		},
+		{
+			name:   "yaml plain",
+			format: FormatYAML,
+			file:   "Chart.yaml",
+			key:    "version",
+			content: `apiVersion: v2
+name: auth # the chart
+version: 1.2.3 # bumped by changeset
+appVersion: "1.2.3"
+`,
+			old: "version: 1.2.3 #",
+			new: "version: 1.3.0 #",
+		},
+		{
+			name:   "yaml quoted nested",
+			format: FormatYAML,
+			file:   "openapi.yaml",
+			key:    "info.version",
+			content: `openapi: 3.0.0
+info:
+  title: Auth API
+  version: 'v1.2.3'
+paths: {}
+`,
+			old: "version: 'v1.2.3'",
+			new: "version: 'v1.3.0'",
+		},
+		{
+			name:   "toml table",
+			format: FormatTOML,
+			file:   "pyproject.toml",
+			key:    "project.version",
+			content: `version = "0.0.1"
+
+[project]
+name = "auth"
+description = """
+version = "not this one"
+"""
+version = "1.2.3" # comment
+
+[[project.authors]]
+version = "nope"
+`,
+			old: `version = "1.2.3"`,
+			new: `version = "1.3.0"`,
+		},
+		{
+			name:    "toml dotted key",
+			format:  FormatTOML,
+			file:    "Cargo.toml",
+			key:     "package.version",
+			content: "package.name = 'auth'\r\npackage.version = '1.2.3'\r\n",
+			old:     "package.version = '1.2.3'",
+			new:     "package.version = '1.3.0'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := filesystem.NewMockFileSystem()
+			fs.AddFile("/workspace/"+tt.file, []byte(tt.content))
+			store := NewKeyPathVersionStore(fs, tt.format, tt.file, tt.key)
+
+			version, err := store.Read("/workspace")
+			require.NoError(t, err)
+			require.Equal(t, "1.2.3", version.String())
+
+			require.NoError(t, store.Write("/workspace", version.Bump(models.BumpMinor)))
+
+			data, err := fs.ReadFile("/workspace/" + tt.file)
+			require.NoError(t, err)
+			require.Equal(t, strings.Replace(tt.content, tt.old, tt.new, 1), string(data))
+		})
+	}
+}
+
+func TestKeyPathVersionStore_Errors(t *testing.T) {
+	fs := filesystem.NewMockFileSystem()
+	fs.AddFile("/workspace/Chart.yaml", []byte("name: auth\ninfo:\n  revision: 3\n"))
+	fs.AddFile("/workspace/package.json", []byte(`{"version": 3}`))
+
+	_, err := NewKeyPathVersionStore(fs, FormatYAML, "Chart.yaml", "version").Read("/workspace")
+	require.ErrorContains(t, err, `failed to find version in /workspace/Chart.yaml: key "version" not found`)
+
+	_, err = NewKeyPathVersionStore(fs, FormatYAML, "Chart.yaml", "name.version").Read("/workspace")
+	require.ErrorContains(t, err, `parent of "version" is not a mapping`)
+
+	_, err = NewKeyPathVersionStore(fs, FormatJSON, "package.json", "version").Read("/workspace")
+	require.ErrorContains(t, err, "value is not a string")
+
+	_, err = NewKeyPathVersionStore(fs, FormatTOML, "Cargo.toml", "package.version").Read("/workspace")
+	require.ErrorContains(t, err, "failed to read /workspace/Cargo.toml")
+}
@@ -0,0 +1,170 @@
+package versioning
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jakoblorz/go-changesets/internal/filesystem"
+	"github.com/jakoblorz/go-changesets/internal/git"
+	"github.com/jakoblorz/go-changesets/internal/models"
+)
+
+// StoreEnv is what a StoreFactory may use besides the store's configuration.
+// All fields are nil when a configuration is only validated.
+type StoreEnv struct {
+	FS  filesystem.FileSystem
+	Git git.GitClient
+
+	// Changelog reads the project's CHANGELOG.md with the workspace's template
+	Changelog ChangelogReader
+
+	// Project is the project whose version is stored
+	Project *models.Project
+}
+
+// StoreFactory creates a VersionStore from its configuration. It must not
+// access the file system or git, so that configurations can be validated up front.
+type StoreFactory func(env StoreEnv, spec models.VersionStoreSpec) (VersionStore, error)
+
+var storeFactories = map[string]StoreFactory{
+	"version-file": func(env StoreEnv, spec models.VersionStoreSpec) (VersionStore, error) {
+		if spec.File == "" {
+			return NewVersionFile(env.FS), nil
+		}
+		return NewNamedVersionFile(env.FS, spec.File), nil
+	},
+	"package-json": func(env StoreEnv, spec models.VersionStoreSpec) (VersionStore, error) {
+		if spec.File != "" && spec.File != "package.json" {
+			return nil, fmt.Errorf("file must be package.json (use type json for other files)")
+		}
+		return NewPackageJSONVersionStore(env.FS), nil
+	},
+	"go-const": func(env StoreEnv, spec models.VersionStoreSpec) (VersionStore, error) {
+		if spec.File == "" {
+			return nil, fmt.Errorf("file is required")
+		}
+		return NewGoConstVersionStore(env.FS, spec.File, spec.Key), nil
+	},
+	"tag": func(env StoreEnv, spec models.VersionStoreSpec) (VersionStore, error) {
+		if spec.File != "" || spec.Key != "" {
+			return nil, fmt.Errorf("file and key are not supported")
+		}
+		return NewTagVersionStore(env.Git, env.Changelog, env.Project), nil
+	},
+	"json": newKeyPathFactory(FormatJSON),
+	"yaml": newKeyPathFactory(FormatYAML),
+	"toml": newKeyPathFactory(FormatTOML),
+}
+
+func newKeyPathFactory(format Format) StoreFactory {
+	return func(env StoreEnv, spec models.VersionStoreSpec) (VersionStore, error) {
+		if spec.File == "" {
+			return nil, fmt.Errorf("file is required")
+		}
+		if spec.Key == "" {
+			return nil, fmt.Errorf("key is required")
+		}
+		return NewKeyPathVersionStore(env.FS, format, spec.File, spec.Key), nil
+	}
+}
+
+// RegisterStore adds or replaces the store type used for specs of storeType.
+func RegisterStore(storeType string, factory StoreFactory) {
+	storeFactories[storeType] = factory
+}
+
+// StoreTypes returns the registered store types, sorted.
+func StoreTypes() []string {
+	types := make([]string, 0, len(storeFactories))
+	for storeType := range storeFactories {
+		types = append(types, storeType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// NewStore creates the store configured by spec.
+func NewStore(env StoreEnv, spec models.VersionStoreSpec) (VersionStore, error) {
+	factory, ok := storeFactories[spec.Type]
+	if !ok {
+		return nil, fmt.Errorf("unknown version store type %q (known types: %s)", spec.Type, strings.Join(StoreTypes(), ", "))
+	}
+
+	store, err := factory(env, spec)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", spec.Type, err)
+	}
+	return store, nil
+}
+
+// ValidateStoreSpec checks that spec names a registered store type and has
+// the fields the type requires.
+func ValidateStoreSpec(spec models.VersionStoreSpec) error {
+	_, err := NewStore(StoreEnv{}, spec)
+	return err
+}
+
+// NewProjectVersionStore returns the store of env.Project: the default store of
+// its type, or its configured stores with the first one as the primary store.
+// env.Git and env.Changelog may be nil if no configured store needs them.
+func NewProjectVersionStore(env StoreEnv) (VersionStore, error) {
+	project := env.Project
+	if len(project.VersionStores) == 0 {
+		return NewVersionStore(env.FS, project.Type), nil
+	}
+
+	stores := make([]VersionStore, 0, len(project.VersionStores))
+	for i, spec := range project.VersionStores {
+		store, err := NewStore(env, spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid version store %d of %s: %w", i, project.Name, err)
+		}
+		stores = append(stores, store)
+	}
+
+	if len(stores) == 1 {
+		return stores[0], nil
+	}
+	return &MirroredVersionStore{primary: stores[0], mirrors: stores[1:]}, nil
+}
+
+var _ VersionStore = (*MirroredVersionStore)(nil)
+
+// MirroredVersionStore reads the version from a primary store and writes it
+// to the primary store and all mirrors.
+type MirroredVersionStore struct {
+	primary VersionStore
+	mirrors []VersionStore
+}
+
+// Read reads the version from the primary store
+func (m *MirroredVersionStore) Read(projectRoot string) (*models.Version, error) {
+	return m.primary.Read(projectRoot)
+}
+
+// Write writes the version to the primary store and all mirrors
+func (m *MirroredVersionStore) Write(projectRoot string, version *models.Version) error {
+	var errs []error
+	for _, store := range append([]VersionStore{m.primary}, m.mirrors...) {
+		if err := store.Write(projectRoot, version); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// IsEnabled reports whether the primary store is enabled
+func (m *MirroredVersionStore) IsEnabled(projectRoot string) bool {
+	return m.primary.IsEnabled(projectRoot)
+}
+
+// Files returns the files of the primary store followed by those of the mirrors
+func (m *MirroredVersionStore) Files(projectRoot string) []string {
+	files := m.primary.Files(projectRoot)
+	for _, store := range m.mirrors {
+		files = append(files, store.Files(projectRoot)...)
+	}
+	return files
+}
@@ -0,0 +1,65 @@
+package versioning
+
+import (
+	"testing"
+
+	"github.com/jakoblorz/go-changesets/internal/filesystem"
+	"github.com/jakoblorz/go-changesets/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewProjectVersionStore_Default(t *testing.T) {
+	fs := filesystem.NewMockFileSystem()
+
+	store, err := NewProjectVersionStore(StoreEnv{FS: fs, Project: &models.Project{Name: "web", Type: models.ProjectTypeNode}})
+	require.NoError(t, err)
+	require.IsType(t, &PackageJSONVersionStore{}, store)
+
+	store, err = NewProjectVersionStore(StoreEnv{FS: fs, Project: &models.Project{Name: "auth", Type: models.ProjectTypeGo}})
+	require.NoError(t, err)
+	require.Equal(t, []string{"/workspace/version.txt"}, store.Files("/workspace"))
+}
+
+func TestNewProjectVersionStore_Mirrored(t *testing.T) {
+	fs := filesystem.NewMockFileSystem()
+	fs.AddFile("/workspace/VERSION", []byte("1.2.3\n"))
+	fs.AddFile("/workspace/buildinfo/version.go", []byte("package buildinfo\n\nconst Version = \"1.2.3\"\n"))
+	fs.AddFile("/workspace/chart/Chart.yaml", []byte("name: auth\nversion: 1.2.3\n"))
+
+	project := &models.Project{
+		Name: "auth",
+		Type: models.ProjectTypeGo,
+		VersionStores: []models.VersionStoreSpec{
+			{Type: "version-file", File: "VERSION"},
+			{Type: "go-const", File: "buildinfo/version.go"},
+			{Type: "yaml", File: "chart/Chart.yaml", Key: "version"},
+		},
+	}
+	store, err := NewProjectVersionStore(StoreEnv{FS: fs, Project: project})
+	require.NoError(t, err)
+	require.Equal(t, []string{"/workspace/VERSION", "/workspace/buildinfo/version.go", "/workspace/chart/Chart.yaml"}, store.Files("/workspace"))
+
+	require.NoError(t, store.Write("/workspace", &models.Version{Major: 2}))
+
+	version, err := store.Read("/workspace")
+	require.NoError(t, err)
+	require.Equal(t, "2.0.0", version.String())
+
+	goSource, err := fs.ReadFile("/workspace/buildinfo/version.go")
+	require.NoError(t, err)
+	require.Contains(t, string(goSource), `const Version = "2.0.0"`)
+
+	chart, err := fs.ReadFile("/workspace/chart/Chart.yaml")
+	require.NoError(t, err)
+	require.Equal(t, "name: auth\nversion: 2.0.0\n", string(chart))
+}
+
+func TestValidateStoreSpec(t *testing.T) {
+	require.NoError(t, ValidateStoreSpec(models.VersionStoreSpec{Type: "version-file"}))
+	require.NoError(t, ValidateStoreSpec(models.VersionStoreSpec{Type: "toml", File: "Cargo.toml", Key: "package.version"}))
+
+	require.EqualError(t, ValidateStoreSpec(models.VersionStoreSpec{Type: "xml"}),
+		`unknown version store type "xml" (known types: go-const, json, package-json, tag, toml, version-file, yaml)`)
+	require.EqualError(t, ValidateStoreSpec(models.VersionStoreSpec{Type: "yaml", File: "Chart.yaml"}), "yaml: key is required")
+	require.EqualError(t, ValidateStoreSpec(models.VersionStoreSpec{Type: "go-const"}), "go-const: file is required")
+}
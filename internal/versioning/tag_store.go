@@ -0,0 +1,97 @@
+package versioning
+
+import (
+	"fmt"
+
+	"github.com/jakoblorz/go-changesets/internal/git"
+	"github.com/jakoblorz/go-changesets/internal/models"
+)
+
+var _ VersionStore = (*TagVersionStore)(nil)
+
+// ChangelogReader reads the release versions of a project's CHANGELOG.md.
+// It is implemented by changelog.Changelog, which knows the release headings
+// of the configured template.
+type ChangelogReader interface {
+	// Versions returns the versions of projectName's releases, newest first
+	Versions(projectRoot, projectName string) ([]*models.Version, error)
+}
+
+// TagVersionStore derives a project's version from its release tags instead
+// of a version file. The version is the highest of the latest reachable
+// release tag and the newest release in the project's CHANGELOG.md, so that a
+// release written by 'changeset version' but not yet tagged is what
+// 'changeset publish' tags. Writing is a no-op.
+type TagVersionStore struct {
+	git       git.GitClient
+	changelog ChangelogReader
+	project   *models.Project
+}
+
+// NewTagVersionStore creates a store for the tags of project. The changelog
+// may be nil, in which case only tags are read.
+func NewTagVersionStore(gitClient git.GitClient, changelog ChangelogReader, project *models.Project) *TagVersionStore {
+	return &TagVersionStore{git: gitClient, changelog: changelog, project: project}
+}
+
+// Read returns the newer of the latest release tag and the newest CHANGELOG.md
+// release, or 0.0.0 if there is neither. Snapshot and other prerelease tags
+// are ignored.
+func (s *TagVersionStore) Read(projectRoot string) (*models.Version, error) {
+	if s.git == nil || s.project == nil {
+		return nil, fmt.Errorf("tag version store requires a git repository")
+	}
+
+	version := &models.Version{}
+
+	tags, err := s.git.GetTagsWithPrefix(s.project.TagPrefix() + "*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags of %s: %w", s.project.Name, err)
+	}
+	for _, tag := range tags {
+		// The pattern can match tags of nested modules, e.g. "auth/v*" matches "auth/vault/v1.0.0"
+		tagVersion, err := s.project.TagVersion(tag)
+		if err != nil || tagVersion.IsPrerelease() {
+			continue
+		}
+		if tagVersion.Compare(version) > 0 {
+			version = tagVersion
+		}
+	}
+
+	if pending := s.changelogVersion(projectRoot); pending != nil && pending.Compare(version) > 0 {
+		version = pending
+	}
+	return version, nil
+}
+
+// changelogVersion returns the newest release of the project in its
+// CHANGELOG.md, or nil if there is none. Entries without a version, such as
+// the dated batches of the workspace root, are skipped.
+func (s *TagVersionStore) changelogVersion(projectRoot string) *models.Version {
+	if s.changelog == nil {
+		return nil
+	}
+
+	versions, err := s.changelog.Versions(projectRoot, s.project.Name)
+	if err != nil || len(versions) == 0 {
+		return nil
+	}
+	return versions[0]
+}
+
+// Write does nothing; the version is recorded by the changelog and, once
+// published, by the release tag.
+func (s *TagVersionStore) Write(string, *models.Version) error {
+	return nil
+}
+
+// IsEnabled always returns true
+func (s *TagVersionStore) IsEnabled(string) bool {
+	return true
+}
+
+// Files returns no files
+func (s *TagVersionStore) Files(string) []string {
+	return nil
+}
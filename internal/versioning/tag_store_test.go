@@ -0,0 +1,85 @@
+package versioning
+
+import (
+	"testing"
+
+	"github.com/jakoblorz/go-changesets/internal/changelog"
+	"github.com/jakoblorz/go-changesets/internal/filesystem"
+	"github.com/jakoblorz/go-changesets/internal/git"
+	"github.com/jakoblorz/go-changesets/internal/models"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTagVersionStore_Read(t *testing.T) {
+	fs := filesystem.NewMockFileSystem()
+	gitClient := git.NewMockGitClient()
+	project := &models.Project{Name: "auth", RootPath: "/workspace/auth", Type: models.ProjectTypeGo}
+	store := NewTagVersionStore(gitClient, changelog.NewChangelog(fs), project)
+
+	version, err := store.Read(project.RootPath)
+	require.NoError(t, err)
+	require.Equal(t, "0.0.0", version.String())
+
+	gitClient.AddTag("auth", "1.1.0", "Release 1.1.0")
+	gitClient.AddTag("auth", "1.2.0", "Release 1.2.0")
+	gitClient.AddTag("auth", "1.3.0-rc2", "Snapshot")
+	gitClient.AddTag("auth", "1.3.0-canary.4", "Snapshot")
+	gitClient.AddTag("authz", "9.0.0", "Another project")
+
+	version, err = store.Read(project.RootPath)
+	require.NoError(t, err)
+	require.Equal(t, "1.2.0", version.String())
+
+	// A versioned but unpublished release is read from the changelog
+	fs.AddFile("/workspace/auth/CHANGELOG.md", []byte("# Changelog\n\n## auth@1.3.0 (2024-03-01)\n\n## auth@1.2.0 (2024-02-01)\n"))
+	version, err = store.Read(project.RootPath)
+	require.NoError(t, err)
+	require.Equal(t, "1.3.0", version.String())
+
+	gitClient.AddTag("auth", "1.4.0", "Release 1.4.0")
+	version, err = store.Read(project.RootPath)
+	require.NoError(t, err)
+	require.Equal(t, "1.4.0", version.String())
+
+	require.NoError(t, store.Write(project.RootPath, &models.Version{Major: 2}))
+	require.Empty(t, store.Files(project.RootPath))
+	require.Empty(t, fs.GetFiles()["/workspace/auth/version.txt"])
+}
+
+func TestTagVersionStore_ReadChangelogWithCustomTemplate(t *testing.T) {
+	fs := filesystem.NewMockFileSystem()
+	fs.AddFile("/workspace/.changeset/release.tmpl", []byte(
+		"{{/* header: ^# Release (?P<version>\\S+) */}}\n# Release {{.Version}}\n{{range .Items}}- {{.FirstLine}}\n{{end}}\n"))
+	gitClient := git.NewMockGitClient()
+	gitClient.AddTag("auth", "1.2.0", "Release 1.2.0")
+	project := &models.Project{Name: "auth", RootPath: "/workspace/auth", Type: models.ProjectTypeGo}
+
+	cl := changelog.NewChangelog(fs).WithTemplate("/workspace/.changeset/release.tmpl")
+	store := NewTagVersionStore(gitClient, cl, project)
+
+	// The heading of the custom template is not a "## " line
+	fs.AddFile("/workspace/auth/CHANGELOG.md", []byte("# Changelog\n\n# Release 1.3.0\n- Add OAuth\n\n# Release 1.2.0\n- Initial release\n"))
+	version, err := store.Read(project.RootPath)
+	require.NoError(t, err)
+	require.Equal(t, "1.3.0", version.String())
+}
+
+func TestTagVersionStore_ReadChangelogSkipsDatedEntries(t *testing.T) {
+	fs := filesystem.NewMockFileSystem()
+	gitClient := git.NewMockGitClient()
+	project := &models.Project{Name: "auth", RootPath: "/workspace", Type: models.ProjectTypeGo}
+	store := NewTagVersionStore(gitClient, changelog.NewChangelog(fs), project)
+
+	// A project at the root shares CHANGELOG.md with the dated batches of all
+	// projects and the entries of other projects
+	fs.AddFile("/workspace/CHANGELOG.md", []byte("# Changelog\n\n## 2024-03-02\n\n### api@2.0.0\n\n## api@2.0.0\n\n## auth@1.3.0 (2024-03-01)\n"))
+	version, err := store.Read(project.RootPath)
+	require.NoError(t, err)
+	require.Equal(t, "1.3.0", version.String())
+}
+
+func TestTagVersionStore_RequiresGit(t *testing.T) {
+	store := NewTagVersionStore(nil, nil, &models.Project{Name: "auth"})
+	_, err := store.Read("/workspace/auth")
+	require.ErrorContains(t, err, "requires a git repository")
+}
@@ -15,17 +15,29 @@ var _ VersionStore = (*VersionFile)(nil)
 
 // VersionFile handles reading and writing version.txt files
 type VersionFile struct {
-	fs filesystem.FileSystem
+	fs   filesystem.FileSystem
+	name string
 }
 
 // NewVersionFile creates a new VersionFile instance
 func NewVersionFile(fs filesystem.FileSystem) *VersionFile {
-	return &VersionFile{fs: fs}
+	return NewNamedVersionFile(fs, versionFileName)
+}
+
+// NewNamedVersionFile creates a VersionFile for a plain-text version file
+// other than version.txt, e.g. VERSION. name is relative to the project root.
+func NewNamedVersionFile(fs filesystem.FileSystem, name string) *VersionFile {
+	return &VersionFile{fs: fs, name: name}
+}
+
+// Files returns the path of the version file
+func (vf *VersionFile) Files(projectRoot string) []string {
+	return []string{filepath.Join(projectRoot, vf.name)}
 }
 
 // Read reads the version from version.txt in the project root
 func (vf *VersionFile) Read(projectRoot string) (*models.Version, error) {
-	versionPath := filepath.Join(projectRoot, versionFileName)
+	versionPath := filepath.Join(projectRoot, vf.name)
 
 	if !vf.fs.Exists(versionPath) {
 		// Default to 0.0.0 if version.txt doesn't exist
@@ -50,7 +62,7 @@ func (vf *VersionFile) Read(projectRoot string) (*models.Version, error) {
 // Returns false if version.txt contains "false" (case-insensitive)
 // Returns true if version.txt doesn't exist or contains a valid version
 func (vf *VersionFile) IsEnabled(projectRoot string) bool {
-	versionPath := filepath.Join(projectRoot, versionFileName)
+	versionPath := filepath.Join(projectRoot, vf.name)
 
 	if !vf.fs.Exists(versionPath) {
 		return true // No version.txt = enabled by default
@@ -73,7 +85,7 @@ func (vf *VersionFile) IsEnabled(projectRoot string) bool {
 
 // Write writes the version to version.txt in the project root
 func (vf *VersionFile) Write(projectRoot string, version *models.Version) error {
-	versionPath := filepath.Join(projectRoot, versionFileName)
+	versionPath := filepath.Join(projectRoot, vf.name)
 
 	content := version.String() + "\n"
 	if err := vf.fs.WriteFile(versionPath, []byte(content), 0644); err != nil {
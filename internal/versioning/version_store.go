@@ -16,6 +16,9 @@ type VersionStore interface {
 	Read(projectRoot string) (*models.Version, error)
 	Write(projectRoot string, version *models.Version) error
 	IsEnabled(projectRoot string) bool
+
+	// Files returns the files the version is written to
+	Files(projectRoot string) []string
 }
 
 // NewVersionStore returns the appropriate store for the project type.
@@ -101,6 +104,11 @@ func (p *PackageJSONVersionStore) Write(projectRoot string, version *models.Vers
 	return nil
 }
 
+// Files returns the path of package.json
+func (p *PackageJSONVersionStore) Files(projectRoot string) []string {
+	return []string{filepath.Join(projectRoot, "package.json")}
+}
+
 // IsEnabled always returns true for Node projects (no disable flag).
 func (p *PackageJSONVersionStore) IsEnabled(string) bool {
 	return true
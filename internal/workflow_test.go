@@ -275,7 +275,7 @@ func TestVersionPublishWithGitTags(t *testing.T) {
 	fileVersion, _ := versionFile.Read(project.RootPath)
 
 	// Try to get latest tag (should fail - no tags yet)
-	_, err := gitMock.GetLatestTag("auth")
+	_, err := gitMock.GetLatestTag("auth@v*")
 	require.Error(t, err)
 
 	// Since no tag exists, this is first release - should publish
@@ -299,7 +299,7 @@ func TestVersionPublishWithGitTags(t *testing.T) {
 
 	// Step 3: Try to publish again - should skip (already published)
 	// Get latest tag
-	latestTag, err := gitMock.GetLatestTag("auth")
+	latestTag, err := gitMock.GetLatestTag("auth@v*")
 	require.NoError(t, err)
 
 	require.Equal(t, tag, latestTag)
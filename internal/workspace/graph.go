@@ -0,0 +1,221 @@
+package workspace
+
+import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jakoblorz/go-changesets/internal/models"
+	"golang.org/x/mod/modfile"
+)
+
+// DependencyGraph describes which workspace projects depend on each other.
+// Only dependencies on other projects of the same workspace are tracked.
+type DependencyGraph struct {
+	dependencies map[string][]string
+	dependents   map[string][]string
+}
+
+// Dependencies returns the workspace projects the project depends on, sorted.
+func (g *DependencyGraph) Dependencies(projectName string) []string {
+	return g.dependencies[projectName]
+}
+
+// Dependents returns the workspace projects that depend on the project, sorted.
+func (g *DependencyGraph) Dependents(projectName string) []string {
+	return g.dependents[projectName]
+}
+
+// AllDependencies returns the workspace projects the project depends on,
+// directly or through other workspace projects, sorted.
+func (g *DependencyGraph) AllDependencies(projectName string) []string {
+	seen := make(map[string]bool)
+	var visit func(name string)
+	visit = func(name string) {
+		for _, dep := range g.dependencies[name] {
+			if !seen[dep] {
+				seen[dep] = true
+				visit(dep)
+			}
+		}
+	}
+	visit(projectName)
+	delete(seen, projectName)
+
+	names := make([]string, 0, len(seen))
+	for name := range seen {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// TopologicalOrder sorts projectNames so that every project comes after the
+// projects it depends on, directly or through other workspace projects.
+// Otherwise the order of projectNames is kept. A dependency cycle is an error.
+func (g *DependencyGraph) TopologicalOrder(projectNames []string) ([]string, error) {
+	selected := make(map[string]bool, len(projectNames))
+	for _, name := range projectNames {
+		selected[name] = true
+	}
+
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int)
+	var path []string
+	ordered := make([]string, 0, len(projectNames))
+
+	var visit func(name string) error
+	visit = func(name string) error {
+		switch state[name] {
+		case visited:
+			return nil
+		case visiting:
+			start := 0
+			for path[start] != name {
+				start++
+			}
+			cycle := append(append([]string(nil), path[start:]...), name)
+			return fmt.Errorf("dependency cycle: %s", strings.Join(cycle, " -> "))
+		}
+
+		state[name] = visiting
+		path = append(path, name)
+		for _, dep := range g.dependencies[name] {
+			if err := visit(dep); err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+		state[name] = visited
+
+		if selected[name] {
+			ordered = append(ordered, name)
+		}
+		return nil
+	}
+
+	for _, name := range projectNames {
+		if err := visit(name); err != nil {
+			return nil, err
+		}
+	}
+	return ordered, nil
+}
+
+// packageDependencies is the subset of package.json needed for the dependency graph.
+type packageDependencies struct {
+	Name            string            `json:"name"`
+	Dependencies    map[string]string `json:"dependencies"`
+	DevDependencies map[string]string `json:"devDependencies"`
+}
+
+// DependencyGraph builds the dependency graph of the workspace projects from
+// the require directives of go.mod files and the dependencies and
+// devDependencies of package.json files.
+func (w *Workspace) DependencyGraph() (*DependencyGraph, error) {
+	goModules := make(map[string]string)
+	nodePackages := make(map[string]string)
+	nodeManifests := make(map[string]packageDependencies)
+
+	for _, p := range w.Projects {
+		switch p.Type {
+		case models.ProjectTypeGo:
+			goModules[p.ModulePath] = p.Name
+		case models.ProjectTypeNode:
+			pkg, err := w.readPackageDependencies(p)
+			if err != nil {
+				return nil, err
+			}
+			nodeManifests[p.Name] = pkg
+			if pkg.Name != "" {
+				nodePackages[pkg.Name] = p.Name
+			}
+		}
+	}
+
+	graph := &DependencyGraph{
+		dependencies: make(map[string][]string),
+		dependents:   make(map[string][]string),
+	}
+
+	for _, p := range w.Projects {
+		deps := make(map[string]struct{})
+
+		switch p.Type {
+		case models.ProjectTypeGo:
+			requires, err := w.readGoRequires(p)
+			if err != nil {
+				return nil, err
+			}
+			for _, path := range requires {
+				if name, ok := goModules[path]; ok {
+					deps[name] = struct{}{}
+				}
+			}
+		case models.ProjectTypeNode:
+			pkg := nodeManifests[p.Name]
+			for _, group := range []map[string]string{pkg.Dependencies, pkg.DevDependencies} {
+				for dep := range group {
+					if name, ok := nodePackages[dep]; ok {
+						deps[name] = struct{}{}
+					}
+				}
+			}
+		}
+
+		for dep := range deps {
+			if dep == p.Name {
+				continue
+			}
+			graph.dependencies[p.Name] = append(graph.dependencies[p.Name], dep)
+			graph.dependents[dep] = append(graph.dependents[dep], p.Name)
+		}
+	}
+
+	for _, names := range graph.dependencies {
+		sort.Strings(names)
+	}
+	for _, names := range graph.dependents {
+		sort.Strings(names)
+	}
+
+	return graph, nil
+}
+
+func (w *Workspace) readGoRequires(p *models.Project) ([]string, error) {
+	goModPath := filepath.Join(p.RootPath, "go.mod")
+	data, err := w.fs.ReadFile(goModPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", goModPath, err)
+	}
+
+	modFile, err := modfile.ParseLax(goModPath, data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", goModPath, err)
+	}
+
+	paths := make([]string, 0, len(modFile.Require))
+	for _, req := range modFile.Require {
+		paths = append(paths, req.Mod.Path)
+	}
+	return paths, nil
+}
+
+func (w *Workspace) readPackageDependencies(p *models.Project) (packageDependencies, error) {
+	packagePath := filepath.Join(p.RootPath, "package.json")
+	data, err := w.fs.ReadFile(packagePath)
+	if err != nil {
+		return packageDependencies{}, fmt.Errorf("failed to read %s: %w", packagePath, err)
+	}
+
+	var pkg packageDependencies
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return packageDependencies{}, fmt.Errorf("failed to parse %s: %w", packagePath, err)
+	}
+	return pkg, nil
+}
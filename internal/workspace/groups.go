@@ -0,0 +1,76 @@
+package workspace
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/jakoblorz/go-changesets/internal/models"
+)
+
+// VersionGroups returns the fixed and linked groups of the workspace.
+func (w *Workspace) VersionGroups() []*models.VersionGroup {
+	var groups []*models.VersionGroup
+	seen := make(map[*models.VersionGroup]bool)
+	for _, p := range w.Projects {
+		if p.Group != nil && !seen[p.Group] {
+			seen[p.Group] = true
+			groups = append(groups, p.Group)
+		}
+	}
+	return groups
+}
+
+// applyVersionGroups resolves the fixed and linked groups of the config and
+// sets the group of every member project.
+func (w *Workspace) applyVersionGroups() error {
+	if w.Config == nil {
+		return nil
+	}
+
+	for _, p := range w.Projects {
+		p.Group = nil
+	}
+
+	kinds := []struct {
+		kind   models.GroupKind
+		groups [][]string
+	}{
+		{models.GroupKindFixed, w.Config.Fixed},
+		{models.GroupKindLinked, w.Config.Linked},
+	}
+
+	for _, k := range kinds {
+		for i, patterns := range k.groups {
+			group := &models.VersionGroup{Kind: k.kind}
+
+			for _, pattern := range patterns {
+				matched := false
+				for _, p := range w.Projects {
+					if ok, err := filepath.Match(pattern, p.Name); err != nil || !ok {
+						continue
+					}
+					matched = true
+
+					if p.Group == group {
+						continue
+					}
+					if p.Group != nil {
+						return fmt.Errorf("project %s is in more than one version group (%s[%d])", p.Name, k.kind, i)
+					}
+					p.Group = group
+				}
+				if !matched {
+					return fmt.Errorf("%s[%d]: no project matches %q", k.kind, i, pattern)
+				}
+			}
+
+			for _, p := range w.Projects {
+				if p.Group == group {
+					group.Projects = append(group.Projects, p.Name)
+				}
+			}
+		}
+	}
+
+	return nil
+}
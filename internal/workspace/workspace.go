@@ -6,14 +6,19 @@ import (
 	"fmt"
 	"io"
 	"io/fs"
+	"path"
 	"path/filepath"
+	"sort"
 	"strings"
 
 	gitignore "github.com/denormal/go-gitignore"
+	"github.com/jakoblorz/go-changesets/internal/config"
 	"github.com/jakoblorz/go-changesets/internal/filesystem"
+	"github.com/jakoblorz/go-changesets/internal/git"
 	"github.com/jakoblorz/go-changesets/internal/models"
 	"github.com/jakoblorz/go-changesets/internal/versioning"
 	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
 )
 
 var (
@@ -31,6 +36,13 @@ type Workspace struct {
 	WorkFilePath        string
 	Projects            []*models.Project
 	nodeStrictWorkspace bool
+	nodeStrictSet       bool
+	tagScheme           models.TagScheme
+	projectTagSchemes   map[string]models.TagScheme
+	git                 git.GitClient
+
+	// Config is the .changeset/config.json of the workspace, nil if absent
+	Config *config.Config
 }
 
 // Option configures workspace behavior.
@@ -40,6 +52,7 @@ type Option func(*Workspace)
 func WithNodeStrictWorkspace(enabled bool) Option {
 	return func(w *Workspace) {
 		w.nodeStrictWorkspace = enabled
+		w.nodeStrictSet = true
 	}
 }
 
@@ -57,10 +70,27 @@ func WithWarningWriter(writer io.Writer) Option {
 	}
 }
 
+// WithTagScheme sets the release tag scheme of all projects, with per-project
+// overrides by project name. An empty scheme keeps the default.
+func WithTagScheme(scheme models.TagScheme, projectSchemes map[string]models.TagScheme) Option {
+	return func(w *Workspace) {
+		w.tagScheme = scheme
+		w.projectTagSchemes = projectSchemes
+	}
+}
+
+// WithGitClient sets the git client used to find the repository root, which
+// module tags are relative to. Without one, the workspace root is used.
+func WithGitClient(gitClient git.GitClient) Option {
+	return func(w *Workspace) {
+		w.git = gitClient
+	}
+}
+
 // New creates a new Workspace instance.
 func New(fs filesystem.FileSystem, options ...Option) *Workspace {
 	goEnv := newOSGoEnvReader(fs)
-	if _, ok := fs.(*filesystem.MockFileSystem); ok {
+	if _, ok := filesystem.Unwrap(fs).(*filesystem.MockFileSystem); ok {
 		goEnv = NewMockGoEnvReader(fs)
 	}
 
@@ -89,16 +119,6 @@ func (w *Workspace) Detect() error {
 		return err
 	}
 
-	scanRoot := goRoot
-	if scanRoot == "" {
-		scanRoot = nodeRoot
-	}
-
-	nodeProjects, err := w.loadNodeProjects(nodeRoot, scanRoot)
-	if err != nil {
-		return fmt.Errorf("failed to load projects: %w", err)
-	}
-
 	if goRoot == "" && nodeRoot == "" {
 		return fmt.Errorf("workspace not found")
 	}
@@ -109,16 +129,187 @@ func (w *Workspace) Detect() error {
 		w.RootPath = nodeRoot
 	}
 
+	cfg, err := config.Load(w.fs, w.ChangesetDir())
+	if err != nil {
+		return err
+	}
+	w.Config = cfg
+	if cfg != nil && !w.nodeStrictSet {
+		w.nodeStrictWorkspace = cfg.NodeStrictWorkspace
+	}
+
+	nodeProjects, err := w.loadNodeProjects(nodeRoot, w.RootPath)
+	if err != nil {
+		return fmt.Errorf("failed to load projects: %w", err)
+	}
+
 	projects := append(goProjects, nodeProjects...)
 	projects = dedupeProjectNames(projects)
+	if err := w.checkConfigProjects(projects); err != nil {
+		return err
+	}
+	projects = w.removeIgnoredProjects(projects)
 	if len(projects) == 0 {
 		return fmt.Errorf("failed to load projects: no projects found in workspace")
 	}
 
 	w.Projects = projects
+	if err := w.applyVersionGroups(); err != nil {
+		return err
+	}
+	w.applyVersionStores()
+	if err := w.applyTagSchemes(); err != nil {
+		return err
+	}
+	w.warnRenamedProjects()
+	return nil
+}
+
+// checkConfigProjects reports project settings and ignore patterns of the
+// config that do not name a project of the workspace, e.g. after a rename.
+func (w *Workspace) checkConfigProjects(projects []*models.Project) error {
+	if w.Config == nil {
+		return nil
+	}
+
+	known := make(map[string]bool, len(projects))
+	for _, p := range projects {
+		known[p.Name] = true
+	}
+
+	names := make([]string, 0, len(w.Config.Projects))
+	for name := range w.Config.Projects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if !known[name] {
+			return &config.ValidationError{Path: w.Config.Path, Err: fmt.Errorf("projects.%s: no such project in the workspace", name)}
+		}
+	}
+
+	for i, pattern := range w.Config.Ignore {
+		matched := false
+		for _, p := range projects {
+			if ok, err := filepath.Match(pattern, p.Name); err == nil && ok {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return &config.ValidationError{Path: w.Config.Path, Err: fmt.Errorf("ignore[%d]: no project matches %q", i, pattern)}
+		}
+	}
+
+	return nil
+}
+
+// removeIgnoredProjects drops the projects matched by the config's ignore list.
+func (w *Workspace) removeIgnoredProjects(projects []*models.Project) []*models.Project {
+	if w.Config == nil || len(w.Config.Ignore) == 0 {
+		return projects
+	}
+
+	kept := projects[:0]
+	for _, p := range projects {
+		if w.Config.IsIgnored(p.Name) {
+			continue
+		}
+		kept = append(kept, p)
+	}
+	return kept
+}
+
+// applyVersionStores sets the configured version stores of every project,
+// falling back to the config's default stores.
+func (w *Workspace) applyVersionStores() {
+	for _, p := range w.Projects {
+		p.VersionStores = nil
+		if w.Config == nil {
+			continue
+		}
+		p.VersionStores = w.Config.Projects[p.Name].VersionStores
+		if len(p.VersionStores) == 0 {
+			p.VersionStores = w.Config.VersionStores
+		}
+	}
+}
+
+// applyTagSchemes sets the module directory and tag scheme of every project.
+func (w *Workspace) applyTagSchemes() error {
+	for name := range w.projectTagSchemes {
+		if _, err := w.GetProject(name); err != nil {
+			return fmt.Errorf("invalid tag scheme override: %w", err)
+		}
+	}
+
+	repoRoot := w.repositoryRoot()
+	for _, p := range w.Projects {
+		if rel, err := filepath.Rel(repoRoot, p.RootPath); err == nil {
+			p.ModuleDir = filepath.ToSlash(rel)
+		}
+
+		// Flags take precedence over the config, and a project's scheme over
+		// the default of its source
+		var scheme models.TagScheme
+		explicit := false
+		if w.Config != nil {
+			scheme = w.Config.TagScheme
+			if projectConfig, ok := w.Config.Projects[p.Name]; ok && projectConfig.TagScheme != "" {
+				scheme = projectConfig.TagScheme
+				explicit = true
+			}
+		}
+		if w.tagScheme != "" {
+			scheme = w.tagScheme
+			explicit = false
+		}
+		if override, ok := w.projectTagSchemes[p.Name]; ok {
+			scheme = override
+			explicit = true
+		}
+		if scheme == "" {
+			scheme = models.TagSchemeProject
+		}
+		if _, err := models.ParseTagScheme(string(scheme)); err != nil {
+			return err
+		}
+
+		// The module scheme is the Go proxy's convention; keep the default
+		// for Node projects unless they were configured explicitly
+		if scheme == models.TagSchemeModule && p.Type != models.ProjectTypeGo {
+			if explicit {
+				return fmt.Errorf("tag scheme %s is only supported for Go projects (project %s)", scheme, p.Name)
+			}
+			scheme = models.TagSchemeProject
+		}
+
+		p.TagScheme = scheme
+	}
+
 	return nil
 }
 
+// repositoryRoot returns the top-level directory of the git repository, or
+// the workspace root if it is unknown. The Go proxy resolves module tags
+// relative to the repository root, which differs from the workspace root when
+// go.work lives in a subdirectory.
+func (w *Workspace) repositoryRoot() string {
+	if w.git == nil {
+		return w.RootPath
+	}
+
+	root, err := w.git.GetRepositoryRoot()
+	if err != nil {
+		w.warnf("warning: failed to find git repository root; module tags are relative to %s: %v", w.RootPath, err)
+		return w.RootPath
+	}
+	if root == "" {
+		return w.RootPath
+	}
+	return root
+}
+
 func (w *Workspace) warnf(format string, args ...interface{}) {
 	if w.warningWriter == nil {
 		return
@@ -217,6 +408,31 @@ func (w *Workspace) loadGoProjects(root, workFilePath string) ([]*models.Project
 	return projects, nil
 }
 
+// GoModuleDirs returns the directories of all modules listed in go.work,
+// including modules hidden from changesets via version.txt.
+func (w *Workspace) GoModuleDirs() ([]string, error) {
+	if w.WorkFilePath == "" {
+		return nil, nil
+	}
+
+	data, err := w.fs.ReadFile(w.WorkFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read go.work: %w", err)
+	}
+
+	workFile, err := modfile.ParseWork(w.WorkFilePath, data, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse go.work: %w", err)
+	}
+
+	root := filepath.Dir(w.WorkFilePath)
+	dirs := make([]string, 0, len(workFile.Use))
+	for _, use := range workFile.Use {
+		dirs = append(dirs, filepath.Join(root, use.Path))
+	}
+	return dirs, nil
+}
+
 // loadGoProject loads a single Go project from a directory.
 func (w *Workspace) loadGoProject(projectPath string) (*models.Project, error) {
 	goModPath := filepath.Join(projectPath, "go.mod")
@@ -240,6 +456,36 @@ func (w *Workspace) loadGoProject(projectPath string) (*models.Project, error) {
 	return models.NewProject(name, projectPath, modulePath, goModPath, models.ProjectTypeGo), nil
 }
 
+// warnRenamedProjects warns about Go projects whose module path ends in /vN,
+// e.g. github.com/org/auth/v2, that have release tags of their former project
+// name "v2". Those tags are ignored under the name "auth" until they are
+// renamed. Module tags do not contain the project name and are not affected.
+func (w *Workspace) warnRenamedProjects() {
+	if w.git == nil {
+		return
+	}
+
+	for _, p := range w.Projects {
+		formerName := path.Base(p.ModulePath)
+		if p.Type != models.ProjectTypeGo || formerName == p.Name {
+			continue
+		}
+
+		former := *p
+		former.Name = formerName
+		if former.TagPrefix() == p.TagPrefix() {
+			continue
+		}
+
+		tags, err := w.git.GetTagsWithPrefix(former.TagPrefix() + "*")
+		if err != nil || len(tags) == 0 {
+			continue
+		}
+		w.warnf("warning: module %s is now project %s instead of %s; its %d release tag(s) named %s... are ignored until they are renamed to %s...",
+			p.ModulePath, p.Name, formerName, len(tags), former.TagPrefix(), p.TagPrefix())
+	}
+}
+
 // GetProject returns a project by name.
 func (w *Workspace) GetProject(name string) (*models.Project, error) {
 	for _, p := range w.Projects {
@@ -259,14 +505,36 @@ func (w *Workspace) GetProjectNames() []string {
 	return names
 }
 
+// ProjectForPath returns the project whose root contains path, preferring the
+// most deeply nested project. It returns nil if no project contains path.
+func (w *Workspace) ProjectForPath(path string) *models.Project {
+	path = filepath.Clean(path)
+
+	var match *models.Project
+	for _, p := range w.Projects {
+		root := filepath.Clean(p.RootPath)
+		if path != root && !strings.HasPrefix(path, root+string(filepath.Separator)) {
+			continue
+		}
+		if match == nil || len(root) > len(filepath.Clean(match.RootPath)) {
+			match = p
+		}
+	}
+	return match
+}
+
 // ChangesetDir returns the path to the .changeset directory.
 func (w *Workspace) ChangesetDir() string {
 	return filepath.Join(w.RootPath, ".changeset")
 }
 
 // extractProjectName extracts the project name from a module path.
-// e.g., "github.com/user/project" -> "project".
+// e.g., "github.com/user/project" -> "project", "github.com/user/project/v2" -> "project".
 func extractProjectName(modulePath string) string {
+	if prefix, _, ok := module.SplitPathVersion(modulePath); ok && prefix != "" {
+		modulePath = prefix
+	}
+
 	parts := strings.Split(modulePath, "/")
 	if len(parts) > 0 {
 		return parts[len(parts)-1]
@@ -54,6 +54,20 @@ func (wb *WorkspaceBuilder) AddProject(name, path, modulePath string) *Workspace
 	return wb
 }
 
+// AddDependency adds a require directive for dependencyModule to a project's go.mod
+func (wb *WorkspaceBuilder) AddDependency(project, dependencyModule string) *WorkspaceBuilder {
+	for _, p := range wb.projects {
+		if p.Name == project {
+			goModPath := filepath.Join(wb.root, p.Path, "go.mod")
+			goMod, _ := wb.fs.ReadFile(goModPath)
+			goMod = append(goMod, fmt.Sprintf("\nrequire %s v0.0.0\n", dependencyModule)...)
+			wb.fs.AddFile(goModPath, goMod)
+			break
+		}
+	}
+	return wb
+}
+
 // AddChangeset adds a changeset to the workspace
 func (wb *WorkspaceBuilder) AddChangeset(id, project, bump, message string) *WorkspaceBuilder {
 	content := fmt.Sprintf("---\n%s: %s\n---\n\n%s\n", project, bump, message)
@@ -3,10 +3,12 @@ package workspace
 import (
 	"bytes"
 	"errors"
+	"path/filepath"
 	"testing"
 
 	"github.com/jakoblorz/go-changesets/internal/changeset"
 	"github.com/jakoblorz/go-changesets/internal/filesystem"
+	"github.com/jakoblorz/go-changesets/internal/git"
 	"github.com/jakoblorz/go-changesets/internal/models"
 	"github.com/stretchr/testify/require"
 )
@@ -340,3 +342,360 @@ func TestNormalizeGoEnv_StripsDevNullSentinel(t *testing.T) {
 	goEnv := normalizeGoEnv("", "NUL")
 	require.Empty(t, goEnv.GoMod)
 }
+
+func TestWorkspace_ProjectForPath(t *testing.T) {
+	ws, _ := buildWorkspace(t, func(wb *WorkspaceBuilder) {
+		wb.AddProject("api", "services/api", "github.com/test/api")
+		wb.AddProject("api-client", "services/api/client", "github.com/test/api-client")
+		wb.AddProject("apiary", "services/apiary", "github.com/test/apiary")
+	})
+
+	project := ws.ProjectForPath(testWorkspaceRoot + "/services/api/handler.go")
+	require.NotNil(t, project)
+	require.Equal(t, "api", project.Name)
+
+	project = ws.ProjectForPath(testWorkspaceRoot + "/services/api/client/client.go")
+	require.NotNil(t, project)
+	require.Equal(t, "api-client", project.Name)
+
+	project = ws.ProjectForPath(testWorkspaceRoot + "/services/apiary/main.go")
+	require.NotNil(t, project)
+	require.Equal(t, "apiary", project.Name)
+
+	require.Nil(t, ws.ProjectForPath(testWorkspaceRoot+"/README.md"))
+}
+
+func TestWorkspaceDetect_GoMajorVersionModulePath(t *testing.T) {
+	ws, _ := buildWorkspace(t, func(wb *WorkspaceBuilder) {
+		wb.AddProject("auth", "auth", "github.com/test/auth/v2")
+	})
+
+	require.Len(t, ws.Projects, 1)
+	require.Equal(t, "auth", ws.Projects[0].Name)
+	require.Equal(t, "github.com/test/auth/v2", ws.Projects[0].ModulePath)
+}
+
+func TestWorkspaceDetect_GoMajorVersionModulePathFormerName(t *testing.T) {
+	wb := NewWorkspaceBuilder(testWorkspaceRoot)
+	wb.AddProject("auth", "auth", "github.com/test/auth/v2")
+	fs := wb.Build()
+
+	gitClient := git.NewMockGitClient()
+	var warnings bytes.Buffer
+	ws := New(fs, WithGitClient(gitClient), WithWarningWriter(&warnings))
+	require.NoError(t, ws.Detect())
+	require.Empty(t, warnings.String())
+
+	// Releases made while the project was named after the /v2 suffix
+	gitClient.AddTag("v2", "2.0.0", "Release 2.0.0")
+	ws = New(fs, WithGitClient(gitClient), WithWarningWriter(&warnings))
+	require.NoError(t, ws.Detect())
+	require.Equal(t, "auth", ws.Projects[0].Name)
+	require.Contains(t, warnings.String(), "module github.com/test/auth/v2 is now project auth instead of v2; its 1 release tag(s) named v2@v... are ignored until they are renamed to auth@v...")
+
+	// Module tags such as auth/v2.0.0 do not contain the project name
+	fs.AddFile(filepath.Join(testWorkspaceRoot, ".changeset", "config.json"), []byte(`{"tagScheme": "module"}`))
+	warnings.Reset()
+	ws = New(fs, WithGitClient(gitClient), WithWarningWriter(&warnings))
+	require.NoError(t, ws.Detect())
+	require.Equal(t, "auth/v", ws.Projects[0].TagPrefix())
+	require.Empty(t, warnings.String())
+}
+
+func TestWorkspace_GoModuleDirs(t *testing.T) {
+	ws, _ := buildWorkspace(t, func(wb *WorkspaceBuilder) {
+		wb.AddProject("auth", "services/auth", "github.com/test/auth")
+		wb.AddProject("internal-tools", "tools", "github.com/test/tools")
+		wb.SetVersion("internal-tools", "false")
+	})
+
+	dirs, err := ws.GoModuleDirs()
+	require.NoError(t, err)
+	require.Equal(t, []string{testWorkspaceRoot + "/services/auth", testWorkspaceRoot + "/tools"}, dirs)
+}
+
+func TestWorkspace_DependencyGraph_Go(t *testing.T) {
+	ws, _ := buildWorkspace(t, func(wb *WorkspaceBuilder) {
+		wb.AddProject("shared", "shared", "github.com/test/shared")
+		wb.AddProject("backend", "backend", "github.com/test/backend")
+		wb.AddProject("www", "www", "github.com/test/www")
+		wb.AddDependency("backend", "github.com/test/shared")
+		wb.AddDependency("www", "github.com/test/shared")
+		wb.AddDependency("www", "github.com/test/backend")
+		wb.AddDependency("www", "github.com/external/lib")
+	})
+
+	graph, err := ws.DependencyGraph()
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"backend", "www"}, graph.Dependents("shared"))
+	require.Equal(t, []string{"www"}, graph.Dependents("backend"))
+	require.Empty(t, graph.Dependents("www"))
+	require.Equal(t, []string{"backend", "shared"}, graph.Dependencies("www"))
+	require.Empty(t, graph.Dependencies("shared"))
+}
+
+func TestDependencyGraph_TopologicalOrder(t *testing.T) {
+	ws, _ := buildWorkspace(t, func(wb *WorkspaceBuilder) {
+		wb.AddProject("www", "www", "github.com/test/www")
+		wb.AddProject("tools", "tools", "github.com/test/tools")
+		wb.AddProject("backend", "backend", "github.com/test/backend")
+		wb.AddProject("shared", "shared", "github.com/test/shared")
+		wb.AddDependency("backend", "github.com/test/shared")
+		wb.AddDependency("www", "github.com/test/backend")
+	})
+
+	graph, err := ws.DependencyGraph()
+	require.NoError(t, err)
+
+	order, err := graph.TopologicalOrder([]string{"www", "tools", "backend", "shared"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"shared", "backend", "www", "tools"}, order)
+
+	// Projects that are not selected still order the selected ones
+	order, err = graph.TopologicalOrder([]string{"www", "shared"})
+	require.NoError(t, err)
+	require.Equal(t, []string{"shared", "www"}, order)
+
+	require.Equal(t, []string{"backend", "shared"}, graph.AllDependencies("www"))
+	require.Empty(t, graph.AllDependencies("shared"))
+}
+
+func TestDependencyGraph_TopologicalOrderCycle(t *testing.T) {
+	ws, _ := buildWorkspace(t, func(wb *WorkspaceBuilder) {
+		wb.AddProject("shared", "shared", "github.com/test/shared")
+		wb.AddProject("backend", "backend", "github.com/test/backend")
+		wb.AddProject("www", "www", "github.com/test/www")
+		wb.AddDependency("shared", "github.com/test/www")
+		wb.AddDependency("backend", "github.com/test/shared")
+		wb.AddDependency("www", "github.com/test/backend")
+	})
+
+	graph, err := ws.DependencyGraph()
+	require.NoError(t, err)
+
+	_, err = graph.TopologicalOrder([]string{"shared", "backend", "www"})
+	require.EqualError(t, err, "dependency cycle: shared -> www -> backend -> shared")
+}
+
+func TestWorkspace_DependencyGraph_Node(t *testing.T) {
+	fs := filesystem.NewMockFileSystem()
+	fs.AddFile("/workspace/package.json", []byte(`{"name":"root","private":true,"workspaces":["packages/*"]}`))
+	fs.AddFile("/workspace/packages/ui/package.json", []byte(`{"name":"@acme/ui","version":"1.0.0"}`))
+	fs.AddFile("/workspace/packages/web/package.json", []byte(`{"name":"web","version":"0.2.0","dependencies":{"@acme/ui":"^1.0.0","react":"^18.0.0"}}`))
+	fs.AddFile("/workspace/packages/docs/package.json", []byte(`{"name":"docs","version":"0.1.0","devDependencies":{"@acme/ui":"workspace:*"}}`))
+	fs.SetCurrentDir("/workspace")
+
+	ws := New(fs, WithGoEnv(NewMockGoEnvReader(fs)))
+	require.NoError(t, ws.Detect())
+
+	graph, err := ws.DependencyGraph()
+	require.NoError(t, err)
+
+	require.Equal(t, []string{"docs", "web"}, graph.Dependents("@acme/ui"))
+	require.Equal(t, []string{"@acme/ui"}, graph.Dependencies("web"))
+}
+
+func TestWorkspaceDetect_TagScheme(t *testing.T) {
+	wb := NewWorkspaceBuilder(testWorkspaceRoot)
+	wb.AddProject("backend", "apps/backend", "github.com/test/backend")
+	wb.AddProject("legacy", "libs/legacy", "github.com/test/legacy")
+	fs := wb.Build()
+
+	ws := New(fs, WithTagScheme(models.TagSchemeModule, map[string]models.TagScheme{"legacy": models.TagSchemeProject}))
+	require.NoError(t, ws.Detect())
+
+	backend, err := ws.GetProject("backend")
+	require.NoError(t, err)
+	require.Equal(t, models.TagSchemeModule, backend.TagScheme)
+	require.Equal(t, "apps/backend", backend.ModuleDir)
+	require.Equal(t, "apps/backend/v", backend.TagPrefix())
+
+	legacy, err := ws.GetProject("legacy")
+	require.NoError(t, err)
+	require.Equal(t, models.TagSchemeProject, legacy.TagScheme)
+	require.Equal(t, "legacy@v", legacy.TagPrefix())
+}
+
+func TestWorkspaceDetect_TagSchemeNestedWorkspace(t *testing.T) {
+	// go.work lives in go/ below the repository root, so module tags need
+	// the go/ prefix for the Go proxy to find them
+	wb := NewWorkspaceBuilder("/repo/go")
+	wb.AddProject("backend", "apps/backend", "github.com/test/repo/go/apps/backend")
+	fs := wb.Build()
+
+	gitClient := git.NewMockGitClient()
+	gitClient.SetRepositoryRoot("/repo")
+
+	ws := New(fs, WithGitClient(gitClient), WithTagScheme(models.TagSchemeModule, nil))
+	require.NoError(t, ws.Detect())
+
+	backend, err := ws.GetProject("backend")
+	require.NoError(t, err)
+	require.Equal(t, "go/apps/backend", backend.ModuleDir)
+	require.Equal(t, "go/apps/backend/v", backend.TagPrefix())
+
+	// Without a repository, tags fall back to the workspace root
+	gitClient.SetIsRepo(false)
+	var warnings bytes.Buffer
+	ws = New(fs, WithGitClient(gitClient), WithTagScheme(models.TagSchemeModule, nil), WithWarningWriter(&warnings))
+	require.NoError(t, ws.Detect())
+
+	backend, err = ws.GetProject("backend")
+	require.NoError(t, err)
+	require.Equal(t, "apps/backend/v", backend.TagPrefix())
+	require.Contains(t, warnings.String(), "failed to find git repository root")
+}
+
+func TestWorkspaceDetect_TagSchemeValidation(t *testing.T) {
+	wb := NewWorkspaceBuilder(testWorkspaceRoot)
+	wb.AddProject("backend", "apps/backend", "github.com/test/backend")
+	fs := wb.Build()
+
+	ws := New(fs, WithTagScheme("calver", nil))
+	require.ErrorContains(t, ws.Detect(), "invalid tag scheme: calver")
+
+	ws = New(fs, WithTagScheme("", map[string]models.TagScheme{"frontend": models.TagSchemeModule}))
+	require.ErrorContains(t, ws.Detect(), "frontend")
+}
+
+func TestWorkspaceDetect_TagSchemeModuleRequiresGo(t *testing.T) {
+	fs := filesystem.NewMockFileSystem()
+	fs.AddFile("/workspace/package.json", []byte(`{"name":"root","private":true,"workspaces":["packages/*"]}`))
+	fs.AddFile("/workspace/packages/web/package.json", []byte(`{"name":"web","version":"0.2.0"}`))
+	fs.SetCurrentDir("/workspace")
+
+	ws := New(fs, WithTagScheme(models.TagSchemeModule, nil))
+	require.NoError(t, ws.Detect())
+	require.Equal(t, models.TagSchemeProject, ws.Projects[0].TagScheme)
+
+	ws = New(fs, WithTagScheme("", map[string]models.TagScheme{"web": models.TagSchemeModule}))
+	require.ErrorContains(t, ws.Detect(), "only supported for Go projects")
+}
+
+func TestWorkspaceDetect_Config(t *testing.T) {
+	wb := NewWorkspaceBuilder(testWorkspaceRoot)
+	wb.AddProject("backend", "apps/backend", "github.com/test/backend")
+	wb.AddProject("legacy", "libs/legacy", "github.com/test/legacy")
+	wb.AddProject("example-api", "examples/api", "github.com/test/example-api")
+	fs := wb.Build()
+	fs.AddFile(filepath.Join(testWorkspaceRoot, ".changeset", "config.json"), []byte(`{
+		"ignore": ["example-*"],
+		"tagScheme": "module",
+		"projects": {"legacy": {"tagScheme": "project"}}
+	}`))
+
+	ws := New(fs)
+	require.NoError(t, ws.Detect())
+	require.NotNil(t, ws.Config)
+	require.Len(t, ws.Projects, 2)
+
+	_, err := ws.GetProject("example-api")
+	require.Error(t, err)
+
+	backend, err := ws.GetProject("backend")
+	require.NoError(t, err)
+	require.Equal(t, models.TagSchemeModule, backend.TagScheme)
+
+	legacy, err := ws.GetProject("legacy")
+	require.NoError(t, err)
+	require.Equal(t, models.TagSchemeProject, legacy.TagScheme)
+
+	// Flags take precedence over the config
+	ws = New(fs, WithTagScheme(models.TagSchemeProject, nil))
+	require.NoError(t, ws.Detect())
+	backend, err = ws.GetProject("backend")
+	require.NoError(t, err)
+	require.Equal(t, models.TagSchemeProject, backend.TagScheme)
+}
+
+func TestWorkspaceDetect_TagSchemePrecedence(t *testing.T) {
+	wb := NewWorkspaceBuilder(testWorkspaceRoot)
+	wb.AddProject("backend", "apps/backend", "github.com/test/backend")
+	wb.AddProject("legacy", "libs/legacy", "github.com/test/legacy")
+	fs := wb.Build()
+	fs.AddFile(filepath.Join(testWorkspaceRoot, ".changeset", "config.json"), []byte(`{
+		"projects": {"backend": {"tagScheme": "module"}, "legacy": {"tagScheme": "module"}}
+	}`))
+
+	schemes := func(opts ...Option) map[string]models.TagScheme {
+		t.Helper()
+		ws := New(fs, opts...)
+		require.NoError(t, ws.Detect())
+		result := make(map[string]models.TagScheme)
+		for _, p := range ws.Projects {
+			result[p.Name] = p.TagScheme
+		}
+		return result
+	}
+
+	require.Equal(t, map[string]models.TagScheme{"backend": models.TagSchemeModule, "legacy": models.TagSchemeModule}, schemes())
+
+	// The global flag overrides per-project config
+	require.Equal(t, map[string]models.TagScheme{"backend": models.TagSchemeProject, "legacy": models.TagSchemeProject},
+		schemes(WithTagScheme(models.TagSchemeProject, nil)))
+
+	// A per-project flag overrides the global flag
+	require.Equal(t, map[string]models.TagScheme{"backend": models.TagSchemeProject, "legacy": models.TagSchemeModule},
+		schemes(WithTagScheme(models.TagSchemeProject, map[string]models.TagScheme{"legacy": models.TagSchemeModule})))
+}
+
+func TestWorkspaceDetect_InvalidConfig(t *testing.T) {
+	wb := NewWorkspaceBuilder(testWorkspaceRoot)
+	wb.AddProject("backend", "apps/backend", "github.com/test/backend")
+	fs := wb.Build()
+	fs.AddFile(filepath.Join(testWorkspaceRoot, ".changeset", "config.json"), []byte(`{"tagSchema": "module"}`))
+
+	ws := New(fs)
+	require.ErrorContains(t, ws.Detect(), `unknown key "tagSchema"`)
+}
+
+func TestWorkspaceDetect_UnknownConfigProjects(t *testing.T) {
+	wb := NewWorkspaceBuilder(testWorkspaceRoot)
+	wb.AddProject("backend", "apps/backend", "github.com/test/backend")
+	wb.AddProject("example-cli", "examples/cli", "github.com/test/example-cli")
+	fs := wb.Build()
+	configPath := filepath.Join(testWorkspaceRoot, ".changeset", "config.json")
+
+	fs.AddFile(configPath, []byte(`{"projects": {"backend": {"tagScheme": "module"}, "frontend": {"tagScheme": "module"}}}`))
+	require.EqualError(t, New(fs).Detect(), "invalid "+configPath+": projects.frontend: no such project in the workspace")
+
+	fs.AddFile(configPath, []byte(`{"ignore": ["example-*", "legacy"]}`))
+	require.EqualError(t, New(fs).Detect(), "invalid "+configPath+`: ignore[1]: no project matches "legacy"`)
+
+	// Ignored projects may still have settings
+	fs.AddFile(configPath, []byte(`{"ignore": ["example-*"], "projects": {"example-cli": {"tagScheme": "module"}}}`))
+	ws := New(fs)
+	require.NoError(t, ws.Detect())
+	require.Len(t, ws.Projects, 1)
+}
+
+func TestWorkspaceDetect_VersionGroups(t *testing.T) {
+	wb := NewWorkspaceBuilder(testWorkspaceRoot)
+	wb.AddProject("api-client", "api/client", "github.com/test/api-client")
+	wb.AddProject("api-server", "api/server", "github.com/test/api-server")
+	wb.AddProject("web", "web", "github.com/test/web")
+	wb.AddProject("cli", "cli", "github.com/test/cli")
+	fs := wb.Build()
+	configPath := filepath.Join(testWorkspaceRoot, ".changeset", "config.json")
+	fs.AddFile(configPath, []byte(`{"fixed": [["api-*"]], "linked": [["cli", "web"]]}`))
+
+	ws := New(fs)
+	require.NoError(t, ws.Detect())
+
+	groups := ws.VersionGroups()
+	require.Len(t, groups, 2)
+	require.Equal(t, models.GroupKindFixed, groups[0].Kind)
+	require.Equal(t, []string{"api-client", "api-server"}, groups[0].Projects)
+	require.Equal(t, []string{"web", "cli"}, groups[1].Projects)
+
+	web, err := ws.GetProject("web")
+	require.NoError(t, err)
+	require.Same(t, groups[1], web.Group)
+
+	fs.AddFile(configPath, []byte(`{"fixed": [["api-*"]], "linked": [["api-client", "web"]]}`))
+	require.ErrorContains(t, New(fs).Detect(), "project api-client is in more than one version group")
+
+	fs.AddFile(configPath, []byte(`{"fixed": [["web", "mobile"]]}`))
+	require.ErrorContains(t, New(fs).Detect(), `fixed[0]: no project matches "mobile"`)
+}